@@ -26,29 +26,73 @@ import (
 // to create a chaos profile
 type ChaosEngineSpec struct {
 	//Appinfo contains the AUT details
+	// +kubebuilder:validation:XValidation:rule="has(self.appkind) == has(self.applabel)",message="appkind and applabel must be specified together"
 	Appinfo ApplicationParams `json:"appinfo,omitempty"`
 	//DefaultHealthCheck defines whether default health checks should be executed or not. It can be true or false
 	// default value is true
 	DefaultHealthCheck bool `json:"defaultHealthCheck,omitempty"`
 	//ChaosServiceAccount is the SvcAcc specified for chaos runner pods
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
 	ChaosServiceAccount string `json:"chaosServiceAccount"`
 	//Components contains the image, imagePullPolicy, arguments, and commands of runner
+	// The runner is defaulted from the operator config when the components are left out
+	// +optional
 	Components ComponentParams `json:"components"`
 	//Consists of experiments executed by the engine
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
 	Experiments []ExperimentList `json:"experiments"`
 	//JobCleanUpPolicy decides to retain or delete the jobs
 	JobCleanUpPolicy CleanUpPolicy `json:"jobCleanUpPolicy,omitempty"`
 	//AuxiliaryAppInfo contains details of dependent applications (infra chaos)
 	AuxiliaryAppInfo string `json:"auxiliaryAppInfo,omitempty"`
 	//EngineStatus is a requirement for validation
+	// An empty engineState is defaulted to active
+	// +optional
 	EngineState EngineState `json:"engineState"`
 	// TerminationGracePeriodSeconds contains terminationGracePeriod for the chaos resources
+	// +kubebuilder:validation:Minimum=0
 	TerminationGracePeriodSeconds int64 `json:"terminationGracePeriodSeconds,omitempty"`
 	// Selectors contains the target application details
+	// +kubebuilder:validation:XValidation:rule="has(self.pods) != has(self.workloads)",message="exactly one of pods or workloads must be specified"
 	Selectors *Selector `json:"selectors,omitempty"`
+	// TTLSecondsAfterFinished limits the lifetime of a ChaosEngine that has finished execution
+	// (completed or stopped). The ChaosEngine is deleted once the TTL expires after its completionTime
+	// +kubebuilder:validation:Minimum=0
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+	// Notifiers contains the webhooks which are notified on the lifecycle events of the ChaosEngine
+	Notifiers []NotifierSpec `json:"notifiers,omitempty"`
+}
+
+// NotifierSpec defines an http webhook which is notified on the lifecycle events of the ChaosEngine
+type NotifierSpec struct {
+	// Name of the notifier
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// URL of the webhook endpoint
+	URL string `json:"url,omitempty"`
+	// URLSecretRef selects the key of a secret containing the URL of the webhook endpoint, it takes precedence over URL
+	URLSecretRef *corev1.SecretKeySelector `json:"urlSecretRef,omitempty"`
+	// Events contains the reasons of the events to be notified, all the lifecycle events are notified if empty
+	// supported values: ChaosEngineInitialized, ChaosEngineCompleted, ChaosEngineStopped, RestartInProgress, ChaosResourcesOperationFailed
+	// +kubebuilder:validation:items:Enum=ChaosEngineInitialized;ChaosEngineCompleted;ChaosEngineStopped;RestartInProgress;ChaosResourcesOperationFailed
+	Events []string `json:"events,omitempty"`
+	// Template is the go template of the payload, rendered with the event. The event is sent as json if empty
+	Template string `json:"template,omitempty"`
+	// Headers contains the additional headers of the webhook request
+	Headers map[string]string `json:"headers,omitempty"`
+	// HMACSecretRef selects the key of a secret used to sign the payload with HMAC-SHA256,
+	// the signature is sent as sha256=<hex> in the X-Litmus-Signature header
+	HMACSecretRef *corev1.SecretKeySelector `json:"hmacSecretRef,omitempty"`
+	// Retries is the number of retries of a failed delivery, with exponential backoff. Defaults to 3,
+	// bounded by the notifier.maxRetries of the operator config
+	// +kubebuilder:validation:Minimum=0
+	Retries *int32 `json:"retries,omitempty"`
 }
 
 // EngineState provides interface for all supported strings in spec.EngineState
+// +kubebuilder:validation:Enum=active;stop
 type EngineState string
 
 const (
@@ -89,6 +133,7 @@ const (
 )
 
 // CleanUpPolicy defines the garbage collection method used by chaos-operator
+// +kubebuilder:validation:Enum=delete;retain
 type CleanUpPolicy string
 
 const (
@@ -103,9 +148,17 @@ const (
 // ChaosEngineStatus derives information about status of individual experiments
 type ChaosEngineStatus struct {
 	//EngineStatus is a typed string to support limited values for ChaosEngine Status
+	// +optional
 	EngineStatus EngineStatus `json:"engineStatus"`
 	//Detailed status of individual experiments
+	// +optional
 	Experiments []ExperimentStatuses `json:"experiments"`
+	//StartTime is the time at which the current run of the ChaosEngine was initialized
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+	//CompletionTime is the time at which the ChaosEngine was completed or stopped
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+	//ResilienceScore is the weighted average of the probe success percentages of the finished experiments
+	ResilienceScore string `json:"resilienceScore,omitempty"`
 }
 
 // ApplicationParams defines information about Application-Under-Test (AUT) on the cluster
@@ -116,6 +169,7 @@ type ApplicationParams struct {
 	//Unique label of the AUT
 	Applabel string `json:"applabel,omitempty"`
 	//kind of application
+	// +kubebuilder:validation:Pattern=`^(^$|deployment|statefulset|daemonset|deploymentconfig|rollout)$`
 	AppKind string `json:"appkind,omitempty"`
 }
 
@@ -124,6 +178,7 @@ type Selector struct {
 	Pods      []Pod      `json:"pods,omitempty"`
 }
 
+// +kubebuilder:validation:Enum=deployment;statefulset;daemonset;daemonSet;deploymentconfig;rollout
 type WorkloadKind string
 
 const (
@@ -134,21 +189,27 @@ const (
 	WorkloadRollout          WorkloadKind = "rollout"
 )
 
+// +kubebuilder:validation:XValidation:rule="has(self.names) != has(self.labels)",message="exactly one of names or labels must be specified"
 type Workload struct {
-	Kind      WorkloadKind `json:"kind"`
-	Namespace string       `json:"namespace"`
-	Names     string       `json:"names,omitempty"`
-	Labels    string       `json:"labels,omitempty"`
+	// +kubebuilder:validation:Required
+	Kind WorkloadKind `json:"kind"`
+	// +kubebuilder:validation:Required
+	Namespace string `json:"namespace"`
+	Names     string `json:"names,omitempty"`
+	Labels    string `json:"labels,omitempty"`
 }
 
 type Pod struct {
+	// +kubebuilder:validation:Required
 	Namespace string `json:"namespace"`
-	Names     string `json:"names"`
+	// +kubebuilder:validation:Required
+	Names string `json:"names"`
 }
 
 // ComponentParams defines information about the runner
 type ComponentParams struct {
 	//Contains information of the runner pod
+	// +optional
 	Runner RunnerInfo `json:"runner"`
 	// Contains information of the sidecar
 	Sidecar []Sidecar `json:"sidecar,omitempty"`
@@ -162,6 +223,7 @@ type Sidecar struct {
 	// Secrets for the sidecar container
 	Secrets []Secret `json:"secrets,omitempty"`
 	// EnvFrom for the sidecar container
+	// +optional
 	EnvFrom []corev1.EnvFromSource `json:"envFrom"`
 	// ENV contains ENV passed to the sidecar container
 	ENV []corev1.EnvVar `json:"env,omitempty"`
@@ -172,6 +234,7 @@ type RunnerInfo struct {
 	//Image of the runner pod
 	Image string `json:"image,omitempty"`
 	//Type of runner
+	// +kubebuilder:validation:Enum=go
 	Type string `json:"type,omitempty"`
 	//Args of runner
 	Args []string `json:"args,omitempty"`
@@ -201,15 +264,22 @@ type RunnerInfo struct {
 // These experiments are "pulled" as versioned charts from a "hub"
 type ExperimentList struct {
 	//Name of the chaos experiment
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
 	Name string `json:"name"`
 	//Holds properties of an experiment listed in the engine
+	// +optional
 	Spec ExperimentAttributes `json:"spec"`
 }
 
 // ExperimentAttributes defines attributes of experiments
 type ExperimentAttributes struct {
 	//Execution priority of the chaos experiment
+	// +optional
 	Rank uint32 `json:"rank"`
+	//Weight of the chaos experiment in the resilience score of the engine, defaults to 10
+	// +kubebuilder:validation:Minimum=0
+	Weight *uint32 `json:"weight,omitempty"`
 	// It contains env, configmaps, secrets, experimentImage, node selector, custom experiment annotation
 	// which can be provided or overridden from the chaos engine
 	Components ExperimentComponents `json:"components,omitempty"`
@@ -221,8 +291,11 @@ type ExperimentAttributes struct {
 // ProbeAttributes contains details of probe, which can be applied on the experiments
 type ProbeAttributes struct {
 	// Name of probe
+	// +kubebuilder:validation:Required
 	Name string `json:"name"`
 	// Type of probe
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=k8sProbe;httpProbe;cmdProbe;promProbe;sloProbe
 	Type string `json:"type"`
 	// inputs needed for the k8s probe
 	K8sProbeInputs *K8sProbeInputs `json:"k8sProbe/inputs,omitempty"`
@@ -235,9 +308,12 @@ type ProbeAttributes struct {
 	// inputs needed for the SLO probe
 	SLOProbeInputs *SLOProbeInputs `json:"sloProbe/inputs,omitempty"`
 	// RunProperty contains timeout, retry and interval for the probe
+	// +kubebuilder:validation:Required
 	RunProperties RunProperty `json:"runProperties"`
 	// mode for k8s probe
 	// it can be SOT, EOT, Edge
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=SOT;EOT;Edge;Continuous;OnChaos
 	Mode string `json:"mode"`
 	// Data contains the manifest/data for the resource, which need to be created
 	// it supported for create operation only
@@ -247,10 +323,13 @@ type ProbeAttributes struct {
 // K8sProbeInputs contains all the inputs required for k8s probe
 type K8sProbeInputs struct {
 	// group of the resource
+	// +kubebuilder:validation:Required
 	Group string `json:"group"`
 	// apiversion of the resource
+	// +kubebuilder:validation:Required
 	Version string `json:"version"`
 	// kind of resource
+	// +kubebuilder:validation:Required
 	Resource string `json:"resource"`
 	// ResourceNames to get the resources using their list of comma separated names
 	ResourceNames string `json:"resourceNames,omitempty"`
@@ -262,14 +341,19 @@ type K8sProbeInputs struct {
 	LabelSelector string `json:"labelSelector,omitempty"`
 	// Operation performed by the k8s probe
 	// it can be create, delete, present, absent
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=present;absent;create;delete
 	Operation string `json:"operation"`
 }
 
 // CmdProbeInputs contains all the inputs required for cmd probe
 type CmdProbeInputs struct {
 	// Command need to be executed for the probe
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
 	Command string `json:"command"`
 	// Comparator check for the correctness of the probe output
+	// +kubebuilder:validation:Required
 	Comparator ComparatorInfo `json:"comparator"`
 	// The source where we have to run the command
 	// It will run in inline(inside experiment itself) mode if source is nil
@@ -279,6 +363,7 @@ type CmdProbeInputs struct {
 // SourceDetails contains source details of the cmdProbe
 type SourceDetails struct {
 	// Image for the source pod
+	// +kubebuilder:validation:Required
 	Image string `json:"image"`
 	// HostNetwork define the hostNetwork of the external pod
 	// it supports boolean values and default value is false
@@ -313,28 +398,34 @@ type SourceDetails struct {
 // PromProbeInputs contains all the inputs required for prometheus probe
 type PromProbeInputs struct {
 	// Endpoint for the prometheus probe
+	// +kubebuilder:validation:Required
 	Endpoint string `json:"endpoint"`
 	// Query to get promethus metrics
 	Query string `json:"query,omitempty"`
 	// QueryPath contains filePath, which contains prometheus query
 	QueryPath string `json:"queryPath,omitempty"`
 	// Comparator check for the correctness of the probe output
+	// +kubebuilder:validation:Required
 	Comparator ComparatorInfo `json:"comparator"`
 }
 
 // SLOProbeInputs contains all the inputs required for SLO probe
 type SLOProbeInputs struct {
 	// PlatformEndpoint for the monitoring service endpoint
+	// +kubebuilder:validation:Required
 	PlatformEndpoint string `json:"platformEndpoint"`
 	// SLOIdentifier for fetching the details of the SLO
+	// +kubebuilder:validation:Required
 	SLOIdentifier string `json:"sloIdentifier"`
 	// InsecureSkipVerify flag to skip certificate checks
 	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
 	// EvaluationWindow is the time period for which the metrics will be evaluated
 	EvaluationWindow *EvaluationWindow `json:"evaluationWindow,omitempty"`
 	// SLOSourceMetadata consists of required metadata details to fetch metric data
+	// +kubebuilder:validation:Required
 	SLOSourceMetadata SLOSourceMetadata `json:"sloSourceMetadata"`
 	// Comparator check for the correctness of the probe output
+	// +kubebuilder:validation:Required
 	Comparator ComparatorInfo `json:"comparator"`
 }
 
@@ -348,18 +439,23 @@ type EvaluationWindow struct {
 
 type SLOSourceMetadata struct {
 	// APITokenSecret for authenticating with the platform service
+	// +kubebuilder:validation:Required
 	APITokenSecret string `json:"apiTokenSecret"`
 	// Scope required for fetching details
+	// +kubebuilder:validation:Required
 	Scope Identifier `json:"scope"`
 }
 
 // Identifier required for fetching details from the Platform APIs
 type Identifier struct {
 	// AccountIdentifier for account ID
+	// +kubebuilder:validation:Required
 	AccountIdentifier string `json:"accountIdentifier"`
 	// OrgIdentifier for organization ID
+	// +kubebuilder:validation:Required
 	OrgIdentifier string `json:"orgIdentifier"`
 	// ProjectIdentifier for project ID
+	// +kubebuilder:validation:Required
 	ProjectIdentifier string `json:"projectIdentifier"`
 }
 
@@ -367,26 +463,33 @@ type Identifier struct {
 type ComparatorInfo struct {
 	// Type of data
 	// it can be int, float, string
+	// +kubebuilder:validation:Enum=int;float;string
 	Type string `json:"type,omitempty"`
 	// Criteria for matching data
 	// it supports >=, <=, ==, >, <, != for int and float
 	// it supports equal, notEqual, contains for string
+	// +kubebuilder:validation:Required
 	Criteria string `json:"criteria"`
 	// Value contains relative value for criteria
+	// +kubebuilder:validation:Required
 	Value string `json:"value"`
 }
 
 // HTTPProbeInputs contains all the inputs required for http probe
 type HTTPProbeInputs struct {
 	// URL which needs to curl, to check the status
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
 	URL string `json:"url"`
 	// InsecureSkipVerify flag to skip certificate checks
 	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
 	// Method define the http method, it can be get or post
+	// +kubebuilder:validation:Required
 	Method HTTPMethod `json:"method"`
 }
 
 // HTTPMethod define the http method details
+// +kubebuilder:validation:MinProperties=1
 type HTTPMethod struct {
 	Get  *GetMethod  `json:"get,omitempty"`
 	Post *PostMethod `json:"post,omitempty"`
@@ -396,8 +499,12 @@ type HTTPMethod struct {
 type GetMethod struct {
 	// Criteria for matching data
 	// it supports  == != operations
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
 	Criteria string `json:"criteria"`
 	// Value contains relative value for criteria
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
 	ResponseCode string `json:"responseCode"`
 }
 
@@ -411,16 +518,24 @@ type PostMethod struct {
 	BodyPath string `json:"bodyPath,omitempty"`
 	// Criteria for matching data
 	// it supports  == != operations
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
 	Criteria string `json:"criteria"`
 	// Value contains relative value for criteria
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
 	ResponseCode string `json:"responseCode"`
 }
 
 // RunProperty contains timeout, retry and interval for the probe
 type RunProperty struct {
 	//ProbeTimeout contains timeout for the probe
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^([0-9]+|([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+)$`
 	ProbeTimeout string `json:"probeTimeout"`
 	// Interval contains the interval for the probe
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^([0-9]+|([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+)$`
 	Interval string `json:"interval"`
 	// Retry contains the retry count for the probe
 	Retry int `json:"retry,omitempty"`
@@ -428,11 +543,14 @@ type RunProperty struct {
 	Attempt int `json:"attempt,omitempty"`
 	//ProbePollingInterval contains time interval, for which continuous probe should be sleep
 	// after each iteration
+	// +kubebuilder:validation:Pattern=`^([0-9]+|([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+)$`
 	ProbePollingInterval string `json:"probePollingInterval,omitempty"`
 	//InitialDelaySeconds time interval for which probe will wait before run
+	// +kubebuilder:validation:Pattern=`^([0-9]+|([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+)$`
 	InitialDelay string `json:"initialDelay,omitempty"`
 	// EvaluationTimeout is the timeout window in which the SLO metrics
 	// will be fetched and will be evaluated
+	// +kubebuilder:validation:Pattern=`^([0-9]+|([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+)$`
 	EvaluationTimeout string `json:"evaluationTimeout,omitempty"`
 	// StopOnFailure contains flag to stop/continue experiment execution, if probe fails
 	// it will stop the experiment execution, if provided true
@@ -464,16 +582,26 @@ type StatusCheckTimeout struct {
 // These fields are immutable, and are derived by kubernetes(operator)
 type ExperimentStatuses struct {
 	//Name of the chaos experiment
+	// +optional
 	Name string `json:"name"`
 	//Name of chaos-runner pod managing this experiment
+	// +optional
 	Runner string `json:"runner"`
 	//Name of experiment pod executing the chaos
+	// +optional
 	ExpPod string `json:"experimentPod"`
 	//Current state of chaos experiment
+	// +optional
 	Status ExperimentStatus `json:"status"`
 	//Result of a completed chaos experiment
+	// +optional
 	Verdict string `json:"verdict"`
+	//Phase of the chaosresult, mirrored from the chaosresult of the experiment
+	ResultPhase ResultPhase `json:"resultPhase,omitempty"`
+	//ProbeSuccessPercentage of the experiment, mirrored from the chaosresult of the experiment
+	ProbeSuccessPercentage string `json:"probeSuccessPercentage,omitempty"`
 	//Time of last state change of chaos experiment
+	// +optional
 	LastUpdateTime metav1.Time `json:"lastUpdateTime"`
 }
 
@@ -481,6 +609,12 @@ type ExperimentStatuses struct {
 // +resource:path=chaosengine
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:storageversion
+//+kubebuilder:resource:shortName=ce
+//+kubebuilder:printcolumn:name="State",type=string,JSONPath=`.spec.engineState`
+//+kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.engineStatus`
+//+kubebuilder:printcolumn:name="Score",type=string,JSONPath=`.status.resilienceScore`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // ChaosEngine is the Schema for the chaosengines API
 type ChaosEngine struct {
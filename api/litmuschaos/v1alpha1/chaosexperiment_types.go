@@ -27,6 +27,9 @@ import (
 // in the chaos engine to be run against a given app.
 type ChaosExperimentSpec struct {
 	// Definition carries low-level chaos options
+	// The unknown fields are preserved, for the definitions of the other versions of the chaoshub
+	// +kubebuilder:validation:Required
+	// +kubebuilder:pruning:PreserveUnknownFields
 	Definition ExperimentDef `json:"definition"`
 }
 
@@ -38,23 +41,37 @@ type ChaosExperimentStatus struct {
 
 // ConfigMap is an simpler implementation of corev1.ConfigMaps, needed for experiments
 type ConfigMap struct {
-	Data      map[string]string `json:"data,omitempty"`
-	Name      string            `json:"name"`
-	MountPath string            `json:"mountPath"`
+	Data map[string]string `json:"data,omitempty"`
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	MountPath string `json:"mountPath"`
 }
 
 // Secret is an simpler implementation of corev1.Secret
 type Secret struct {
-	Name      string `json:"name"`
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
 	MountPath string `json:"mountPath"`
 }
 
 // HostFile is an simpler implementation of corev1.HostPath, needed for experiments
 type HostFile struct {
-	Name      string              `json:"name"`
-	MountPath string              `json:"mountPath"`
-	NodePath  string              `json:"nodePath"`
-	Type      corev1.HostPathType `json:"type,omitempty"`
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	MountPath string `json:"mountPath"`
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	NodePath string              `json:"nodePath"`
+	Type     corev1.HostPathType `json:"type,omitempty"`
 }
 
 // ExperimentDef defines information about nature of chaos & components subjected to it
@@ -67,14 +84,19 @@ type ExperimentDef struct {
 	// ImagePullPolicy of the chaos experiment container
 	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
 	//Scope specifies the service account scope (& thereby blast radius) of the experiment
+	// +kubebuilder:validation:Enum=Namespaced;Cluster
 	Scope string `json:"scope"`
 	// List of Permission needed for a service account to execute experiment
+	// +optional
 	Permissions []rbacV1.PolicyRule `json:"permissions"`
 	// List of ENV vars passed to executor pod
+	// +optional
 	ENVList []corev1.EnvVar `json:"env"`
 	// Defines command to invoke experiment
+	// +optional
 	Command []string `json:"command"`
 	// Defines arguments to runner's entrypoint command
+	// +optional
 	Args []string `json:"args"`
 	// ConfigMaps contains a list of ConfigMaps
 	ConfigMaps []ConfigMap `json:"configMaps,omitempty"`
@@ -85,6 +107,7 @@ type ExperimentDef struct {
 	// Annotations that needs to be provided in the pod for pod that is getting created
 	ExperimentAnnotations map[string]string `json:"experimentAnnotations,omitempty"`
 	// SecurityContext holds security configuration that will be applied to a container
+	// +kubebuilder:pruning:PreserveUnknownFields
 	SecurityContext SecurityContext `json:"securityContext,omitempty"`
 	// HostPID is need to be provided in the chaospod
 	HostPID bool `json:"hostPID,omitempty"`
@@ -99,7 +122,9 @@ type SecurityContext struct {
 }
 
 //+kubebuilder:object:root=true
-//+kubebuilder:subresource:status
+//+kubebuilder:resource:shortName=cex
+//+kubebuilder:printcolumn:name="Image",type=string,JSONPath=`.spec.definition.image`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +genclient
 // +resource:path=chaosexperiment
 
@@ -107,6 +132,8 @@ type SecurityContext struct {
 type ChaosExperiment struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
+	// Description contains the details of the experiment, as published in the chaoshub
+	Description map[string]string `json:"description,omitempty"`
 
 	Spec   ChaosExperimentSpec   `json:"spec,omitempty"`
 	Status ChaosExperimentStatus `json:"status,omitempty"`
@@ -78,6 +78,7 @@ const (
 // ChaosResultStatus defines the observed state of ChaosResult
 type ChaosResultStatus struct {
 	// ExperimentStatus contains the status,verdict of the experiment
+	// +optional
 	ExperimentStatus TestStatus `json:"experimentStatus"`
 	// ProbeStatus contains the status of the probe
 	ProbeStatuses []ProbeStatuses `json:"probeStatuses,omitempty"`
@@ -87,8 +88,11 @@ type ChaosResultStatus struct {
 
 // HistoryDetails contains cumulative values of verdicts
 type HistoryDetails struct {
-	PassedRuns  int             `json:"passedRuns"`
-	FailedRuns  int             `json:"failedRuns"`
+	// +optional
+	PassedRuns int `json:"passedRuns"`
+	// +optional
+	FailedRuns int `json:"failedRuns"`
+	// +optional
 	StoppedRuns int             `json:"stoppedRuns"`
 	Targets     []TargetDetails `json:"targets,omitempty"`
 }
@@ -123,8 +127,10 @@ type ProbeStatus struct {
 // TestStatus defines information about the status and results of a chaos experiment
 type TestStatus struct {
 	// Phase defines whether an experiment is running or completed
+	// +optional
 	Phase ResultPhase `json:"phase"`
 	// Verdict defines whether an experiment result is pass or fail
+	// +optional
 	Verdict ResultVerdict `json:"verdict"`
 	// ErrorOutput defines error message and error code
 	ErrorOutput *ErrorOutput `json:"errorOutput,omitempty"`
@@ -141,7 +147,12 @@ type ErrorOutput struct {
 }
 
 //+kubebuilder:object:root=true
-//+kubebuilder:subresource:status
+//+kubebuilder:resource:shortName=cres
+//+kubebuilder:printcolumn:name="Engine",type=string,JSONPath=`.spec.engine`
+//+kubebuilder:printcolumn:name="Experiment",type=string,JSONPath=`.spec.experiment`
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.experimentStatus.phase`
+//+kubebuilder:printcolumn:name="Verdict",type=string,JSONPath=`.status.experimentStatus.verdict`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 // +genclient
 // +resource:path=chaosresult
 
@@ -150,7 +161,11 @@ type ChaosResult struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec   ChaosResultSpec   `json:"spec,omitempty"`
+	// the chaosresults are written by the experiments, the unknown fields are preserved
+	// for the other versions of the experiments
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Spec ChaosResultSpec `json:"spec,omitempty"`
+	// +kubebuilder:pruning:PreserveUnknownFields
 	Status ChaosResultStatus `json:"status,omitempty"`
 }
 
@@ -118,6 +118,18 @@ func (in *ChaosEngineSpec) DeepCopyInto(out *ChaosEngineSpec) {
 		*out = new(Selector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Notifiers != nil {
+		in, out := &in.Notifiers, &out.Notifiers
+		*out = make([]NotifierSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChaosEngineSpec.
@@ -140,6 +152,14 @@ func (in *ChaosEngineStatus) DeepCopyInto(out *ChaosEngineStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChaosEngineStatus.
@@ -157,6 +177,13 @@ func (in *ChaosExperiment) DeepCopyInto(out *ChaosExperiment) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	in.Spec.DeepCopyInto(&out.Spec)
 	out.Status = in.Status
 }
@@ -456,6 +483,11 @@ func (in *EvaluationWindow) DeepCopy() *EvaluationWindow {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExperimentAttributes) DeepCopyInto(out *ExperimentAttributes) {
 	*out = *in
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(uint32)
+		**out = **in
+	}
 	in.Components.DeepCopyInto(&out.Components)
 	if in.Probe != nil {
 		in, out := &in.Probe, &out.Probe
@@ -762,6 +794,48 @@ func (in *K8sProbeInputs) DeepCopy() *K8sProbeInputs {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotifierSpec) DeepCopyInto(out *NotifierSpec) {
+	*out = *in
+	if in.URLSecretRef != nil {
+		in, out := &in.URLSecretRef, &out.URLSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.HMACSecretRef != nil {
+		in, out := &in.HMACSecretRef, &out.HMACSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotifierSpec.
+func (in *NotifierSpec) DeepCopy() *NotifierSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NotifierSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Pod) DeepCopyInto(out *Pod) {
 	*out = *in
@@ -818,7 +892,7 @@ func (in *ProbeAttributes) DeepCopyInto(out *ProbeAttributes) {
 	if in.SLOProbeInputs != nil {
 		in, out := &in.SLOProbeInputs, &out.SLOProbeInputs
 		*out = new(SLOProbeInputs)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	out.RunProperties = in.RunProperties
 }
@@ -969,7 +1043,11 @@ func (in *RunnerInfo) DeepCopy() *RunnerInfo {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SLOProbeInputs) DeepCopyInto(out *SLOProbeInputs) {
 	*out = *in
-	out.EvaluationWindow = in.EvaluationWindow
+	if in.EvaluationWindow != nil {
+		in, out := &in.EvaluationWindow, &out.EvaluationWindow
+		*out = new(EvaluationWindow)
+		**out = **in
+	}
 	out.SLOSourceMetadata = in.SLOSourceMetadata
 	out.Comparator = in.Comparator
 }
@@ -0,0 +1,650 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// OriginalsAnnotation keeps the v1alpha1 values which are not restored from their v1beta1 values, keyed by their path,
+// i.e. the probe durations in the number of seconds used by the older chaos-runners, the selector names which are
+// not comma separated as by the v1beta1 names, and the unparseable durations and selector labels
+const OriginalsAnnotation = "litmuschaos.io/v1alpha1-originals"
+
+// originals contains the original v1alpha1 values, keyed by their path
+type originals map[string]string
+
+// ConvertTo converts the v1beta1 ChaosEngine to the v1alpha1 (hub) version
+func (src *ChaosEngine) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*v1alpha1.ChaosEngine)
+	if !ok {
+		return fmt.Errorf("unsupported conversion of chaosengine to %T", dstRaw)
+	}
+	dst.ObjectMeta = src.ObjectMeta
+
+	// the original values are restored into the hub, which does not carry the annotation
+	original := originals{}
+	if value, found := src.Annotations[OriginalsAnnotation]; found {
+		_ = json.Unmarshal([]byte(value), &original)
+		dst.Annotations = map[string]string{}
+		for k, v := range src.Annotations {
+			if k != OriginalsAnnotation {
+				dst.Annotations[k] = v
+			}
+		}
+		if len(dst.Annotations) == 0 {
+			dst.Annotations = nil
+		}
+	}
+
+	spec := &src.Spec
+	dst.Spec = v1alpha1.ChaosEngineSpec{
+		Appinfo:                       v1alpha1.ApplicationParams(spec.Appinfo),
+		DefaultHealthCheck:            spec.DefaultHealthCheck,
+		ChaosServiceAccount:           spec.ChaosServiceAccount,
+		Components:                    componentsToHub(spec.Components),
+		JobCleanUpPolicy:              v1alpha1.CleanUpPolicy(spec.JobCleanUpPolicy),
+		AuxiliaryAppInfo:              spec.AuxiliaryAppInfo,
+		EngineState:                   v1alpha1.EngineState(spec.EngineState),
+		TerminationGracePeriodSeconds: spec.TerminationGracePeriodSeconds,
+		TTLSecondsAfterFinished:       spec.TTLSecondsAfterFinished,
+	}
+	if spec.Experiments != nil {
+		dst.Spec.Experiments = make([]v1alpha1.ExperimentList, len(spec.Experiments))
+		for i := range spec.Experiments {
+			dst.Spec.Experiments[i] = experimentToHub(spec.Experiments[i], fmt.Sprintf("spec.experiments[%d]", i), original)
+		}
+	}
+	if spec.Selectors != nil {
+		selectors, err := selectorToHub(*spec.Selectors, original)
+		if err != nil {
+			return err
+		}
+		dst.Spec.Selectors = &selectors
+	}
+	for _, notifier := range spec.Notifiers {
+		dst.Spec.Notifiers = append(dst.Spec.Notifiers, v1alpha1.NotifierSpec(notifier))
+	}
+
+	status := &src.Status
+	dst.Status = v1alpha1.ChaosEngineStatus{
+		EngineStatus:    v1alpha1.EngineStatus(status.EngineStatus),
+		StartTime:       status.StartTime,
+		CompletionTime:  status.CompletionTime,
+		ResilienceScore: status.ResilienceScore,
+	}
+	if status.Experiments != nil {
+		dst.Status.Experiments = make([]v1alpha1.ExperimentStatuses, len(status.Experiments))
+		for i, experiment := range status.Experiments {
+			dst.Status.Experiments[i] = v1alpha1.ExperimentStatuses{
+				Name:                   experiment.Name,
+				Runner:                 experiment.Runner,
+				ExpPod:                 experiment.ExpPod,
+				Status:                 v1alpha1.ExperimentStatus(experiment.Status),
+				Verdict:                string(experiment.Verdict),
+				ResultPhase:            v1alpha1.ResultPhase(experiment.ResultPhase),
+				ProbeSuccessPercentage: experiment.ProbeSuccessPercentage,
+				LastUpdateTime:         experiment.LastUpdateTime,
+			}
+		}
+	}
+	return nil
+}
+
+// ConvertFrom converts the v1alpha1 (hub) ChaosEngine to the v1beta1 version
+func (dst *ChaosEngine) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*v1alpha1.ChaosEngine)
+	if !ok {
+		return fmt.Errorf("unsupported conversion of chaosengine from %T", srcRaw)
+	}
+	dst.ObjectMeta = src.ObjectMeta
+
+	spec := &src.Spec
+	dst.Spec = ChaosEngineSpec{
+		Appinfo:                       ApplicationParams(spec.Appinfo),
+		DefaultHealthCheck:            spec.DefaultHealthCheck,
+		ChaosServiceAccount:           spec.ChaosServiceAccount,
+		Components:                    componentsFromHub(spec.Components),
+		JobCleanUpPolicy:              CleanUpPolicy(spec.JobCleanUpPolicy),
+		AuxiliaryAppInfo:              spec.AuxiliaryAppInfo,
+		EngineState:                   EngineState(spec.EngineState),
+		TerminationGracePeriodSeconds: spec.TerminationGracePeriodSeconds,
+		TTLSecondsAfterFinished:       spec.TTLSecondsAfterFinished,
+	}
+	// the values which are not restored from their v1beta1 values are carried in the annotation
+	original := originals{}
+	if spec.Experiments != nil {
+		dst.Spec.Experiments = make([]ExperimentList, len(spec.Experiments))
+		for i := range spec.Experiments {
+			dst.Spec.Experiments[i] = experimentFromHub(spec.Experiments[i], fmt.Sprintf("spec.experiments[%d]", i), original)
+		}
+	}
+	if spec.Selectors != nil {
+		selectors := selectorFromHub(*spec.Selectors, original)
+		dst.Spec.Selectors = &selectors
+	}
+	if len(original) != 0 {
+		value, err := json.Marshal(original)
+		if err != nil {
+			return err
+		}
+		dst.Annotations = map[string]string{OriginalsAnnotation: string(value)}
+		for k, v := range src.Annotations {
+			if k != OriginalsAnnotation {
+				dst.Annotations[k] = v
+			}
+		}
+	}
+	for _, notifier := range spec.Notifiers {
+		dst.Spec.Notifiers = append(dst.Spec.Notifiers, NotifierSpec(notifier))
+	}
+
+	status := &src.Status
+	dst.Status = ChaosEngineStatus{
+		EngineStatus:    EngineStatus(status.EngineStatus),
+		StartTime:       status.StartTime,
+		CompletionTime:  status.CompletionTime,
+		ResilienceScore: status.ResilienceScore,
+	}
+	if status.Experiments != nil {
+		dst.Status.Experiments = make([]ExperimentStatuses, len(status.Experiments))
+		for i, experiment := range status.Experiments {
+			dst.Status.Experiments[i] = ExperimentStatuses{
+				Name:                   experiment.Name,
+				Runner:                 experiment.Runner,
+				ExpPod:                 experiment.ExpPod,
+				Status:                 ExperimentStatus(experiment.Status),
+				Verdict:                ResultVerdict(experiment.Verdict),
+				ResultPhase:            ResultPhase(experiment.ResultPhase),
+				ProbeSuccessPercentage: experiment.ProbeSuccessPercentage,
+				LastUpdateTime:         experiment.LastUpdateTime,
+			}
+		}
+	}
+	return nil
+}
+
+func componentsToHub(in ComponentParams) v1alpha1.ComponentParams {
+	out := v1alpha1.ComponentParams{
+		Runner: v1alpha1.RunnerInfo{
+			Image:            in.Runner.Image,
+			Type:             in.Runner.Type,
+			Args:             in.Runner.Args,
+			Command:          in.Runner.Command,
+			ImagePullPolicy:  in.Runner.ImagePullPolicy,
+			ImagePullSecrets: in.Runner.ImagePullSecrets,
+			RunnerAnnotation: in.Runner.RunnerAnnotation,
+			RunnerLabels:     in.Runner.RunnerLabels,
+			NodeSelector:     in.Runner.NodeSelector,
+			ConfigMaps:       configMapsToHub(in.Runner.ConfigMaps),
+			Secrets:          secretsToHub(in.Runner.Secrets),
+			Tolerations:      in.Runner.Tolerations,
+			Resources:        in.Runner.Resources,
+		},
+	}
+	if in.Sidecar != nil {
+		out.Sidecar = make([]v1alpha1.Sidecar, len(in.Sidecar))
+		for i, sidecar := range in.Sidecar {
+			out.Sidecar[i] = v1alpha1.Sidecar{
+				Image:           sidecar.Image,
+				ImagePullPolicy: sidecar.ImagePullPolicy,
+				Secrets:         secretsToHub(sidecar.Secrets),
+				EnvFrom:         sidecar.EnvFrom,
+				ENV:             sidecar.ENV,
+			}
+		}
+	}
+	return out
+}
+
+func componentsFromHub(in v1alpha1.ComponentParams) ComponentParams {
+	out := ComponentParams{
+		Runner: RunnerInfo{
+			Image:            in.Runner.Image,
+			Type:             in.Runner.Type,
+			Args:             in.Runner.Args,
+			Command:          in.Runner.Command,
+			ImagePullPolicy:  in.Runner.ImagePullPolicy,
+			ImagePullSecrets: in.Runner.ImagePullSecrets,
+			RunnerAnnotation: in.Runner.RunnerAnnotation,
+			RunnerLabels:     in.Runner.RunnerLabels,
+			NodeSelector:     in.Runner.NodeSelector,
+			ConfigMaps:       configMapsFromHub(in.Runner.ConfigMaps),
+			Secrets:          secretsFromHub(in.Runner.Secrets),
+			Tolerations:      in.Runner.Tolerations,
+			Resources:        in.Runner.Resources,
+		},
+	}
+	if in.Sidecar != nil {
+		out.Sidecar = make([]Sidecar, len(in.Sidecar))
+		for i, sidecar := range in.Sidecar {
+			out.Sidecar[i] = Sidecar{
+				Image:           sidecar.Image,
+				ImagePullPolicy: sidecar.ImagePullPolicy,
+				Secrets:         secretsFromHub(sidecar.Secrets),
+				EnvFrom:         sidecar.EnvFrom,
+				ENV:             sidecar.ENV,
+			}
+		}
+	}
+	return out
+}
+
+func configMapsToHub(in []ConfigMap) []v1alpha1.ConfigMap {
+	if in == nil {
+		return nil
+	}
+	out := make([]v1alpha1.ConfigMap, len(in))
+	for i := range in {
+		out[i] = v1alpha1.ConfigMap(in[i])
+	}
+	return out
+}
+
+func configMapsFromHub(in []v1alpha1.ConfigMap) []ConfigMap {
+	if in == nil {
+		return nil
+	}
+	out := make([]ConfigMap, len(in))
+	for i := range in {
+		out[i] = ConfigMap(in[i])
+	}
+	return out
+}
+
+func secretsToHub(in []Secret) []v1alpha1.Secret {
+	if in == nil {
+		return nil
+	}
+	out := make([]v1alpha1.Secret, len(in))
+	for i := range in {
+		out[i] = v1alpha1.Secret(in[i])
+	}
+	return out
+}
+
+func secretsFromHub(in []v1alpha1.Secret) []Secret {
+	if in == nil {
+		return nil
+	}
+	out := make([]Secret, len(in))
+	for i := range in {
+		out[i] = Secret(in[i])
+	}
+	return out
+}
+
+func experimentToHub(in ExperimentList, path string, original originals) v1alpha1.ExperimentList {
+	components := in.Spec.Components
+	out := v1alpha1.ExperimentList{
+		Name: in.Name,
+		Spec: v1alpha1.ExperimentAttributes{
+			Rank:   in.Spec.Rank,
+			Weight: in.Spec.Weight,
+			Components: v1alpha1.ExperimentComponents{
+				ENV:                        components.ENV,
+				ConfigMaps:                 configMapsToHub(components.ConfigMaps),
+				Secrets:                    secretsToHub(components.Secrets),
+				ExperimentAnnotations:      components.ExperimentAnnotations,
+				ExperimentImage:            components.ExperimentImage,
+				ExperimentImagePullSecrets: components.ExperimentImagePullSecrets,
+				NodeSelector:               components.NodeSelector,
+				StatusCheckTimeouts:        v1alpha1.StatusCheckTimeout(components.StatusCheckTimeouts),
+				Resources:                  components.Resources,
+				Tolerations:                components.Tolerations,
+			},
+		},
+	}
+	if in.Spec.Probe != nil {
+		out.Spec.Probe = make([]v1alpha1.ProbeAttributes, len(in.Spec.Probe))
+		for i, probe := range in.Spec.Probe {
+			out.Spec.Probe[i] = v1alpha1.ProbeAttributes{
+				Name:          probe.Name,
+				Type:          string(probe.Type),
+				RunProperties: runPropertyToHub(probe.RunProperties, fmt.Sprintf("%v.spec.probe[%d].runProperties", path, i), original),
+				Mode:          string(probe.Mode),
+				Data:          probe.Data,
+			}
+			if probe.K8sProbeInputs != nil {
+				inputs := v1alpha1.K8sProbeInputs(*probe.K8sProbeInputs)
+				out.Spec.Probe[i].K8sProbeInputs = &inputs
+			}
+			if probe.HTTPProbeInputs != nil {
+				inputs := v1alpha1.HTTPProbeInputs{
+					URL:                probe.HTTPProbeInputs.URL,
+					InsecureSkipVerify: probe.HTTPProbeInputs.InsecureSkipVerify,
+				}
+				if method := probe.HTTPProbeInputs.Method.Get; method != nil {
+					inputs.Method.Get = (*v1alpha1.GetMethod)(method)
+				}
+				if method := probe.HTTPProbeInputs.Method.Post; method != nil {
+					inputs.Method.Post = (*v1alpha1.PostMethod)(method)
+				}
+				out.Spec.Probe[i].HTTPProbeInputs = &inputs
+			}
+			if probe.CmdProbeInputs != nil {
+				out.Spec.Probe[i].CmdProbeInputs = &v1alpha1.CmdProbeInputs{
+					Command:    probe.CmdProbeInputs.Command,
+					Comparator: v1alpha1.ComparatorInfo(probe.CmdProbeInputs.Comparator),
+					Source:     (*v1alpha1.SourceDetails)(probe.CmdProbeInputs.Source),
+				}
+			}
+			if probe.PromProbeInputs != nil {
+				out.Spec.Probe[i].PromProbeInputs = &v1alpha1.PromProbeInputs{
+					Endpoint:   probe.PromProbeInputs.Endpoint,
+					Query:      probe.PromProbeInputs.Query,
+					QueryPath:  probe.PromProbeInputs.QueryPath,
+					Comparator: v1alpha1.ComparatorInfo(probe.PromProbeInputs.Comparator),
+				}
+			}
+			if probe.SLOProbeInputs != nil {
+				out.Spec.Probe[i].SLOProbeInputs = &v1alpha1.SLOProbeInputs{
+					PlatformEndpoint:   probe.SLOProbeInputs.PlatformEndpoint,
+					SLOIdentifier:      probe.SLOProbeInputs.SLOIdentifier,
+					InsecureSkipVerify: probe.SLOProbeInputs.InsecureSkipVerify,
+					EvaluationWindow:   (*v1alpha1.EvaluationWindow)(probe.SLOProbeInputs.EvaluationWindow),
+					SLOSourceMetadata: v1alpha1.SLOSourceMetadata{
+						APITokenSecret: probe.SLOProbeInputs.SLOSourceMetadata.APITokenSecret,
+						Scope:          v1alpha1.Identifier(probe.SLOProbeInputs.SLOSourceMetadata.Scope),
+					},
+					Comparator: v1alpha1.ComparatorInfo(probe.SLOProbeInputs.Comparator),
+				}
+			}
+		}
+	}
+	return out
+}
+
+func experimentFromHub(in v1alpha1.ExperimentList, path string, original originals) ExperimentList {
+	components := in.Spec.Components
+	out := ExperimentList{
+		Name: in.Name,
+		Spec: ExperimentAttributes{
+			Rank:   in.Spec.Rank,
+			Weight: in.Spec.Weight,
+			Components: ExperimentComponents{
+				ENV:                        components.ENV,
+				ConfigMaps:                 configMapsFromHub(components.ConfigMaps),
+				Secrets:                    secretsFromHub(components.Secrets),
+				ExperimentAnnotations:      components.ExperimentAnnotations,
+				ExperimentImage:            components.ExperimentImage,
+				ExperimentImagePullSecrets: components.ExperimentImagePullSecrets,
+				NodeSelector:               components.NodeSelector,
+				StatusCheckTimeouts:        StatusCheckTimeout(components.StatusCheckTimeouts),
+				Resources:                  components.Resources,
+				Tolerations:                components.Tolerations,
+			},
+		},
+	}
+	if in.Spec.Probe != nil {
+		out.Spec.Probe = make([]ProbeAttributes, len(in.Spec.Probe))
+		for i, probe := range in.Spec.Probe {
+			out.Spec.Probe[i] = ProbeAttributes{
+				Name:          probe.Name,
+				Type:          ProbeType(probe.Type),
+				RunProperties: runPropertyFromHub(probe.RunProperties, fmt.Sprintf("%v.spec.probe[%d].runProperties", path, i), original),
+				Mode:          ProbeMode(probe.Mode),
+				Data:          probe.Data,
+			}
+			if probe.K8sProbeInputs != nil {
+				inputs := K8sProbeInputs(*probe.K8sProbeInputs)
+				out.Spec.Probe[i].K8sProbeInputs = &inputs
+			}
+			if probe.HTTPProbeInputs != nil {
+				inputs := HTTPProbeInputs{
+					URL:                probe.HTTPProbeInputs.URL,
+					InsecureSkipVerify: probe.HTTPProbeInputs.InsecureSkipVerify,
+				}
+				if method := probe.HTTPProbeInputs.Method.Get; method != nil {
+					inputs.Method.Get = (*GetMethod)(method)
+				}
+				if method := probe.HTTPProbeInputs.Method.Post; method != nil {
+					inputs.Method.Post = (*PostMethod)(method)
+				}
+				out.Spec.Probe[i].HTTPProbeInputs = &inputs
+			}
+			if probe.CmdProbeInputs != nil {
+				out.Spec.Probe[i].CmdProbeInputs = &CmdProbeInputs{
+					Command:    probe.CmdProbeInputs.Command,
+					Comparator: ComparatorInfo(probe.CmdProbeInputs.Comparator),
+					Source:     (*SourceDetails)(probe.CmdProbeInputs.Source),
+				}
+			}
+			if probe.PromProbeInputs != nil {
+				out.Spec.Probe[i].PromProbeInputs = &PromProbeInputs{
+					Endpoint:   probe.PromProbeInputs.Endpoint,
+					Query:      probe.PromProbeInputs.Query,
+					QueryPath:  probe.PromProbeInputs.QueryPath,
+					Comparator: ComparatorInfo(probe.PromProbeInputs.Comparator),
+				}
+			}
+			if probe.SLOProbeInputs != nil {
+				out.Spec.Probe[i].SLOProbeInputs = &SLOProbeInputs{
+					PlatformEndpoint:   probe.SLOProbeInputs.PlatformEndpoint,
+					SLOIdentifier:      probe.SLOProbeInputs.SLOIdentifier,
+					InsecureSkipVerify: probe.SLOProbeInputs.InsecureSkipVerify,
+					EvaluationWindow:   (*EvaluationWindow)(probe.SLOProbeInputs.EvaluationWindow),
+					SLOSourceMetadata: SLOSourceMetadata{
+						APITokenSecret: probe.SLOProbeInputs.SLOSourceMetadata.APITokenSecret,
+						Scope:          Identifier(probe.SLOProbeInputs.SLOSourceMetadata.Scope),
+					},
+					Comparator: ComparatorInfo(probe.SLOProbeInputs.Comparator),
+				}
+			}
+		}
+	}
+	return out
+}
+
+func runPropertyToHub(in RunProperty, path string, original originals) v1alpha1.RunProperty {
+	return v1alpha1.RunProperty{
+		ProbeTimeout:         original.durationToHub(path+".probeTimeout", in.ProbeTimeout),
+		Interval:             original.durationToHub(path+".interval", in.Interval),
+		Retry:                in.Retry,
+		Attempt:              in.Attempt,
+		ProbePollingInterval: original.durationToHub(path+".probePollingInterval", in.ProbePollingInterval),
+		InitialDelay:         original.durationToHub(path+".initialDelay", in.InitialDelay),
+		EvaluationTimeout:    original.durationToHub(path+".evaluationTimeout", in.EvaluationTimeout),
+		StopOnFailure:        in.StopOnFailure,
+	}
+}
+
+func runPropertyFromHub(in v1alpha1.RunProperty, path string, original originals) RunProperty {
+	return RunProperty{
+		ProbeTimeout:         original.durationFromHub(path+".probeTimeout", in.ProbeTimeout),
+		Interval:             original.durationFromHub(path+".interval", in.Interval),
+		Retry:                in.Retry,
+		Attempt:              in.Attempt,
+		ProbePollingInterval: original.durationFromHub(path+".probePollingInterval", in.ProbePollingInterval),
+		InitialDelay:         original.durationFromHub(path+".initialDelay", in.InitialDelay),
+		EvaluationTimeout:    original.durationFromHub(path+".evaluationTimeout", in.EvaluationTimeout),
+		StopOnFailure:        in.StopOnFailure,
+	}
+}
+
+// toHub returns the original v1alpha1 value of the path, unless the v1beta1 value has been changed since, i.e. unless
+// the canonical form of the original differs from the converted v1beta1 value. An unconvertible original is only
+// restored while the v1beta1 value is still empty
+func (original originals) toHub(path, out string, canonical func(string) (string, error)) string {
+	value, found := original[path]
+	if !found {
+		return out
+	}
+	if converted, err := canonical(value); (err != nil && out == "") || (err == nil && converted == out) {
+		return value
+	}
+	return out
+}
+
+// fromHub keeps the original v1alpha1 value of the path, if it is not restored from its canonical form
+func (original originals) fromHub(path, in string, canonical func(string) (string, error)) {
+	if converted, err := canonical(in); err != nil || converted != in {
+		original[path] = in
+	}
+}
+
+// durationToHub returns the v1alpha1 duration of the path
+func (original originals) durationToHub(path string, in *metav1.Duration) string {
+	return original.toHub(path, formatDuration(in), canonicalDuration)
+}
+
+// durationFromHub parses the v1alpha1 duration of the path. The unparseable durations are converted to nil,
+// rather than failing the conversion
+func (original originals) durationFromHub(path, in string) *metav1.Duration {
+	original.fromHub(path, in, canonicalDuration)
+	duration, err := parseDuration(in)
+	if err != nil {
+		return nil
+	}
+	return duration
+}
+
+// formatDuration formats the duration as the v1alpha1 duration string, nil duration is an empty string
+func formatDuration(in *metav1.Duration) string {
+	if in == nil {
+		return ""
+	}
+	return in.Duration.String()
+}
+
+// parseDuration parses the v1alpha1 duration string, which is either a go duration or
+// the number of seconds, as used by the older chaos-runners
+func parseDuration(in string) (*metav1.Duration, error) {
+	if in == "" {
+		return nil, nil
+	}
+	if seconds, err := strconv.Atoi(in); err == nil {
+		return &metav1.Duration{Duration: time.Duration(seconds) * time.Second}, nil
+	}
+	duration, err := time.ParseDuration(in)
+	if err != nil {
+		return nil, err
+	}
+	return &metav1.Duration{Duration: duration}, nil
+}
+
+func canonicalDuration(in string) (string, error) {
+	duration, err := parseDuration(in)
+	return formatDuration(duration), err
+}
+
+func selectorToHub(in Selector, original originals) (v1alpha1.Selector, error) {
+	out := v1alpha1.Selector{}
+	if in.Workloads != nil {
+		out.Workloads = make([]v1alpha1.Workload, len(in.Workloads))
+		for i, workload := range in.Workloads {
+			path := fmt.Sprintf("spec.selectors.workloads[%d]", i)
+			out.Workloads[i] = v1alpha1.Workload{
+				Kind:      v1alpha1.WorkloadKind(workload.Kind),
+				Namespace: workload.Namespace,
+				Names:     original.toHub(path+".names", strings.Join(workload.Names, ","), canonicalNames),
+			}
+			labels := ""
+			if workload.Labels != nil {
+				selector, err := metav1.LabelSelectorAsSelector(workload.Labels)
+				if err != nil {
+					return out, fmt.Errorf("invalid labels of %v workloads in %v namespace, due to error: %v", workload.Kind, workload.Namespace, err)
+				}
+				labels = selector.String()
+			}
+			out.Workloads[i].Labels = original.toHub(path+".labels", labels, canonicalLabels)
+		}
+	}
+	if in.Pods != nil {
+		out.Pods = make([]v1alpha1.Pod, len(in.Pods))
+		for i, pod := range in.Pods {
+			out.Pods[i] = v1alpha1.Pod{
+				Namespace: pod.Namespace,
+				Names:     original.toHub(fmt.Sprintf("spec.selectors.pods[%d].names", i), strings.Join(pod.Names, ","), canonicalNames),
+			}
+		}
+	}
+	return out, nil
+}
+
+// selectorFromHub converts the v1alpha1 selectors. The unparseable labels are converted to nil,
+// rather than failing the conversion
+func selectorFromHub(in v1alpha1.Selector, original originals) Selector {
+	out := Selector{}
+	if in.Workloads != nil {
+		out.Workloads = make([]Workload, len(in.Workloads))
+		for i, workload := range in.Workloads {
+			path := fmt.Sprintf("spec.selectors.workloads[%d]", i)
+			original.fromHub(path+".names", workload.Names, canonicalNames)
+			original.fromHub(path+".labels", workload.Labels, canonicalLabels)
+			out.Workloads[i] = Workload{
+				Kind:      WorkloadKind(workload.Kind),
+				Namespace: workload.Namespace,
+				Names:     splitNames(workload.Names),
+			}
+			if workload.Labels != "" {
+				if selector, err := metav1.ParseToLabelSelector(workload.Labels); err == nil {
+					out.Workloads[i].Labels = selector
+				}
+			}
+		}
+	}
+	if in.Pods != nil {
+		out.Pods = make([]Pod, len(in.Pods))
+		for i, pod := range in.Pods {
+			original.fromHub(fmt.Sprintf("spec.selectors.pods[%d].names", i), pod.Names, canonicalNames)
+			out.Pods[i] = Pod{
+				Namespace: pod.Namespace,
+				Names:     splitNames(pod.Names),
+			}
+		}
+	}
+	return out
+}
+
+// splitNames splits the comma separated names of the v1alpha1 selectors
+func splitNames(in string) []string {
+	var names []string
+	for _, name := range strings.Split(in, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func canonicalNames(in string) (string, error) {
+	return strings.Join(splitNames(in), ","), nil
+}
+
+func canonicalLabels(in string) (string, error) {
+	if in == "" {
+		return "", nil
+	}
+	selector, err := metav1.ParseToLabelSelector(in)
+	if err != nil {
+		return "", err
+	}
+	labels, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return "", err
+	}
+	return labels.String(), nil
+}
@@ -0,0 +1,295 @@
+/*
+Copyright 2019 LitmusChaos Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+   http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	fuzz "github.com/google/gofuzz"
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// fuzzer returns the fuzzer of the chaosengines, restricted to the equality based label selectors of v1beta1.
+// The v1alpha1 durations, names and labels are either canonical, or carried by the annotation, i.e. the number
+// of seconds of the older chaos-runners, the names with spaces and the unparseable durations and labels
+func fuzzer(f *fuzz.Fuzzer) *fuzz.Fuzzer {
+	return f.NilChance(0.2).NumElements(0, 2).MaxDepth(8).Funcs(
+		func(m *metav1.TypeMeta, c fuzz.Continue) {
+			// the type meta is set by the scheme, and not converted
+			*m = metav1.TypeMeta{}
+		},
+		func(q *resource.Quantity, c fuzz.Continue) {
+			*q = *resource.NewQuantity(c.Int63n(1000), resource.DecimalSI)
+		},
+		func(t *metav1.Time, c fuzz.Continue) {
+			*t = metav1.Unix(c.Int63n(1<<32), 0)
+		},
+		func(d *metav1.Duration, c fuzz.Continue) {
+			d.Duration = time.Duration(c.Int63n(1<<40)) * time.Millisecond
+		},
+		func(s *metav1.LabelSelector, c fuzz.Continue) {
+			*s = metav1.LabelSelector{MatchLabels: map[string]string{}}
+			for i := 0; i <= c.Intn(2); i++ {
+				s.MatchLabels[fmt.Sprintf("key-%d", c.Intn(100))] = fmt.Sprintf("value-%d", c.Intn(100))
+			}
+		},
+		func(w *Workload, c fuzz.Continue) {
+			c.FuzzNoCustom(w)
+			w.Names = names(c)
+		},
+		func(p *Pod, c fuzz.Continue) {
+			c.FuzzNoCustom(p)
+			p.Names = names(c)
+		},
+		func(r *v1alpha1.RunProperty, c fuzz.Continue) {
+			c.FuzzNoCustom(r)
+			for _, d := range []*string{&r.ProbeTimeout, &r.Interval, &r.ProbePollingInterval, &r.InitialDelay, &r.EvaluationTimeout} {
+				*d = ""
+				switch c.Intn(4) {
+				case 1:
+					*d = (time.Duration(c.Int63n(1<<40)) * time.Millisecond).String()
+				case 2:
+					*d = fmt.Sprint(c.Intn(600))
+				case 3:
+					*d = fmt.Sprintf("%d seconds", c.Intn(600))
+				}
+			}
+		},
+		func(w *v1alpha1.Workload, c fuzz.Continue) {
+			c.FuzzNoCustom(w)
+			w.Names = joinNames(c)
+			switch c.Intn(4) {
+			case 0:
+				w.Labels = ""
+			case 1:
+				w.Labels = fmt.Sprintf("key-%d=value-%d", c.Intn(100), c.Intn(100))
+			case 2:
+				w.Labels = fmt.Sprintf("key-%d = value-%d, key-%d=value", c.Intn(100), c.Intn(100), c.Intn(100))
+			case 3:
+				w.Labels = fmt.Sprintf("key-%d in value-%d", c.Intn(100), c.Intn(100))
+			}
+		},
+		func(p *v1alpha1.Pod, c fuzz.Continue) {
+			c.FuzzNoCustom(p)
+			p.Names = joinNames(c)
+		},
+	)
+}
+
+func names(c fuzz.Continue) []string {
+	var names []string
+	for i := 0; i < c.Intn(3); i++ {
+		names = append(names, fmt.Sprintf("name-%d", c.Intn(100)))
+	}
+	return names
+}
+
+// joinNames returns the comma separated names of the v1alpha1 selectors, with the spaces and the
+// empty names which are left out of the v1beta1 names
+func joinNames(c fuzz.Continue) string {
+	if c.RandBool() {
+		return strings.Join(names(c), ",")
+	}
+	return strings.Join(append(names(c), ""), " , ")
+}
+
+func TestFuzzyConversion(t *testing.T) {
+	f := fuzzer(fuzz.New().RandSource(rand.NewSource(time.Now().UnixNano())))
+	for i := 0; i < 500; i++ {
+		hub := &v1alpha1.ChaosEngine{}
+		f.Fuzz(hub)
+		spoke := &ChaosEngine{}
+		if err := spoke.ConvertFrom(hub.DeepCopy()); err != nil {
+			t.Fatalf("Test failed: unable to convert from hub, due to error: %v", err)
+		}
+		converted := &v1alpha1.ChaosEngine{}
+		if err := spoke.ConvertTo(converted); err != nil {
+			t.Fatalf("Test failed: unable to convert to hub, due to error: %v", err)
+		}
+		if !equality.Semantic.DeepEqual(hub, converted) {
+			t.Fatalf("Test failed: hub -> spoke -> hub round trip mismatch: %v", cmp.Diff(hub, converted))
+		}
+
+		spoke = &ChaosEngine{}
+		f.Fuzz(spoke)
+		hub = &v1alpha1.ChaosEngine{}
+		if err := spoke.DeepCopy().ConvertTo(hub); err != nil {
+			t.Fatalf("Test failed: unable to convert to hub, due to error: %v", err)
+		}
+		convertedSpoke := &ChaosEngine{}
+		if err := convertedSpoke.ConvertFrom(hub); err != nil {
+			t.Fatalf("Test failed: unable to convert from hub, due to error: %v", err)
+		}
+		if !equality.Semantic.DeepEqual(spoke, convertedSpoke) {
+			t.Fatalf("Test failed: spoke -> hub -> spoke round trip mismatch: %v", cmp.Diff(spoke, convertedSpoke))
+		}
+	}
+}
+
+func FuzzConversion(f *testing.F) {
+	f.Add([]byte("chaosengine"))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		spoke := &ChaosEngine{}
+		fuzzer(fuzz.NewFromGoFuzz(data)).Fuzz(spoke)
+		hub := &v1alpha1.ChaosEngine{}
+		if err := spoke.DeepCopy().ConvertTo(hub); err != nil {
+			t.Fatalf("unable to convert to hub, due to error: %v", err)
+		}
+		converted := &ChaosEngine{}
+		if err := converted.ConvertFrom(hub); err != nil {
+			t.Fatalf("unable to convert from hub, due to error: %v", err)
+		}
+		if !equality.Semantic.DeepEqual(spoke, converted) {
+			t.Fatalf("spoke -> hub -> spoke round trip mismatch: %v", cmp.Diff(spoke, converted))
+		}
+	})
+}
+
+func TestConvertFrom(t *testing.T) {
+	tests := map[string]struct {
+		runProperty v1alpha1.RunProperty
+		selector    v1alpha1.Selector
+		timeout     *metav1.Duration
+		names       []string
+		originals   string
+	}{
+		"Test Positive-1": {
+			runProperty: v1alpha1.RunProperty{ProbeTimeout: "5s", Interval: "2s"},
+			selector:    v1alpha1.Selector{Workloads: []v1alpha1.Workload{{Kind: "deployment", Names: "nginx, web,"}}},
+			timeout:     &metav1.Duration{Duration: 5 * time.Second},
+			names:       []string{"nginx", "web"},
+			originals:   `{"spec.selectors.workloads[0].names":"nginx, web,"}`,
+		},
+		"Test Positive-2": {
+			// the older runners used the number of seconds
+			runProperty: v1alpha1.RunProperty{ProbeTimeout: "10", Interval: "1"},
+			selector:    v1alpha1.Selector{Workloads: []v1alpha1.Workload{{Kind: "deployment", Labels: "app=nginx"}}},
+			timeout:     &metav1.Duration{Duration: 10 * time.Second},
+			originals:   `{"spec.experiments[0].spec.probe[0].runProperties.interval":"1","spec.experiments[0].spec.probe[0].runProperties.probeTimeout":"10"}`,
+		},
+		"Test Positive-3": {
+			// the unparseable durations are carried by the annotation, rather than failing the conversion
+			runProperty: v1alpha1.RunProperty{ProbeTimeout: "five seconds", Interval: "1s"},
+			selector:    v1alpha1.Selector{Pods: []v1alpha1.Pod{{Names: "nginx"}}},
+			originals:   `{"spec.experiments[0].spec.probe[0].runProperties.probeTimeout":"five seconds"}`,
+		},
+		"Test Positive-4": {
+			// the unparseable labels are carried by the annotation, rather than failing the conversion
+			runProperty: v1alpha1.RunProperty{ProbeTimeout: "5s", Interval: "1s"},
+			selector:    v1alpha1.Selector{Workloads: []v1alpha1.Workload{{Kind: "deployment", Labels: "app in nginx"}}},
+			timeout:     &metav1.Duration{Duration: 5 * time.Second},
+			originals:   `{"spec.selectors.workloads[0].labels":"app in nginx"}`,
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			hub := &v1alpha1.ChaosEngine{
+				Spec: v1alpha1.ChaosEngineSpec{
+					Experiments: []v1alpha1.ExperimentList{{
+						Name: "pod-delete",
+						Spec: v1alpha1.ExperimentAttributes{Probe: []v1alpha1.ProbeAttributes{{Name: "check", RunProperties: mock.runProperty}}},
+					}},
+					Selectors: &mock.selector,
+				},
+			}
+			spoke := &ChaosEngine{}
+			if err := spoke.ConvertFrom(hub); err != nil {
+				t.Fatalf("Test %q failed: expected error to be nil, received %v", name, err)
+			}
+			if timeout := spoke.Spec.Experiments[0].Spec.Probe[0].RunProperties.ProbeTimeout; !equality.Semantic.DeepEqual(timeout, mock.timeout) {
+				t.Fatalf("Test %q failed: expected probeTimeout %v, received %v", name, mock.timeout, timeout)
+			}
+			if len(spoke.Spec.Selectors.Workloads) != 0 && !equality.Semantic.DeepEqual(spoke.Spec.Selectors.Workloads[0].Names, mock.names) {
+				t.Fatalf("Test %q failed: expected names %v, received %v", name, mock.names, spoke.Spec.Selectors.Workloads[0].Names)
+			}
+			if originals := spoke.Annotations[OriginalsAnnotation]; originals != mock.originals {
+				t.Fatalf("Test %q failed: expected the originals annotation %q, received %q", name, mock.originals, originals)
+			}
+
+			// the original values are restored into the hub
+			converted := &v1alpha1.ChaosEngine{}
+			if err := spoke.ConvertTo(converted); err != nil {
+				t.Fatalf("Test %q failed: unable to convert to hub, due to error: %v", name, err)
+			}
+			if runProperty := converted.Spec.Experiments[0].Spec.Probe[0].RunProperties; runProperty != mock.runProperty {
+				t.Fatalf("Test %q failed: expected runProperties %+v, received %+v", name, mock.runProperty, runProperty)
+			}
+			if !equality.Semantic.DeepEqual(*converted.Spec.Selectors, mock.selector) {
+				t.Fatalf("Test %q failed: expected selectors %+v, received %+v", name, mock.selector, *converted.Spec.Selectors)
+			}
+			if _, found := converted.Annotations[OriginalsAnnotation]; found {
+				t.Fatalf("Test %q failed: expected the originals annotation to be removed from the hub", name)
+			}
+		})
+	}
+}
+
+func TestConvertToChangedDuration(t *testing.T) {
+	hub := &v1alpha1.ChaosEngine{
+		Spec: v1alpha1.ChaosEngineSpec{
+			Experiments: []v1alpha1.ExperimentList{{
+				Name: "pod-delete",
+				Spec: v1alpha1.ExperimentAttributes{Probe: []v1alpha1.ProbeAttributes{{Name: "check", RunProperties: v1alpha1.RunProperty{ProbeTimeout: "10", Interval: "1"}}}},
+			}},
+		},
+	}
+	spoke := &ChaosEngine{}
+	if err := spoke.ConvertFrom(hub); err != nil {
+		t.Fatalf("Test failed: unable to convert from hub, due to error: %v", err)
+	}
+	// the probeTimeout is updated through the v1beta1 api, the unchanged interval keeps its original duration
+	spoke.Spec.Experiments[0].Spec.Probe[0].RunProperties.ProbeTimeout = &metav1.Duration{Duration: 20 * time.Second}
+
+	converted := &v1alpha1.ChaosEngine{}
+	if err := spoke.ConvertTo(converted); err != nil {
+		t.Fatalf("Test failed: unable to convert to hub, due to error: %v", err)
+	}
+	if runProperty := converted.Spec.Experiments[0].Spec.Probe[0].RunProperties; runProperty.ProbeTimeout != "20s" || runProperty.Interval != "1" {
+		t.Fatalf("Test failed: expected probeTimeout 20s and interval 1, received %+v", runProperty)
+	}
+}
+
+func TestConvertToChangedSelector(t *testing.T) {
+	hub := &v1alpha1.ChaosEngine{
+		Spec: v1alpha1.ChaosEngineSpec{
+			Selectors: &v1alpha1.Selector{Workloads: []v1alpha1.Workload{
+				{Kind: "deployment", Names: "nginx, web"},
+				{Kind: "statefulset", Labels: "app in mysql"},
+			}},
+		},
+	}
+	spoke := &ChaosEngine{}
+	if err := spoke.ConvertFrom(hub); err != nil {
+		t.Fatalf("Test failed: unable to convert from hub, due to error: %v", err)
+	}
+	// the names and the labels are updated through the v1beta1 api, the originals are not restored then
+	spoke.Spec.Selectors.Workloads[0].Names = []string{"nginx"}
+	spoke.Spec.Selectors.Workloads[1].Labels = &metav1.LabelSelector{MatchLabels: map[string]string{"app": "mysql"}}
+
+	converted := &v1alpha1.ChaosEngine{}
+	if err := spoke.ConvertTo(converted); err != nil {
+		t.Fatalf("Test failed: unable to convert to hub, due to error: %v", err)
+	}
+	if workloads := converted.Spec.Selectors.Workloads; workloads[0].Names != "nginx" || workloads[1].Labels != "app=mysql" {
+		t.Fatalf("Test failed: expected names nginx and labels app=mysql, received %+v", workloads)
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2019 LitmusChaos Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+   http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/litmuschaos/chaos-operator/controllers"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const engineManifest = `apiVersion: litmuschaos.io/v1alpha1
+kind: ChaosEngine
+metadata:
+  name: nginx-chaos
+spec:
+  engineState: active
+  appinfo:
+    appns: default
+    applabel: app=nginx
+    appkind: deployment
+  chaosServiceAccount: pod-delete-sa
+  components:
+    runner:
+      configMaps:
+      - name: runner-config
+        mountPath: /mnt/config
+  experiments:
+  - name: pod-delete
+`
+
+const experimentManifest = `apiVersion: litmuschaos.io/v1alpha1
+kind: ChaosExperiment
+metadata:
+  name: pod-delete
+spec:
+  definition:
+    image: litmuschaos/go-runner:latest
+    scope: Namespaced
+`
+
+// writeManifest writes the manifest into a temporary file and returns its path
+func writeManifest(t *testing.T, manifest string) string {
+	path := filepath.Join(t.TempDir(), "manifest.yaml")
+	if err := os.WriteFile(path, []byte(manifest), 0o600); err != nil {
+		t.Fatalf("unable to write the manifest, due to error: %v", err)
+	}
+	return path
+}
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		manifest string
+		exitCode int
+		contains []string
+	}{
+		"Test Positive-1": {
+			manifest: engineManifest + "---\n" + experimentManifest,
+		},
+		"Test Positive-2": {
+			manifest: strings.Replace(engineManifest, "  engineState: active\n", "  engineState: active\n  unknownField: true\n", 1),
+			contains: []string{SeverityWarning, "unknownField"},
+		},
+		"Test Positive-3": {
+			manifest: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: runner-config\n",
+		},
+		"Test Negative-1": {
+			manifest: strings.Replace(engineManifest, "    appkind: deployment\n", "", 1),
+			exitCode: exitFindings,
+			contains: []string{"incomplete appinfo"},
+		},
+		"Test Negative-2": {
+			manifest: strings.Replace(engineManifest, "  experiments:\n  - name: pod-delete\n", "", 1),
+			exitCode: exitFindings,
+			contains: []string{"application experiment list is empty"},
+		},
+		"Test Negative-3": {
+			manifest: strings.Replace(engineManifest, "engineState: active", "engineState: paused", 1),
+			exitCode: exitFindings,
+			contains: []string{"unsupported engineState"},
+		},
+		"Test Negative-4": {
+			manifest: strings.Replace(experimentManifest, "    image: litmuschaos/go-runner:latest\n", "", 1),
+			exitCode: exitFindings,
+			contains: []string{"spec.definition.image is required"},
+		},
+		"Test Negative-5": {
+			manifest: "apiVersion: litmuschaos.io/v1alpha1\nkind: ChaosEngine\nmetadata:\n  name: nginx-chaos\nspec:\n  experiments: pod-delete\n",
+			exitCode: exitFindings,
+			contains: []string{"unable to decode"},
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := writeManifest(t, mock.manifest)
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			if exitCode := run(context.Background(), []string{"validate", "--output", outputJSON, path}, stdout, stderr); exitCode != mock.exitCode {
+				t.Fatalf("Test %q failed: expected exit code %v, received %v, stdout: %v, stderr: %v", name, mock.exitCode, exitCode, stdout, stderr)
+			}
+			var diagnostics []Diagnostic
+			if err := json.Unmarshal(stdout.Bytes(), &diagnostics); err != nil {
+				t.Fatalf("Test %q failed: unable to decode the diagnostics, due to error: %v", name, err)
+			}
+			for _, s := range mock.contains {
+				if !strings.Contains(stdout.String(), s) {
+					t.Fatalf("Test %q failed: expected the diagnostics to contain %q, received %v", name, s, stdout)
+				}
+			}
+			if len(mock.contains) == 0 && len(diagnostics) != 0 {
+				t.Fatalf("Test %q failed: expected no diagnostics, received %+v", name, diagnostics)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	path := writeManifest(t, engineManifest)
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	if exitCode := run(context.Background(), []string{"render", "--namespace", "litmus", path}, stdout, stderr); exitCode != 0 {
+		t.Fatalf("expected the runner pod to be rendered, received exit code %v, stderr: %v", exitCode, stderr)
+	}
+	pod := &corev1.Pod{}
+	if err := yaml.UnmarshalStrict(stdout.Bytes(), pod); err != nil {
+		t.Fatalf("unable to decode the runner pod, due to error: %v", err)
+	}
+	if pod.Name != "nginx-chaos-runner" || pod.Namespace != "litmus" || pod.Spec.ServiceAccountName != "pod-delete-sa" {
+		t.Fatalf("unexpected metadata of the runner pod %v/%v", pod.Namespace, pod.Name)
+	}
+	if len(pod.OwnerReferences) != 1 || pod.OwnerReferences[0].Name != "nginx-chaos" {
+		t.Fatalf("expected the runner pod to be owned by the chaosengine, received %+v", pod.OwnerReferences)
+	}
+	if len(pod.Spec.Volumes) != 1 || pod.Spec.Volumes[0].ConfigMap == nil || pod.Spec.Volumes[0].ConfigMap.Name != "runner-config" {
+		t.Fatalf("expected the configmap volume of the runner, received %+v", pod.Spec.Volumes)
+	}
+	env := map[string]string{}
+	for _, e := range pod.Spec.Containers[0].Env {
+		env[e.Name] = e.Value
+	}
+	if env["TARGETS"] != "deployment:default:[app=nginx]" || env["EXPERIMENT_LIST"] != "pod-delete" || env["CHAOS_NAMESPACE"] != "litmus" {
+		t.Fatalf("unexpected env of the runner %v", env)
+	}
+}
+
+func TestTargets(t *testing.T) {
+	path := writeManifest(t, engineManifest)
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	if exitCode := run(context.Background(), []string{"targets", "--output", outputJSON, path}, stdout, stderr); exitCode != 0 {
+		t.Fatalf("expected the targets to be shown, received exit code %v, stderr: %v", exitCode, stderr)
+	}
+	var engines []struct {
+		Targets string
+		Decoded []controllers.Target
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &engines); err != nil {
+		t.Fatalf("unable to decode the targets, due to error: %v", err)
+	}
+	if len(engines) != 1 || len(engines[0].Decoded) != 1 {
+		t.Fatalf("expected the targets of a single chaosengine, received %+v", engines)
+	}
+	if target := engines[0].Decoded[0]; target.Kind != "deployment" || target.Namespace != "default" || target.Filter != "app=nginx" {
+		t.Fatalf("unexpected decoded target %+v", target)
+	}
+}
+
+func TestRunUsage(t *testing.T) {
+	tests := map[string]struct {
+		args []string
+	}{
+		"Test Negative-1": {args: nil},
+		"Test Negative-2": {args: []string{"apply"}},
+		"Test Negative-3": {args: []string{"validate"}},
+		"Test Negative-4": {args: []string{"render", "--output", "text", "manifest.yaml"}},
+		"Test Negative-5": {args: []string{"targets", "missing.yaml"}},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			if exitCode := run(context.Background(), mock.args, stdout, stderr); exitCode != exitUsage || stderr.Len() == 0 {
+				t.Fatalf("Test %q failed: expected the usage error, received exit code %v, stderr: %v", name, exitCode, stderr)
+			}
+		})
+	}
+}
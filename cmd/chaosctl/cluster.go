@@ -0,0 +1,316 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	litmuschaosv1alpha1 "github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/chaos-operator/pkg/client/clientset/versioned"
+	"github.com/litmuschaos/chaos-operator/pkg/report"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// pollInterval is the interval at which the cleanup of the chaos resources is checked
+const pollInterval = 2 * time.Second
+
+// clients contains the clientsets of the commands which connect to the cluster
+type clients struct {
+	litmus versioned.Interface
+	kube   kubernetes.Interface
+}
+
+// clusterOptions contains the flags shared by the commands which connect to the cluster
+type clusterOptions struct {
+	kubeconfig string
+	context    string
+	namespace  string
+	timeout    time.Duration
+}
+
+// bind registers the cluster flags on the flagset of a command
+func (opts *clusterOptions) bind(flags *flag.FlagSet) {
+	flags.StringVar(&opts.kubeconfig, "kubeconfig", "", "The path of the kubeconfig file. The KUBECONFIG env, ~/.kube/config or the in-cluster config are used if empty.")
+	flags.StringVar(&opts.context, "context", "", "The kubeconfig context to use. The current context is used if empty.")
+	flags.StringVar(&opts.namespace, "namespace", "", "The namespace of the chaosengine. The namespace of the kubeconfig context is used if empty.")
+	flags.DurationVar(&opts.timeout, "timeout", 0, "The maximum duration to wait for the chaosengine, it waits until interrupted if zero.")
+}
+
+// parseClusterFlags parses the args of the command, which takes a single argument
+func parseClusterFlags(flags *flag.FlagSet, args []string, argName string) (string, error) {
+	flags.SetOutput(os.Stderr)
+	if err := flags.Parse(args); err != nil {
+		return "", err
+	}
+	if flags.NArg() != 1 {
+		return "", fmt.Errorf("expected a single %v, received %v", argName, flags.Args())
+	}
+	return flags.Arg(0), nil
+}
+
+// newClients returns the clientsets of the cluster and the namespace of the command
+// it is a variable to be replaced by the fake clientsets in the tests
+var newClients = func(opts *clusterOptions) (*clients, string, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = opts.kubeconfig
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(loadingRules, &clientcmd.ConfigOverrides{
+		CurrentContext: opts.context,
+		Context:        clientcmdapi.Context{Namespace: opts.namespace},
+	})
+
+	namespace, _, err := clientConfig.Namespace()
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to get the namespace of the kubeconfig, due to error: %v", err)
+	}
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to load the kubeconfig, due to error: %v", err)
+	}
+	litmusClient, err := versioned.NewForConfig(restConfig)
+	if err != nil {
+		return nil, "", err
+	}
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, "", err
+	}
+	return &clients{litmus: litmusClient, kube: kubeClient}, namespace, nil
+}
+
+// withTimeout returns the context bounded by the timeout flag, if set
+func (opts *clusterOptions) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if opts.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, opts.timeout)
+}
+
+// isFinished returns true once the run of the chaosengine is completed or stopped
+func isFinished(engine *litmuschaosv1alpha1.ChaosEngine) bool {
+	return engine.Status.EngineStatus == litmuschaosv1alpha1.EngineStatusCompleted ||
+		engine.Status.EngineStatus == litmuschaosv1alpha1.EngineStatusStopped
+}
+
+// engineStream prints the changes of a chaosengine and its events
+type engineStream struct {
+	out    io.Writer
+	engine *litmuschaosv1alpha1.ChaosEngine
+	events map[string]bool
+}
+
+// printEngine prints the engine status, the experiment statuses and the resilience score which changed
+// since the last printed state of the chaosengine
+func (stream *engineStream) printEngine(engine *litmuschaosv1alpha1.ChaosEngine) {
+	previous := stream.engine
+	if previous == nil {
+		previous = &litmuschaosv1alpha1.ChaosEngine{}
+	}
+	stream.engine = engine
+
+	if previous.Name == "" || previous.Status.EngineStatus != engine.Status.EngineStatus {
+		status := string(engine.Status.EngineStatus)
+		if status == "" {
+			status = "pending"
+		}
+		fmt.Fprintf(stream.out, "chaosengine %v/%v: %v\n", engine.Namespace, engine.Name, status)
+	}
+
+	previousExperiments := make(map[string]litmuschaosv1alpha1.ExperimentStatuses, len(previous.Status.Experiments))
+	for _, exp := range previous.Status.Experiments {
+		previousExperiments[exp.Name] = exp
+	}
+	for _, exp := range engine.Status.Experiments {
+		if prev, found := previousExperiments[exp.Name]; found && prev.Status == exp.Status && prev.Verdict == exp.Verdict {
+			continue
+		}
+		fmt.Fprintf(stream.out, "  experiment %v: %v, verdict: %v\n", exp.Name, exp.Status, exp.Verdict)
+	}
+
+	if engine.Status.ResilienceScore != "" && engine.Status.ResilienceScore != previous.Status.ResilienceScore {
+		fmt.Fprintf(stream.out, "  resilience score: %v\n", engine.Status.ResilienceScore)
+	}
+}
+
+// printEvent prints the event of the chaosengine, unless it is already printed
+func (stream *engineStream) printEvent(event *corev1.Event) {
+	if event.InvolvedObject.Kind != "ChaosEngine" || event.InvolvedObject.Name != stream.engine.Name {
+		return
+	}
+	key := fmt.Sprintf("%v/%v", event.UID, event.Count)
+	if stream.events[key] {
+		return
+	}
+	stream.events[key] = true
+	fmt.Fprintf(stream.out, "  event %v %v: %v\n", event.Type, event.Reason, event.Message)
+}
+
+// follow streams the changes of the chaosengine and its events into out, until done returns true for the chaosengine.
+// The watches are reopened from the last seen resource versions once closed by the apiserver
+func follow(ctx context.Context, c *clients, namespace, name string, out io.Writer, done func(*litmuschaosv1alpha1.ChaosEngine) bool) (*litmuschaosv1alpha1.ChaosEngine, error) {
+	engine, err := c.litmus.LitmuschaosV1alpha1().ChaosEngines(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	stream := &engineStream{out: out, events: map[string]bool{}}
+	stream.printEngine(engine)
+	if done(engine) {
+		return engine, nil
+	}
+
+	engineVersion, eventVersion := engine.ResourceVersion, ""
+	for {
+		engineWatch, err := c.litmus.LitmuschaosV1alpha1().ChaosEngines(namespace).Watch(ctx, metav1.ListOptions{
+			FieldSelector:   fields.OneTermEqualSelector("metadata.name", name).String(),
+			ResourceVersion: engineVersion,
+		})
+		if err != nil {
+			return stream.engine, waitError(ctx, name, err)
+		}
+		eventWatch, err := c.kube.CoreV1().Events(namespace).Watch(ctx, metav1.ListOptions{
+			FieldSelector:   fields.Set{"involvedObject.kind": "ChaosEngine", "involvedObject.name": name}.String(),
+			ResourceVersion: eventVersion,
+		})
+		if err != nil {
+			engineWatch.Stop()
+			return stream.engine, waitError(ctx, name, err)
+		}
+
+		finished, err := stream.consume(ctx, engineWatch, eventWatch, &engineVersion, &eventVersion, done)
+		engineWatch.Stop()
+		eventWatch.Stop()
+		if err != nil || finished {
+			return stream.engine, err
+		}
+	}
+}
+
+// consume prints the changes received on the watches, it returns once done, or once a watch is closed
+func (stream *engineStream) consume(ctx context.Context, engineWatch, eventWatch watch.Interface, engineVersion, eventVersion *string, done func(*litmuschaosv1alpha1.ChaosEngine) bool) (bool, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return false, waitError(ctx, stream.engine.Name, ctx.Err())
+		case change, ok := <-engineWatch.ResultChan():
+			if !ok {
+				return false, nil
+			}
+			switch change.Type {
+			case watch.Error:
+				// the resource version is expired, the watch is reopened from the current state
+				*engineVersion = ""
+				return false, nil
+			case watch.Deleted:
+				return false, fmt.Errorf("chaosengine %v is deleted", stream.engine.Name)
+			}
+			engine, ok := change.Object.(*litmuschaosv1alpha1.ChaosEngine)
+			if !ok || engine.Name != stream.engine.Name {
+				continue
+			}
+			*engineVersion = engine.ResourceVersion
+			stream.printEngine(engine)
+			if done(engine) {
+				return true, nil
+			}
+		case change, ok := <-eventWatch.ResultChan():
+			if !ok {
+				return false, nil
+			}
+			if change.Type == watch.Error {
+				*eventVersion = ""
+				return false, nil
+			}
+			if event, ok := change.Object.(*corev1.Event); ok {
+				*eventVersion = event.ResourceVersion
+				stream.printEvent(event)
+			}
+		}
+	}
+}
+
+// waitError returns the error of an interrupted wait on the chaosengine
+func waitError(ctx context.Context, name string, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out waiting for the chaosengine %v", name)
+	}
+	if ctx.Err() != nil {
+		return fmt.Errorf("interrupted waiting for the chaosengine %v", name)
+	}
+	return err
+}
+
+// waitForCleanup waits until the pods of the run, labeled with the chaosUID of the chaosengine, are deleted
+func waitForCleanup(ctx context.Context, c *clients, engine *litmuschaosv1alpha1.ChaosEngine) error {
+	selector := labels.Set{"chaosUID": string(engine.UID)}.String()
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		pods, err := c.kube.CoreV1().Pods(engine.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+		if err != nil {
+			return waitError(ctx, engine.Name, err)
+		}
+		if len(pods.Items) == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return waitError(ctx, engine.Name, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// buildReport returns the report of the last run of the chaosengine, from its status and its chaosresults
+func buildReport(ctx context.Context, c *clients, engine *litmuschaosv1alpha1.ChaosEngine) (report.Report, error) {
+	results, err := c.litmus.LitmuschaosV1alpha1().ChaosResults(engine.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.Set{"chaosUID": string(engine.UID)}.String(),
+	})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return report.Report{}, fmt.Errorf("unable to list the chaosresults of %v, due to error: %v", engine.Name, err)
+	}
+	var items []litmuschaosv1alpha1.ChaosResult
+	if results != nil {
+		items = results.Items
+	}
+	return report.Build(engine, items), nil
+}
+
+// isPassed returns true if the run of the chaosengine is completed and all its experiments passed
+func isPassed(runReport report.Report) bool {
+	if runReport.EngineStatus != string(litmuschaosv1alpha1.EngineStatusCompleted) {
+		return false
+	}
+	for _, exp := range runReport.Experiments {
+		if exp.Verdict != string(litmuschaosv1alpha1.ResultVerdictPassed) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,309 @@
+/*
+Copyright 2019 LitmusChaos Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+   http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	litmusfake "github.com/litmuschaos/chaos-operator/pkg/client/clientset/versioned/fake"
+	"github.com/litmuschaos/chaos-operator/pkg/report"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// fakeCluster contains the fake clientsets and the watchers which replay the scripted changes
+type fakeCluster struct {
+	litmus        *litmusfake.Clientset
+	engineWatcher *watch.FakeWatcher
+	eventWatcher  *watch.FakeWatcher
+}
+
+// newFakeCluster replaces the clientsets of the commands with fake clientsets holding the objects
+func newFakeCluster(t *testing.T, objects ...runtime.Object) *fakeCluster {
+	cluster := &fakeCluster{
+		litmus:        litmusfake.NewSimpleClientset(objects...),
+		engineWatcher: watch.NewFakeWithChanSize(10, false),
+		eventWatcher:  watch.NewFake(),
+	}
+	kube := kubefake.NewSimpleClientset()
+	cluster.litmus.PrependWatchReactor("chaosengines", k8stesting.DefaultWatchReactor(cluster.engineWatcher, nil))
+	kube.PrependWatchReactor("events", k8stesting.DefaultWatchReactor(cluster.eventWatcher, nil))
+
+	previous := newClients
+	newClients = func(opts *clusterOptions) (*clients, string, error) {
+		namespace := opts.namespace
+		if namespace == "" {
+			namespace = "default"
+		}
+		return &clients{litmus: cluster.litmus, kube: kube}, namespace, nil
+	}
+	t.Cleanup(func() { newClients = previous })
+	return cluster
+}
+
+// getEngine returns the chaosengine stored in the fake clientset
+func (cluster *fakeCluster) getEngine(t *testing.T, name string) *v1alpha1.ChaosEngine {
+	engine, err := cluster.litmus.LitmuschaosV1alpha1().ChaosEngines("default").Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unable to get the chaosengine, due to error: %v", err)
+	}
+	return engine
+}
+
+// newEngine returns a chaosengine of the given engine status, with the verdict of its experiment
+func newEngine(status v1alpha1.EngineStatus, verdict string) *v1alpha1.ChaosEngine {
+	engine := &v1alpha1.ChaosEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx-chaos", Namespace: "default", UID: "engine-uid"},
+		Spec: v1alpha1.ChaosEngineSpec{
+			EngineState: v1alpha1.EngineStateActive,
+			Experiments: []v1alpha1.ExperimentList{{Name: "pod-delete"}},
+		},
+		Status: v1alpha1.ChaosEngineStatus{EngineStatus: status},
+	}
+	if status == v1alpha1.EngineStatusCompleted || status == v1alpha1.EngineStatusStopped {
+		engine.Spec.EngineState = v1alpha1.EngineStateStop
+	}
+	if verdict != "" {
+		engine.Status.Experiments = []v1alpha1.ExperimentStatuses{{
+			Name:        "pod-delete",
+			Status:      v1alpha1.ExperimentStatusCompleted,
+			Verdict:     verdict,
+			ResultPhase: v1alpha1.ResultPhaseCompleted,
+		}}
+	}
+	return engine
+}
+
+func TestRun(t *testing.T) {
+	tests := map[string]struct {
+		verdict  string
+		exitCode int
+	}{
+		"Test Positive-1": {
+			verdict: string(v1alpha1.ResultVerdictPassed),
+		},
+		"Test Negative-1": {
+			verdict:  string(v1alpha1.ResultVerdictFailed),
+			exitCode: exitFindings,
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			cluster := newFakeCluster(t)
+			cluster.engineWatcher.Modify(newEngine(v1alpha1.EngineStatusInitialized, ""))
+			cluster.engineWatcher.Modify(newEngine(v1alpha1.EngineStatusCompleted, mock.verdict))
+
+			path := writeManifest(t, strings.Replace(engineManifest, "engineState: active", "engineState: stop", 1))
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			if exitCode := run(context.Background(), []string{"run", path}, stdout, stderr); exitCode != mock.exitCode {
+				t.Fatalf("Test %q failed: expected exit code %v, received %v, stdout: %v, stderr: %v", name, mock.exitCode, exitCode, stdout, stderr)
+			}
+			for _, s := range []string{"chaosengine default/nginx-chaos created", "initialized", "experiment pod-delete: Completed, verdict: " + mock.verdict} {
+				if !strings.Contains(stdout.String(), s) {
+					t.Fatalf("Test %q failed: expected the output to contain %q, received %v", name, s, stdout)
+				}
+			}
+			if engine := cluster.getEngine(t, "nginx-chaos"); engine.Spec.EngineState != v1alpha1.EngineStateActive {
+				t.Fatalf("Test %q failed: expected the chaosengine to be created active, received %v", name, engine.Spec.EngineState)
+			}
+		})
+	}
+}
+
+func TestRunWithoutWait(t *testing.T) {
+	cluster := newFakeCluster(t)
+	path := writeManifest(t, strings.Replace(engineManifest, "  name: nginx-chaos\n", "  generateName: nginx-chaos-\n", 1))
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	if exitCode := run(context.Background(), []string{"run", "--wait=false", "--name", "nginx-chaos-1", path}, stdout, stderr); exitCode != 0 {
+		t.Fatalf("expected the chaosengine to be created, received exit code %v, stderr: %v", exitCode, stderr)
+	}
+	if engine := cluster.getEngine(t, "nginx-chaos-1"); engine.GenerateName != "" {
+		t.Fatalf("expected the name flag to override the generateName, received %v", engine.GenerateName)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	cluster := newFakeCluster(t, newEngine(v1alpha1.EngineStatusInitialized, ""))
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	done := make(chan int)
+	go func() {
+		done <- run(context.Background(), []string{"watch", "nginx-chaos"}, stdout, stderr)
+	}()
+	// the event watcher is unbuffered, the events are consumed before the chaosengine is finished
+	go func() {
+		cluster.eventWatcher.Add(&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "nginx-chaos.1", Namespace: "default", UID: "event-uid"},
+			InvolvedObject: corev1.ObjectReference{Kind: "ChaosEngine", Name: "nginx-chaos"},
+			Type:           corev1.EventTypeNormal,
+			Reason:         "ChaosEngineInitialized",
+			Message:        "Identifying app under test & launching nginx-chaos-runner",
+			Count:          1,
+		})
+		cluster.eventWatcher.Add(&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "other-chaos.1", Namespace: "default", UID: "other-uid"},
+			InvolvedObject: corev1.ObjectReference{Kind: "ChaosEngine", Name: "other-chaos"},
+			Reason:         "ChaosEngineInitialized",
+		})
+		cluster.engineWatcher.Modify(newEngine(v1alpha1.EngineStatusCompleted, string(v1alpha1.ResultVerdictPassed)))
+	}()
+
+	if exitCode := <-done; exitCode != 0 {
+		t.Fatalf("expected the watch to finish, received exit code %v, stderr: %v", exitCode, stderr)
+	}
+	expected := "chaosengine default/nginx-chaos: initialized\n" +
+		"  event Normal ChaosEngineInitialized: Identifying app under test & launching nginx-chaos-runner\n" +
+		"chaosengine default/nginx-chaos: completed\n" +
+		"  experiment pod-delete: Completed, verdict: Pass\n"
+	if stdout.String() != expected {
+		t.Fatalf("expected the output\n%v\nreceived\n%v", expected, stdout)
+	}
+}
+
+func TestAbort(t *testing.T) {
+	tests := map[string]struct {
+		engine   *v1alpha1.ChaosEngine
+		contains string
+		state    v1alpha1.EngineState
+	}{
+		"Test Positive-1": {
+			engine:   newEngine(v1alpha1.EngineStatusInitialized, ""),
+			contains: "chaos resources of default/nginx-chaos are cleaned up",
+			state:    v1alpha1.EngineStateStop,
+		},
+		"Test Positive-2": {
+			engine:   newEngine(v1alpha1.EngineStatusCompleted, string(v1alpha1.ResultVerdictPassed)),
+			contains: "is already completed",
+			state:    v1alpha1.EngineStateStop,
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			cluster := newFakeCluster(t, mock.engine)
+			cluster.engineWatcher.Modify(newEngine(v1alpha1.EngineStatusStopped, string(v1alpha1.ResultVerdictStopped)))
+
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			if exitCode := run(context.Background(), []string{"abort", "nginx-chaos"}, stdout, stderr); exitCode != 0 {
+				t.Fatalf("Test %q failed: expected the abort to succeed, received exit code %v, stderr: %v", name, exitCode, stderr)
+			}
+			if !strings.Contains(stdout.String(), mock.contains) {
+				t.Fatalf("Test %q failed: expected the output to contain %q, received %v", name, mock.contains, stdout)
+			}
+			if engine := cluster.getEngine(t, "nginx-chaos"); engine.Spec.EngineState != mock.state {
+				t.Fatalf("Test %q failed: expected the engineState %v, received %v", name, mock.state, engine.Spec.EngineState)
+			}
+		})
+	}
+}
+
+func TestRerun(t *testing.T) {
+	tests := map[string]struct {
+		engine   *v1alpha1.ChaosEngine
+		exitCode int
+	}{
+		"Test Positive-1": {
+			engine: newEngine(v1alpha1.EngineStatusStopped, string(v1alpha1.ResultVerdictStopped)),
+		},
+		"Test Negative-1": {
+			engine:   newEngine(v1alpha1.EngineStatusInitialized, ""),
+			exitCode: exitUsage,
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			cluster := newFakeCluster(t, mock.engine)
+			// the previous run is still reported as finished, until the operator initializes the rerun
+			cluster.engineWatcher.Modify(newEngine(v1alpha1.EngineStatusStopped, string(v1alpha1.ResultVerdictStopped)))
+			cluster.engineWatcher.Modify(newEngine(v1alpha1.EngineStatusInitialized, ""))
+			cluster.engineWatcher.Modify(newEngine(v1alpha1.EngineStatusCompleted, string(v1alpha1.ResultVerdictPassed)))
+
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			if exitCode := run(context.Background(), []string{"rerun", "nginx-chaos"}, stdout, stderr); exitCode != mock.exitCode {
+				t.Fatalf("Test %q failed: expected exit code %v, received %v, stdout: %v, stderr: %v", name, mock.exitCode, exitCode, stdout, stderr)
+			}
+			if mock.exitCode != 0 {
+				return
+			}
+			if !strings.Contains(stdout.String(), "pod-delete  Completed  Pass") {
+				t.Fatalf("Test %q failed: expected the report of the rerun, received %v", name, stdout)
+			}
+			if engine := cluster.getEngine(t, "nginx-chaos"); engine.Spec.EngineState != v1alpha1.EngineStateActive {
+				t.Fatalf("Test %q failed: expected the chaosengine to be restarted, received %v", name, engine.Spec.EngineState)
+			}
+		})
+	}
+}
+
+func TestReport(t *testing.T) {
+	result := &v1alpha1.ChaosResult{
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx-chaos-pod-delete", Namespace: "default", Labels: map[string]string{"chaosUID": "engine-uid"}},
+		Spec:       v1alpha1.ChaosResultSpec{EngineName: "nginx-chaos", ExperimentName: "pod-delete"},
+		Status: v1alpha1.ChaosResultStatus{
+			ExperimentStatus: v1alpha1.TestStatus{
+				Phase:                  v1alpha1.ResultPhaseCompleted,
+				Verdict:                v1alpha1.ResultVerdictFailed,
+				ProbeSuccessPercentage: "50",
+				ErrorOutput:            &v1alpha1.ErrorOutput{ErrorCode: "PROBE_FAILED", Reason: "http probe failed"},
+			},
+		},
+	}
+	tests := map[string]struct {
+		output   string
+		contains string
+		exitCode int
+	}{
+		"Test Positive-1": {
+			output:   outputTable,
+			contains: "pod-delete  Completed  Fail     50",
+		},
+		"Test Positive-2": {
+			output:   outputJUnit,
+			contains: `<failure type="Completed" message="http probe failed">`,
+		},
+		"Test Positive-3": {
+			output:   outputJSON,
+			contains: `"probeSuccessPercentage": "50"`,
+		},
+		"Test Negative-1": {
+			output:   "html",
+			exitCode: exitUsage,
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			newFakeCluster(t, newEngine(v1alpha1.EngineStatusCompleted, string(v1alpha1.ResultVerdictPassed)), result)
+			stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+			if exitCode := run(context.Background(), []string{"report", "--output", mock.output, "nginx-chaos"}, stdout, stderr); exitCode != mock.exitCode {
+				t.Fatalf("Test %q failed: expected exit code %v, received %v, stderr: %v", name, mock.exitCode, exitCode, stderr)
+			}
+			if !strings.Contains(stdout.String(), mock.contains) {
+				t.Fatalf("Test %q failed: expected the output to contain %q, received %v", name, mock.contains, stdout)
+			}
+			if mock.output == outputJSON {
+				decoded := report.Report{}
+				if err := json.Unmarshal(stdout.Bytes(), &decoded); err != nil || decoded.Experiments[0].Verdict != "Fail" {
+					t.Fatalf("Test %q failed: unexpected json report %v, error: %v", name, stdout, err)
+				}
+			}
+		})
+	}
+}
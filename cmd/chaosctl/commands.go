@@ -0,0 +1,300 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	litmuschaosv1alpha1 "github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/chaos-operator/controllers"
+	"github.com/litmuschaos/chaos-operator/pkg/config"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// SeverityError marks a manifest which the operator rejects
+	SeverityError = "error"
+	// SeverityWarning marks a manifest which the operator accepts, with a likely mistake
+	SeverityWarning = "warning"
+)
+
+// Diagnostic is a finding of the validation of a manifest
+type Diagnostic struct {
+	File     string `json:"file"`
+	Document int    `json:"document"`
+	Kind     string `json:"kind"`
+	Name     string `json:"name"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// newScheme returns the scheme of the runner pods and their owner chaosengines
+func newScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(litmuschaosv1alpha1.AddToScheme(scheme))
+	return scheme
+}
+
+func runValidate(_ context.Context, args []string, stdout io.Writer) error {
+	opts := &options{}
+	flags := flag.NewFlagSet("validate", flag.ContinueOnError)
+	opts.bind(flags, outputText, outputJSON)
+	if err := parseFlags(flags, args, opts, outputText, outputJSON); err != nil {
+		return err
+	}
+	operatorConfig, err := opts.operatorConfig()
+	if err != nil {
+		return err
+	}
+	documents, err := readDocuments(flags.Args(), opts.namespace)
+	if err != nil {
+		return err
+	}
+
+	diagnostics := []Diagnostic{}
+	for _, doc := range documents {
+		diagnostics = append(diagnostics, validateDocument(doc, operatorConfig)...)
+	}
+
+	if opts.output == outputJSON {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(diagnostics); err != nil {
+			return err
+		}
+	} else {
+		for _, diagnostic := range diagnostics {
+			fmt.Fprintf(stdout, "%v#%v: %v %v/%v: %v\n", diagnostic.File, diagnostic.Document, diagnostic.Severity,
+				diagnostic.Kind, diagnostic.Name, diagnostic.Message)
+		}
+	}
+
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Severity == SeverityError {
+			return errFindings
+		}
+	}
+	return nil
+}
+
+// validateDocument returns the diagnostics of a chaos manifest, the other manifests are skipped
+func validateDocument(doc document, operatorConfig *config.OperatorConfig) []Diagnostic {
+	var diagnostics []Diagnostic
+	report := func(severity, format string, args ...interface{}) {
+		diagnostics = append(diagnostics, Diagnostic{
+			File:     doc.File,
+			Document: doc.Index,
+			Kind:     doc.Kind,
+			Name:     doc.Metadata.Name,
+			Severity: severity,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	switch {
+	case doc.isChaosEngine():
+		engine, strictErr, err := decodeEngine(doc)
+		if err != nil {
+			report(SeverityError, "%v", err)
+			return diagnostics
+		}
+		if strictErr != nil {
+			report(SeverityWarning, "the fields unknown to %v are dropped: %v", doc.APIVersion, strictErr)
+		}
+		validateEngine(engine, operatorConfig, report)
+	case doc.Kind == kindChaosExperiment && doc.APIVersion == litmuschaosv1alpha1.SchemeGroupVersion.String():
+		experiment := &litmuschaosv1alpha1.ChaosExperiment{}
+		strictErr, err := decode(doc, experiment)
+		if err != nil {
+			report(SeverityError, "%v", err)
+			return diagnostics
+		}
+		if strictErr != nil {
+			report(SeverityWarning, "the fields unknown to %v are dropped: %v", doc.APIVersion, strictErr)
+		}
+		validateExperiment(experiment, report)
+	case strings.HasPrefix(doc.APIVersion, litmuschaosv1alpha1.SchemeGroupVersion.Group+"/"):
+		report(SeverityWarning, "%v %v is not validated", doc.APIVersion, doc.Kind)
+	}
+	return diagnostics
+}
+
+// validateEngine applies the checks of the reconcile, which stops the chaosengine on their errors
+func validateEngine(engine *litmuschaosv1alpha1.ChaosEngine, operatorConfig *config.OperatorConfig, report func(severity, format string, args ...interface{})) {
+	if engine.Name == "" {
+		report(SeverityError, "metadata.name is required")
+	}
+	switch engine.Spec.EngineState {
+	case "", litmuschaosv1alpha1.EngineStateActive, litmuschaosv1alpha1.EngineStateStop:
+	default:
+		report(SeverityError, "unsupported engineState %q, supported values: %v, %v", engine.Spec.EngineState,
+			litmuschaosv1alpha1.EngineStateActive, litmuschaosv1alpha1.EngineStateStop)
+	}
+
+	info, err := controllers.EvaluateEngine(engine, operatorConfig)
+	if err != nil {
+		report(SeverityError, "%v", err)
+		return
+	}
+	if _, err := controllers.RenderRunnerPod(info, operatorConfig, newScheme()); err != nil {
+		report(SeverityError, "unable to build the runner pod, due to error: %v", err)
+	}
+	for _, experiment := range engine.Spec.Experiments {
+		if experiment.Name == "" {
+			report(SeverityError, "experiments[].name is required")
+		}
+	}
+}
+
+// validateExperiment checks the fields of the chaosexperiment required by the runner
+func validateExperiment(experiment *litmuschaosv1alpha1.ChaosExperiment, report func(severity, format string, args ...interface{})) {
+	definition := experiment.Spec.Definition
+	if definition.Image == "" {
+		report(SeverityError, "spec.definition.image is required")
+	}
+	for _, configMap := range definition.ConfigMaps {
+		if configMap.Name == "" || configMap.MountPath == "" {
+			report(SeverityError, "spec.definition.configmaps[] require the name and the mountPath")
+		}
+	}
+	for _, secret := range definition.Secrets {
+		if secret.Name == "" || secret.MountPath == "" {
+			report(SeverityError, "spec.definition.secrets[] require the name and the mountPath")
+		}
+	}
+}
+
+func runRender(_ context.Context, args []string, stdout io.Writer) error {
+	opts := &options{}
+	flags := flag.NewFlagSet("render", flag.ContinueOnError)
+	opts.bind(flags, outputYAML, outputJSON)
+	if err := parseFlags(flags, args, opts, outputYAML, outputJSON); err != nil {
+		return err
+	}
+	operatorConfig, err := opts.operatorConfig()
+	if err != nil {
+		return err
+	}
+	documents, err := readDocuments(flags.Args(), opts.namespace)
+	if err != nil {
+		return err
+	}
+
+	scheme := newScheme()
+	var pods []*corev1.Pod
+	for _, doc := range documents {
+		if !doc.isChaosEngine() {
+			continue
+		}
+		engine, _, err := decodeEngine(doc)
+		if err != nil {
+			return err
+		}
+		info, err := controllers.EvaluateEngine(engine, operatorConfig)
+		if err != nil {
+			return fmt.Errorf("%v: the operator stops the chaosengine %v: %v", doc, engine.Name, err)
+		}
+		pod, err := controllers.RenderRunnerPod(info, operatorConfig, scheme)
+		if err != nil {
+			return fmt.Errorf("%v: unable to build the runner pod of %v, due to error: %v", doc, engine.Name, err)
+		}
+		pod.APIVersion, pod.Kind = "v1", "Pod"
+		pods = append(pods, pod)
+	}
+
+	for i, pod := range pods {
+		var data []byte
+		if opts.output == outputJSON {
+			data, err = json.MarshalIndent(pod, "", "  ")
+			data = append(data, '\n')
+		} else {
+			data, err = yaml.Marshal(pod)
+			if i > 0 {
+				data = append([]byte("---\n"), data...)
+			}
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := stdout.Write(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runTargets(_ context.Context, args []string, stdout io.Writer) error {
+	opts := &options{}
+	flags := flag.NewFlagSet("targets", flag.ContinueOnError)
+	opts.bind(flags, outputText, outputJSON)
+	if err := parseFlags(flags, args, opts, outputText, outputJSON); err != nil {
+		return err
+	}
+	operatorConfig, err := opts.operatorConfig()
+	if err != nil {
+		return err
+	}
+	documents, err := readDocuments(flags.Args(), opts.namespace)
+	if err != nil {
+		return err
+	}
+
+	type engineTargets struct {
+		Namespace string               `json:"namespace"`
+		Name      string               `json:"name"`
+		Targets   string               `json:"targets"`
+		Decoded   []controllers.Target `json:"decoded"`
+	}
+	var engines []engineTargets
+	for _, doc := range documents {
+		if !doc.isChaosEngine() {
+			continue
+		}
+		engine, _, err := decodeEngine(doc)
+		if err != nil {
+			return err
+		}
+		info, err := controllers.EvaluateEngine(engine, operatorConfig)
+		if err != nil {
+			return fmt.Errorf("%v: the operator stops the chaosengine %v: %v", doc, engine.Name, err)
+		}
+		engines = append(engines, engineTargets{Namespace: engine.Namespace, Name: engine.Name, Targets: info.Targets, Decoded: controllers.DecodeTargets(info.Targets)})
+	}
+
+	if opts.output == outputJSON {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(engines)
+	}
+	for _, engine := range engines {
+		fmt.Fprintf(stdout, "%v/%v: %v\n", engine.Namespace, engine.Name, engine.Targets)
+		for _, target := range engine.Decoded {
+			fmt.Fprintf(stdout, "  kind=%v namespace=%v filter=%v\n", target.Kind, target.Namespace, target.Filter)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,297 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	litmuschaosv1alpha1 "github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/chaos-operator/pkg/report"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	outputTable = "table"
+	outputJUnit = "junit"
+)
+
+func runRun(ctx context.Context, args []string, stdout io.Writer) error {
+	opts := &clusterOptions{}
+	flags := flag.NewFlagSet("run", flag.ContinueOnError)
+	opts.bind(flags)
+	name := flags.String("name", "", "The name of the created chaosengine, it overrides the name and the generateName of the template.")
+	wait := flags.Bool("wait", true, "Wait for the run to finish and print its report. The exit code is 1 unless all the experiments passed.")
+	template, err := parseClusterFlags(flags, args, "chaosengine template")
+	if err != nil {
+		return err
+	}
+	c, namespace, err := newClients(opts)
+	if err != nil {
+		return err
+	}
+	documents, err := readDocuments([]string{template}, namespace)
+	if err != nil {
+		return err
+	}
+
+	var engine *litmuschaosv1alpha1.ChaosEngine
+	for _, doc := range documents {
+		if !doc.isChaosEngine() {
+			continue
+		}
+		if engine != nil {
+			return fmt.Errorf("expected a single chaosengine in %v", template)
+		}
+		if engine, _, err = decodeEngine(doc); err != nil {
+			return err
+		}
+	}
+	if engine == nil {
+		return fmt.Errorf("no chaosengine found in %v", template)
+	}
+
+	engine.ObjectMeta = metav1.ObjectMeta{
+		Name:         engine.Name,
+		GenerateName: engine.GenerateName,
+		Namespace:    engine.Namespace,
+		Labels:       engine.Labels,
+		Annotations:  engine.Annotations,
+	}
+	if *name != "" {
+		engine.Name, engine.GenerateName = *name, ""
+	}
+	if engine.Name == "" && engine.GenerateName == "" {
+		return fmt.Errorf("the chaosengine template requires a name or a generateName, or the name flag")
+	}
+	engine.Spec.EngineState = litmuschaosv1alpha1.EngineStateActive
+	engine.Status = litmuschaosv1alpha1.ChaosEngineStatus{}
+
+	created, err := c.litmus.LitmuschaosV1alpha1().ChaosEngines(engine.Namespace).Create(ctx, engine, metav1.CreateOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to create the chaosengine, due to error: %v", err)
+	}
+	fmt.Fprintf(stdout, "chaosengine %v/%v created\n", created.Namespace, created.Name)
+	if !*wait {
+		return nil
+	}
+
+	ctx, cancel := opts.withTimeout(ctx)
+	defer cancel()
+	finished, err := follow(ctx, c, created.Namespace, created.Name, stdout, isFinished)
+	if err != nil {
+		return err
+	}
+	return printRunReport(ctx, c, finished, stdout)
+}
+
+func runWatch(ctx context.Context, args []string, stdout io.Writer) error {
+	opts := &clusterOptions{}
+	flags := flag.NewFlagSet("watch", flag.ContinueOnError)
+	opts.bind(flags)
+	name, err := parseClusterFlags(flags, args, "chaosengine name")
+	if err != nil {
+		return err
+	}
+	c, namespace, err := newClients(opts)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := opts.withTimeout(ctx)
+	defer cancel()
+	_, err = follow(ctx, c, namespace, name, stdout, isFinished)
+	return err
+}
+
+func runAbort(ctx context.Context, args []string, stdout io.Writer) error {
+	opts := &clusterOptions{}
+	flags := flag.NewFlagSet("abort", flag.ContinueOnError)
+	opts.bind(flags)
+	name, err := parseClusterFlags(flags, args, "chaosengine name")
+	if err != nil {
+		return err
+	}
+	c, namespace, err := newClients(opts)
+	if err != nil {
+		return err
+	}
+
+	engine, err := c.litmus.LitmuschaosV1alpha1().ChaosEngines(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if isFinished(engine) {
+		fmt.Fprintf(stdout, "chaosengine %v/%v is already %v\n", namespace, name, engine.Status.EngineStatus)
+		return nil
+	}
+	if err := patchEngineState(ctx, c, namespace, name, litmuschaosv1alpha1.EngineStateStop); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "chaosengine %v/%v is being aborted\n", namespace, name)
+	// the operator only stops the initialized chaosengines, the pending ones never start once stopped
+	if engine.Status.EngineStatus == "" {
+		return nil
+	}
+
+	ctx, cancel := opts.withTimeout(ctx)
+	defer cancel()
+	stopped, err := follow(ctx, c, namespace, name, stdout, isFinished)
+	if err != nil {
+		return err
+	}
+	if err := waitForCleanup(ctx, c, stopped); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "chaos resources of %v/%v are cleaned up\n", namespace, name)
+	return nil
+}
+
+func runRerun(ctx context.Context, args []string, stdout io.Writer) error {
+	opts := &clusterOptions{}
+	flags := flag.NewFlagSet("rerun", flag.ContinueOnError)
+	opts.bind(flags)
+	wait := flags.Bool("wait", true, "Wait for the run to finish and print its report. The exit code is 1 unless all the experiments passed.")
+	name, err := parseClusterFlags(flags, args, "chaosengine name")
+	if err != nil {
+		return err
+	}
+	c, namespace, err := newClients(opts)
+	if err != nil {
+		return err
+	}
+
+	engine, err := c.litmus.LitmuschaosV1alpha1().ChaosEngines(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if !isFinished(engine) {
+		return fmt.Errorf("chaosengine %v is not finished, it can be aborted before the rerun", name)
+	}
+	if err := patchEngineState(ctx, c, namespace, name, litmuschaosv1alpha1.EngineStateActive); err != nil {
+		return err
+	}
+	fmt.Fprintf(stdout, "chaosengine %v/%v is restarted\n", namespace, name)
+	if !*wait {
+		return nil
+	}
+
+	// the previous run is finished as well, the rerun is awaited until the operator has initialized it
+	restarted := false
+	isRerunFinished := func(engine *litmuschaosv1alpha1.ChaosEngine) bool {
+		if engine.Status.EngineStatus == litmuschaosv1alpha1.EngineStatusInitialized {
+			restarted = true
+		}
+		return restarted && isFinished(engine)
+	}
+	ctx, cancel := opts.withTimeout(ctx)
+	defer cancel()
+	finished, err := follow(ctx, c, namespace, name, stdout, isRerunFinished)
+	if err != nil {
+		return err
+	}
+	return printRunReport(ctx, c, finished, stdout)
+}
+
+func runReport(ctx context.Context, args []string, stdout io.Writer) error {
+	opts := &clusterOptions{}
+	flags := flag.NewFlagSet("report", flag.ContinueOnError)
+	opts.bind(flags)
+	output := flags.String("output", outputTable, fmt.Sprintf("The output format, supported values: %v.", []string{outputTable, outputJSON, outputJUnit}))
+	name, err := parseClusterFlags(flags, args, "chaosengine name")
+	if err != nil {
+		return err
+	}
+	switch *output {
+	case outputTable, outputJSON, outputJUnit:
+	default:
+		return fmt.Errorf("unsupported output %q, supported values: %v", *output, []string{outputTable, outputJSON, outputJUnit})
+	}
+	c, namespace, err := newClients(opts)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := opts.withTimeout(ctx)
+	defer cancel()
+	engine, err := c.litmus.LitmuschaosV1alpha1().ChaosEngines(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	runReport, err := buildReport(ctx, c, engine)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	switch *output {
+	case outputJSON:
+		data, err = runReport.JSON()
+		data = append(data, '\n')
+	case outputJUnit:
+		data, err = runReport.JUnit()
+		data = append(data, '\n')
+	default:
+		return printReport(runReport, stdout)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = stdout.Write(data)
+	return err
+}
+
+// patchEngineState patches the engineState of the chaosengine, on which the operator starts or stops the run
+func patchEngineState(ctx context.Context, c *clients, namespace, name string, state litmuschaosv1alpha1.EngineState) error {
+	data := []byte(fmt.Sprintf(`{"spec":{"engineState":%q}}`, state))
+	if _, err := c.litmus.LitmuschaosV1alpha1().ChaosEngines(namespace).Patch(ctx, name, types.MergePatchType, data, metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("unable to patch the engineState of %v to %v, due to error: %v", name, state, err)
+	}
+	return nil
+}
+
+// printRunReport prints the report of the finished run, it returns errRunFailed unless all the experiments passed
+func printRunReport(ctx context.Context, c *clients, engine *litmuschaosv1alpha1.ChaosEngine, stdout io.Writer) error {
+	runReport, err := buildReport(ctx, c, engine)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(stdout)
+	if err := printReport(runReport, stdout); err != nil {
+		return err
+	}
+	if !isPassed(runReport) {
+		return errRunFailed
+	}
+	return nil
+}
+
+// printReport prints the report as a table of the experiments
+func printReport(runReport report.Report, stdout io.Writer) error {
+	fmt.Fprintf(stdout, "chaosengine %v/%v: %v, resilience score: %v, duration: %.0fs\n", runReport.Namespace, runReport.Engine,
+		runReport.EngineStatus, runReport.ResilienceScore, runReport.DurationSeconds)
+	w := tabwriter.NewWriter(stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "EXPERIMENT\tPHASE\tVERDICT\tPROBE SUCCESS\tDURATION")
+	for _, exp := range runReport.Experiments {
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%.0fs\n", exp.Name, exp.Phase, exp.Verdict, exp.ProbeSuccessPercentage, exp.DurationSeconds)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// chaosctl validates and renders the chaos manifests with the logic of the chaos-operator,
+// and runs, watches, aborts and reports the chaosengines of a cluster
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/go-logr/logr"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// exitFindings is returned if any of the manifests has an error diagnostic, or if a run has not passed
+	exitFindings = 1
+	// exitUsage is returned on the invalid args, an unreadable input or a failed request to the cluster
+	exitUsage = 2
+)
+
+// errFindings is returned by the commands which have reported the errors of the manifests
+var errFindings = errors.New("the manifests have errors")
+
+// errRunFailed is returned by the commands which have reported a chaosengine run which has not passed
+var errRunFailed = errors.New("the chaosengine run has not passed")
+
+// command is a subcommand of chaosctl
+type command struct {
+	name  string
+	usage string
+	run   func(ctx context.Context, args []string, stdout io.Writer) error
+}
+
+// commands lists the subcommands in the order of the usage
+var commands = []command{
+	{name: "validate", usage: "validate the chaosengines and chaosexperiments with the checks of the operator", run: runValidate},
+	{name: "render", usage: "render the runner pods which the operator creates for the chaosengines", run: runRender},
+	{name: "targets", usage: "show the targets which the operator derives from the chaosengines", run: runTargets},
+	{name: "run", usage: "create a chaosengine from a template and wait for its report", run: runRun},
+	{name: "watch", usage: "stream the status, the experiment statuses and the events of a chaosengine", run: runWatch},
+	{name: "abort", usage: "stop a running chaosengine and wait for the cleanup of its chaos resources", run: runAbort},
+	{name: "rerun", usage: "restart a finished chaosengine and wait for its report", run: runRerun},
+	{name: "report", usage: "print the report of the last run of a chaosengine as a table, json or junit", run: runReport},
+}
+
+func main() {
+	// the operator logic logs through the controller-runtime logger, which is silenced for the cli
+	ctrllog.SetLogger(logr.Discard())
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	exitCode := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	os.Exit(exitCode)
+}
+
+// run executes the subcommand and returns the exit code
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		printUsage(stderr)
+		return exitUsage
+	}
+	for _, cmd := range commands {
+		if cmd.name != args[0] {
+			continue
+		}
+		err := cmd.run(ctx, args[1:], stdout)
+		switch {
+		case err == nil:
+			return 0
+		case errors.Is(err, errFindings), errors.Is(err, errRunFailed):
+			return exitFindings
+		case errors.Is(err, flag.ErrHelp):
+			return exitUsage
+		default:
+			fmt.Fprintf(stderr, "chaosctl %v: %v\n", cmd.name, err)
+			return exitUsage
+		}
+	}
+	fmt.Fprintf(stderr, "chaosctl: unknown command %q\n", args[0])
+	printUsage(stderr)
+	return exitUsage
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintf(w, "Usage: chaosctl <command> [flags] <args>...\n\nCommands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-10s %v\n", cmd.name, cmd.usage)
+	}
+	fmt.Fprintf(w, "\nThe manifests are read from the given files, or from stdin for -. The flags precede the args, run chaosctl <command> -h for the flags.\n")
+}
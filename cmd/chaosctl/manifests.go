@@ -0,0 +1,192 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	litmuschaosv1alpha1 "github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	litmuschaosv1beta1 "github.com/litmuschaos/chaos-operator/api/litmuschaos/v1beta1"
+	"github.com/litmuschaos/chaos-operator/pkg/config"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	kindChaosEngine     = "ChaosEngine"
+	kindChaosExperiment = "ChaosExperiment"
+
+	outputText = "text"
+	outputJSON = "json"
+	outputYAML = "yaml"
+)
+
+// document is a single manifest of an input file
+type document struct {
+	// File is the path of the input file, - for stdin
+	File string
+	// Index is the position of the document within the file, counted from zero
+	Index int
+	metav1.TypeMeta
+	Metadata metav1.ObjectMeta
+
+	raw []byte
+}
+
+// String returns the location of the document for the diagnostics
+func (doc document) String() string {
+	return fmt.Sprintf("%v#%v", doc.File, doc.Index)
+}
+
+// isChaosEngine returns true for the chaosengines of the supported versions
+func (doc document) isChaosEngine() bool {
+	return doc.Kind == kindChaosEngine && (doc.APIVersion == litmuschaosv1alpha1.SchemeGroupVersion.String() ||
+		doc.APIVersion == litmuschaosv1beta1.SchemeGroupVersion.String())
+}
+
+// options contains the flags shared by the commands
+type options struct {
+	configFile string
+	namespace  string
+	output     string
+}
+
+// bind registers the shared flags on the flagset of a command
+func (opts *options) bind(flags *flag.FlagSet, outputs ...string) {
+	flags.StringVar(&opts.configFile, "config", "",
+		"The path of the "+config.Kind+" file of the operator, whose runner defaults are applied. The defaults of the operator are used if empty.")
+	flags.StringVar(&opts.namespace, "namespace", "default", "The namespace of the manifests which do not specify one.")
+	flags.StringVar(&opts.output, "output", outputs[0], fmt.Sprintf("The output format, supported values: %v.", outputs))
+}
+
+// operatorConfig returns the config of the operator, as loaded by the operator
+func (opts *options) operatorConfig() (*config.OperatorConfig, error) {
+	if opts.configFile == "" {
+		return config.Default(), nil
+	}
+	return config.Load(opts.configFile)
+}
+
+// parseFlags parses the args of the command, at least one input file is required
+func parseFlags(flags *flag.FlagSet, args []string, opts *options, outputs ...string) error {
+	flags.SetOutput(os.Stderr)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() == 0 {
+		return fmt.Errorf("no manifests specified")
+	}
+	for _, output := range outputs {
+		if opts.output == output {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported output %q, supported values: %v", opts.output, outputs)
+}
+
+// readDocuments reads the yaml or json documents of the given files, - reads stdin
+func readDocuments(paths []string, defaultNamespace string) ([]document, error) {
+	var documents []document
+	for _, path := range paths {
+		fileDocuments, err := readFile(path, defaultNamespace)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, fileDocuments...)
+	}
+	return documents, nil
+}
+
+// readFile reads the documents of a single file
+func readFile(path, defaultNamespace string) ([]document, error) {
+	r := io.Reader(os.Stdin)
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		r = file
+	}
+
+	var documents []document
+	reader := k8syaml.NewYAMLReader(bufio.NewReader(r))
+	for {
+		raw, err := reader.Read()
+		if err == io.EOF {
+			return documents, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %v, due to error: %v", path, err)
+		}
+		if len(bytes.TrimSpace(raw)) == 0 {
+			continue
+		}
+
+		doc := document{File: path, Index: len(documents), raw: raw}
+		header := struct {
+			metav1.TypeMeta `json:",inline"`
+			Metadata        metav1.ObjectMeta `json:"metadata"`
+		}{}
+		if err := yaml.Unmarshal(raw, &header); err != nil {
+			return nil, fmt.Errorf("unable to decode %v, due to error: %v", doc, err)
+		}
+		doc.TypeMeta = header.TypeMeta
+		doc.Metadata = header.Metadata
+		if doc.Metadata.Namespace == "" {
+			doc.Metadata.Namespace = defaultNamespace
+		}
+		documents = append(documents, doc)
+	}
+}
+
+// decodeEngine decodes the chaosengine document into the hub version, the v1beta1 chaosengines are
+// converted as by the conversion webhook. The strict error reports the fields unknown to the api
+func decodeEngine(doc document) (engine *litmuschaosv1alpha1.ChaosEngine, strictErr error, err error) {
+	engine = &litmuschaosv1alpha1.ChaosEngine{}
+	if doc.APIVersion == litmuschaosv1beta1.SchemeGroupVersion.String() {
+		v1beta1Engine := &litmuschaosv1beta1.ChaosEngine{}
+		if strictErr, err = decode(doc, v1beta1Engine); err != nil {
+			return nil, strictErr, err
+		}
+		if err := v1beta1Engine.ConvertTo(engine); err != nil {
+			return nil, strictErr, err
+		}
+	} else if strictErr, err = decode(doc, engine); err != nil {
+		return nil, strictErr, err
+	}
+	engine.Namespace = doc.Metadata.Namespace
+	return engine, strictErr, nil
+}
+
+// decode decodes the document into the object, the strict error reports the unknown fields
+func decode(doc document, obj interface{}) (strictErr error, err error) {
+	strictErr = yaml.UnmarshalStrict(doc.raw, obj)
+	if strictErr == nil {
+		return nil, nil
+	}
+	if err := yaml.Unmarshal(doc.raw, obj); err != nil {
+		return nil, fmt.Errorf("unable to decode %v, due to error: %v", doc, err)
+	}
+	return strictErr, nil
+}
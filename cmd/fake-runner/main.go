@@ -0,0 +1,141 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// fake-runner replaces the chaos-runner image for the end-to-end tests of the operator,
+// it completes the experiments of the chaosengine as per the scenario of its args without injecting any chaos
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/chaos-operator/pkg/fakerunner"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// exitCrashed is returned if the scenario crashes the runner
+	exitCrashed = 1
+	// exitUsage is returned on the invalid args or env
+	exitUsage = 2
+	// exitError is returned if the simulated run has failed to write the chaosresults or the chaosengine
+	exitError = 3
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	scenario, err := parseScenario(os.Args[1:], os.Stderr)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(exitUsage)
+	}
+	env, err := fakerunner.EnvFrom(os.Getenv)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(exitUsage)
+	}
+
+	scheme := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(exitError)
+	}
+	cfg, err := ctrl.GetConfig()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(exitError)
+	}
+	c, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(exitError)
+	}
+
+	if err := fakerunner.Run(ctx, c, env, scenario); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		if errors.Is(err, fakerunner.ErrCrashed) {
+			os.Exit(exitCrashed)
+		}
+		os.Exit(exitError)
+	}
+	fmt.Printf("completed the experiments %v of chaosengine %v/%v\n", strings.Join(env.Experiments, ","), env.Namespace, env.EngineName)
+}
+
+// parseScenario parses the scenario from the args of the runner container
+func parseScenario(args []string, output io.Writer) (fakerunner.Scenario, error) {
+	scenario := fakerunner.Scenario{ExperimentVerdicts: map[string]v1alpha1.ResultVerdict{}}
+
+	flags := flag.NewFlagSet("fake-runner", flag.ContinueOnError)
+	flags.SetOutput(output)
+	flags.Func("verdict", "verdict of the experiments, i.e. Pass, Fail, Stopped or Error (default Pass)", func(value string) error {
+		verdict, err := fakerunner.ParseVerdict(value)
+		scenario.Verdict = verdict
+		return err
+	})
+	flags.Func("experiment-verdict", "verdict of an experiment as <experiment>=<verdict>, can be repeated", func(value string) error {
+		fields := strings.SplitN(value, "=", 2)
+		if len(fields) != 2 || fields[0] == "" {
+			return fmt.Errorf("expected <experiment>=<verdict>")
+		}
+		verdict, err := fakerunner.ParseVerdict(fields[1])
+		scenario.ExperimentVerdicts[fields[0]] = verdict
+		return err
+	})
+	flags.Func("probe", "probe status of the experiments as <name>:<type>:<mode>:<verdict>, can be repeated", func(value string) error {
+		probe, err := fakerunner.ParseProbe(value)
+		scenario.Probes = append(scenario.Probes, probe)
+		return err
+	})
+	flags.Func("target", "target annotated on the chaosresults as <kind>/<name>, can be repeated (default the named targets of the chaosengine)", func(value string) error {
+		if fields := strings.Split(value, "/"); len(fields) != 2 || fields[0] == "" || fields[1] == "" {
+			return fmt.Errorf("expected <kind>/<name>")
+		}
+		scenario.Targets = append(scenario.Targets, value)
+		return nil
+	})
+	flags.StringVar(&scenario.TargetStatus, "target-status", "reverted", "chaos status of the targets, i.e. injected, reverted, targeted or empty to skip the annotations")
+	flags.DurationVar(&scenario.Duration, "duration", 0, "time for which the experiments stay running")
+	flags.BoolVar(&scenario.Hang, "hang", false, "keep the experiments running until the runner is terminated")
+	flags.BoolVar(&scenario.Crash, "crash", false, "exit with an error once the experiments are running")
+
+	if err := flags.Parse(args); err != nil {
+		return fakerunner.Scenario{}, err
+	}
+	if flags.NArg() != 0 {
+		return fakerunner.Scenario{}, fmt.Errorf("unexpected args %v", flags.Args())
+	}
+	switch scenario.TargetStatus {
+	case "", "injected", "reverted", "targeted":
+	default:
+		return fakerunner.Scenario{}, fmt.Errorf("unsupported target status %q, supported statuses: injected, reverted, targeted", scenario.TargetStatus)
+	}
+	if scenario.Hang && scenario.Crash {
+		return fakerunner.Scenario{}, fmt.Errorf("--hang and --crash are mutually exclusive")
+	}
+	return scenario, nil
+}
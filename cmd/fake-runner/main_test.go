@@ -0,0 +1,57 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+)
+
+func TestParseScenario(t *testing.T) {
+	tests := map[string]struct {
+		args  []string
+		isErr bool
+	}{
+		"Test Positive-1": {args: nil},
+		"Test Positive-2": {args: []string{"--verdict", "Fail", "--experiment-verdict", "pod-delete=Pass", "--probe", "check-frontend:httpProbe:SOT:Failed", "--target", "deployment/nginx", "--target-status", "injected", "--duration", "5s"}},
+		"Test Negative-1": {args: []string{"--verdict", "Awaited"}, isErr: true},
+		"Test Negative-2": {args: []string{"--experiment-verdict", "pod-delete"}, isErr: true},
+		"Test Negative-3": {args: []string{"--target", "nginx"}, isErr: true},
+		"Test Negative-4": {args: []string{"--target-status", "pending"}, isErr: true},
+		"Test Negative-5": {args: []string{"--hang", "--crash"}, isErr: true},
+		"Test Negative-6": {args: []string{"pod-delete"}, isErr: true},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := parseScenario(mock.args, io.Discard); (err != nil) != mock.isErr {
+				t.Fatalf("Test %q failed: expected error %v, received %v", name, mock.isErr, err)
+			}
+		})
+	}
+
+	scenario, err := parseScenario([]string{"--verdict", "Fail", "--experiment-verdict", "pod-delete=Pass", "--duration", "5s"}, io.Discard)
+	if err != nil {
+		t.Fatalf("unable to parse the scenario, due to error: %v", err)
+	}
+	if scenario.Verdict != v1alpha1.ResultVerdictFailed || scenario.ExperimentVerdicts["pod-delete"] != v1alpha1.ResultVerdictPassed ||
+		scenario.Duration != 5*time.Second || scenario.TargetStatus != "reverted" {
+		t.Fatalf("unexpected scenario %+v", scenario)
+	}
+}
@@ -22,26 +22,38 @@ import (
 	"github.com/go-logr/logr"
 	litmuschaosv1alpha1 "github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
 	"github.com/litmuschaos/chaos-operator/pkg/analytics"
-	dynamicclientset "github.com/litmuschaos/chaos-operator/pkg/client/dynamic"
+	"github.com/litmuschaos/chaos-operator/pkg/audit"
+	"github.com/litmuschaos/chaos-operator/pkg/cloudevents"
+	"github.com/litmuschaos/chaos-operator/pkg/config"
+	"github.com/litmuschaos/chaos-operator/pkg/health"
+	"github.com/litmuschaos/chaos-operator/pkg/metrics"
+	"github.com/litmuschaos/chaos-operator/pkg/report"
+	"github.com/litmuschaos/chaos-operator/pkg/sharding"
+	"github.com/litmuschaos/chaos-operator/pkg/timeout"
+	"github.com/litmuschaos/chaos-operator/pkg/tracing"
 	chaosTypes "github.com/litmuschaos/chaos-operator/pkg/types"
 	"github.com/litmuschaos/chaos-operator/pkg/utils"
 	"github.com/litmuschaos/chaos-operator/pkg/utils/retry"
 	"github.com/litmuschaos/elves/kubernetes/container"
 	"github.com/litmuschaos/elves/kubernetes/pod"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
-	"os"
+	k8sretry "k8s.io/client-go/util/retry"
 	"reflect"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"strings"
 	"time"
@@ -49,6 +61,9 @@ import (
 
 const finalizer = "chaosengine.litmuschaos.io/finalizer"
 
+// errEmptyExperimentList is returned for a chaosengine without experiments, no runner is launched for it
+var errEmptyExperimentList = errors.New("application experiment list is empty")
+
 // ChaosEngineReconciler reconciles a ChaosEngine object
 type ChaosEngineReconciler struct {
 	// This client, initialized using mgr.Client() above, is a split client
@@ -59,6 +74,19 @@ type ChaosEngineReconciler struct {
 	// recorder is an event recorder for recording Event resources to the
 	// Kubernetes API.
 	Recorder record.EventRecorder
+	// CloudEvents publishes the lifecycle transitions of the chaosengines, it is optional
+	CloudEvents *cloudevents.Publisher
+	// Audit persists the audit trail of the chaosengine runs, it is optional
+	Audit audit.Sink
+	// Shards restricts the reconcile to the namespaces of the shards owned by this replica, it is optional
+	Shards *sharding.Sharder
+	// Config holds the effective operator config, the defaults are used if it is not set
+	Config *config.Store
+	// Watchdog tracks the running reconciles for the liveness check, it is optional
+	Watchdog *health.Watchdog
+	// APIReader reads the chaosengines from the apiserver, bypassing the cache of the client, to retry the
+	// status patches on the conflicts with the latest chaosengine. The client is used if it is not set
+	APIReader client.Reader
 }
 
 // reconcileEngine contains details of reconcileEngine
@@ -70,27 +98,48 @@ type reconcileEngine struct {
 // podEngineRunner contains the information of pod
 type podEngineRunner struct {
 	pod, engineRunner *corev1.Pod
+	engine            *chaosTypes.EngineInfo
 	*reconcileEngine
 }
 
 //+kubebuilder:rbac:groups=litmuschaos.io,resources=chaosengines,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=litmuschaos.io,resources=chaosengines/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=litmuschaos.io,resources=chaosengines/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;deletecollection
 
 // Reconcile reads that state of the cluster for a ChaosEngine object and makes changes based on the state read
 // and what is in the ChaosEngine.Spec
 // Note:
 // The Controller will requeue the Request to be processed again if the returned error is non-nil or
 // Result.Requeue is true, otherwise upon completion it will remove the work from the queue
-func (r *ChaosEngineReconciler) Reconcile(ctx context.Context, request ctrl.Request) (ctrl.Result, error) {
+func (r *ChaosEngineReconciler) Reconcile(ctx context.Context, request ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracing.StartSpan(ctx, "Reconcile", attribute.String("chaosengine.namespace", request.Namespace), attribute.String("chaosengine.name", request.Name))
+	defer func() { tracing.EndSpan(span, err) }()
+
+	defer r.Watchdog.Track()()
+
+	ctx, cancel := timeout.WithReconcileTimeout(ctx, r.Config)
+	defer func() {
+		err = timeout.Observe(ctx, timeout.OperationReconcile, "ChaosEngine", err)
+		cancel()
+	}()
+
+	// the chaosengines of the shards owned by the other replicas are reconciled by them
+	if owned, err := r.Shards.Owns(ctx, request.Namespace); err != nil || !owned {
+		return reconcile.Result{}, err
+	}
+
 	reqLogger := startReqLogger(request)
 	engine := &chaosTypes.EngineInfo{}
 
-	if err := r.getChaosEngineInstance(engine, request); err != nil {
+	if err := r.getChaosEngineInstance(ctx, engine, request); err != nil {
 		if k8serrors.IsNotFound(err) {
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
+			metrics.DeleteResilienceScore(request.Namespace, request.Name)
 			return reconcile.Result{}, nil
 		}
 		return reconcile.Result{}, err
@@ -98,11 +147,11 @@ func (r *ChaosEngineReconciler) Reconcile(ctx context.Context, request ctrl.Requ
 
 	// Handle deletion of ChaosEngine
 	if engine.Instance.ObjectMeta.GetDeletionTimestamp() != nil {
-		return r.reconcileForDelete(engine, request)
+		return r.reconcileForDelete(ctx, engine, request)
 	}
 
 	// Start the reconcile by setting default values into ChaosEngine
-	if requeue, err := r.initEngine(engine); err != nil {
+	if requeue, err := r.initEngine(ctx, engine); err != nil {
 		if requeue {
 			return reconcile.Result{Requeue: true}, nil
 		}
@@ -111,27 +160,35 @@ func (r *ChaosEngineReconciler) Reconcile(ctx context.Context, request ctrl.Requ
 
 	// Handling of normal execution of ChaosEngine
 	if engine.Instance.Spec.EngineState == litmuschaosv1alpha1.EngineStateActive && engine.Instance.Status.EngineStatus == litmuschaosv1alpha1.EngineStatusInitialized {
-		return r.reconcileForCreationAndRunning(engine, reqLogger)
+		return r.reconcileForCreationAndRunning(ctx, engine, reqLogger)
 	}
 
 	// Handling Graceful completion of ChaosEngine
 	if engine.Instance.Spec.EngineState == litmuschaosv1alpha1.EngineStateStop && engine.Instance.Status.EngineStatus == litmuschaosv1alpha1.EngineStatusCompleted {
-		return r.reconcileForComplete(engine, request)
+		if result, err := r.reconcileForComplete(ctx, engine, request); err != nil {
+			return result, err
+		}
+		return r.reconcileForTTL(ctx, engine)
+	}
+
+	// Handling expiry of the stopped ChaosEngine
+	if engine.Instance.Spec.EngineState == litmuschaosv1alpha1.EngineStateStop && engine.Instance.Status.EngineStatus == litmuschaosv1alpha1.EngineStatusStopped {
+		return r.reconcileForTTL(ctx, engine)
 	}
 
 	// Handling forceful Abort of ChaosEngine
 	if engine.Instance.Spec.EngineState == litmuschaosv1alpha1.EngineStateStop && engine.Instance.Status.EngineStatus == litmuschaosv1alpha1.EngineStatusInitialized {
-		return r.reconcileForDelete(engine, request)
+		return r.reconcileForDelete(ctx, engine, request)
 	}
 
 	// Handling restarting of ChaosEngine post Abort
 	if engine.Instance.Spec.EngineState == litmuschaosv1alpha1.EngineStateActive && (engine.Instance.Status.EngineStatus == litmuschaosv1alpha1.EngineStatusStopped) {
-		return r.reconcileForRestartAfterAbort(engine, request)
+		return r.reconcileForRestartAfterAbort(ctx, engine, request)
 	}
 
 	// Handling restarting of ChaosEngine post Completion
 	if engine.Instance.Spec.EngineState == litmuschaosv1alpha1.EngineStateActive && (engine.Instance.Status.EngineStatus == litmuschaosv1alpha1.EngineStatusCompleted) {
-		return r.reconcileForRestartAfterComplete(engine, request)
+		return r.reconcileForRestartAfterComplete(ctx, engine, request)
 	}
 
 	return ctrl.Result{}, nil
@@ -147,7 +204,8 @@ func getChaosRunnerENV(engine *chaosTypes.EngineInfo, ClientUUID string) []corev
 		SetEnv("CHAOS_SVC_ACC", engine.Instance.Spec.ChaosServiceAccount).
 		SetEnv("AUXILIARY_APPINFO", engine.Instance.Spec.AuxiliaryAppInfo).
 		SetEnv("CLIENT_UUID", ClientUUID).
-		SetEnv("CHAOS_NAMESPACE", engine.Instance.Namespace)
+		SetEnv("CHAOS_NAMESPACE", engine.Instance.Namespace).
+		SetEnv(tracing.TraceParentEnv, engine.TraceParent)
 
 	return envDetails.ENV
 }
@@ -169,12 +227,13 @@ func getChaosRunnerLabels(cr *litmuschaosv1alpha1.ChaosEngine) map[string]string
 // newGoRunnerPodForCR defines a new go-based Runner Pod
 func (r *ChaosEngineReconciler) newGoRunnerPodForCR(engine *chaosTypes.EngineInfo) (*corev1.Pod, error) {
 	engine.VolumeOpts.VolumeOperations(engine.Instance.Spec.Components.Runner.ConfigMaps, engine.Instance.Spec.Components.Runner.Secrets)
+	runnerConfig := r.Config.Get().Runner
 
 	containerForRunner := container.NewBuilder().
 		WithEnvsNew(getChaosRunnerENV(engine, analytics.ClientUUID)).
 		WithName("chaos-runner").
 		WithImage(engine.Instance.Spec.Components.Runner.Image).
-		WithImagePullPolicy(corev1.PullIfNotPresent)
+		WithImagePullPolicy(runnerConfig.ImagePullPolicy)
 
 	if engine.Instance.Spec.Components.Runner.ImagePullPolicy != "" {
 		containerForRunner.WithImagePullPolicy(engine.Instance.Spec.Components.Runner.ImagePullPolicy)
@@ -194,6 +253,8 @@ func (r *ChaosEngineReconciler) newGoRunnerPodForCR(engine *chaosTypes.EngineInf
 
 	if !reflect.DeepEqual(engine.Instance.Spec.Components.Runner.Resources, corev1.ResourceRequirements{}) {
 		containerForRunner.WithResourceRequirements(engine.Instance.Spec.Components.Runner.Resources)
+	} else if !reflect.DeepEqual(runnerConfig.Resources, corev1.ResourceRequirements{}) {
+		containerForRunner.WithResourceRequirements(*runnerConfig.Resources.DeepCopy())
 	}
 
 	podForRunner := pod.NewBuilder().
@@ -232,10 +293,10 @@ func (r *ChaosEngineReconciler) newGoRunnerPodForCR(engine *chaosTypes.EngineInf
 }
 
 // engineRunnerPod to Check if the engineRunner pod already exists, else create
-func engineRunnerPod(runnerPod *podEngineRunner) error {
-	if err := runnerPod.r.Client.Get(context.TODO(), types.NamespacedName{Name: runnerPod.engineRunner.Name, Namespace: runnerPod.engineRunner.Namespace}, runnerPod.pod); err != nil && k8serrors.IsNotFound(err) {
+func engineRunnerPod(ctx context.Context, runnerPod *podEngineRunner) error {
+	if err := runnerPod.r.Client.Get(ctx, types.NamespacedName{Name: runnerPod.engineRunner.Name, Namespace: runnerPod.engineRunner.Namespace}, runnerPod.pod); err != nil && k8serrors.IsNotFound(err) {
 		runnerPod.reqLogger.Info("Creating a new engineRunner Pod", "Pod.Namespace", runnerPod.engineRunner.Namespace, "Pod.Name", runnerPod.engineRunner.Name)
-		if err = runnerPod.r.Client.Create(context.TODO(), runnerPod.engineRunner); err != nil {
+		if err = runnerPod.r.Client.Create(ctx, runnerPod.engineRunner); err != nil {
 			if k8serrors.IsAlreadyExists(err) {
 				runnerPod.reqLogger.Info("Skip reconcile: engineRunner Pod already exists", "Pod.Namespace", runnerPod.pod.Namespace, "Pod.Name", runnerPod.pod.Name)
 				return nil
@@ -245,6 +306,9 @@ func engineRunnerPod(runnerPod *podEngineRunner) error {
 
 		// Pod created successfully - don't reconcile
 		runnerPod.reqLogger.Info("engineRunner Pod created successfully")
+		if runnerPod.engine != nil {
+			runnerPod.r.CloudEvents.Publish(cloudevents.NewEngineEvent(cloudevents.TypeRunnerCreated, runnerPod.engine.Instance, runnerPod.engineRunner.Name))
+		}
 		return nil
 	} else if err != nil {
 		return err
@@ -254,9 +318,9 @@ func engineRunnerPod(runnerPod *podEngineRunner) error {
 }
 
 // Fetch the ChaosEngine instance
-func (r *ChaosEngineReconciler) getChaosEngineInstance(engine *chaosTypes.EngineInfo, request reconcile.Request) error {
+func (r *ChaosEngineReconciler) getChaosEngineInstance(ctx context.Context, engine *chaosTypes.EngineInfo, request reconcile.Request) error {
 	instance := &litmuschaosv1alpha1.ChaosEngine{}
-	if err := r.Client.Get(context.TODO(), request.NamespacedName, instance); err != nil {
+	if err := r.Client.Get(ctx, request.NamespacedName, instance); err != nil {
 		// Error reading the object - reconcile the request.
 		return err
 	}
@@ -267,9 +331,9 @@ func (r *ChaosEngineReconciler) getChaosEngineInstance(engine *chaosTypes.Engine
 }
 
 // Check if the engineRunner pod already exists, else create
-func (r *ChaosEngineReconciler) checkEngineRunnerPod(engine *chaosTypes.EngineInfo, reqLogger logr.Logger) error {
+func (r *ChaosEngineReconciler) checkEngineRunnerPod(ctx context.Context, engine *chaosTypes.EngineInfo, reqLogger logr.Logger) error {
 	if len(engine.AppExperiments) == 0 {
-		return errors.New("application experiment list is empty")
+		return errEmptyExperimentList
 	}
 
 	engineRunner, err := r.newGoRunnerPodForCR(engine)
@@ -286,28 +350,26 @@ func (r *ChaosEngineReconciler) checkEngineRunnerPod(engine *chaosTypes.EngineIn
 	runnerPod := &podEngineRunner{
 		pod:             &corev1.Pod{},
 		engineRunner:    engineRunner,
+		engine:          engine,
 		reconcileEngine: engineReconcile,
 	}
 
-	return engineRunnerPod(runnerPod)
+	return engineRunnerPod(ctx, runnerPod)
 }
 
 // setChaosResourceImage take the runner image from engine spec
-// if it is not there then it will take from chaos-operator env
-// at last if it is not able to find image in engine spec and operator env then it will take default images
-func setChaosResourceImage(engine *chaosTypes.EngineInfo) {
-	ChaosRunnerImage := os.Getenv("CHAOS_RUNNER_IMAGE")
-
-	if engine.Instance.Spec.Components.Runner.Image == "" && ChaosRunnerImage == "" {
-		engine.Instance.Spec.Components.Runner.Image = chaosTypes.DefaultChaosRunnerImage
-	} else if engine.Instance.Spec.Components.Runner.Image == "" {
-		engine.Instance.Spec.Components.Runner.Image = ChaosRunnerImage
+// if it is not there then it will take the runner image of the operator config,
+// which defaults to the chaos-operator env and at last to the default image
+func setChaosResourceImage(engine *chaosTypes.EngineInfo, runnerConfig config.RunnerConfig) {
+	if engine.Instance.Spec.Components.Runner.Image == "" {
+		engine.Instance.Spec.Components.Runner.Image = runnerConfig.Image
 	}
 }
 
 // reconcileForDelete reconciles for deletion/force deletion of Chaos Engine
-func (r *ChaosEngineReconciler) reconcileForDelete(engine *chaosTypes.EngineInfo, request reconcile.Request) (reconcile.Result, error) {
-	patch := client.MergeFrom(engine.Instance.DeepCopy())
+func (r *ChaosEngineReconciler) reconcileForDelete(ctx context.Context, engine *chaosTypes.EngineInfo, request reconcile.Request) (_ reconcile.Result, err error) {
+	ctx, span := tracing.StartSpan(ctx, "reconcileForDelete")
+	defer func() { tracing.EndSpan(span, err) }()
 
 	chaosTypes.Log.Info("Checking if there are any chaos resources to be deleted for", "chaosengine", engine.Instance.Name)
 
@@ -316,14 +378,14 @@ func (r *ChaosEngineReconciler) reconcileForDelete(engine *chaosTypes.EngineInfo
 		client.InNamespace(request.NamespacedName.Namespace),
 		client.MatchingLabels{"chaosUID": string(engine.Instance.UID)},
 	}
-	if err := r.Client.List(context.TODO(), chaosPodList, opts...); err != nil {
+	if err := r.Client.List(ctx, chaosPodList, opts...); err != nil {
 		r.Recorder.Eventf(engine.Instance, corev1.EventTypeWarning, "ChaosResourcesOperationFailed", "(chaos stop) Unable to list chaos experiment pods")
 		return reconcile.Result{}, err
 	}
 
 	if len(chaosPodList.Items) != 0 {
 		chaosTypes.Log.Info("Performing a force delete of chaos experiment pods", "chaosengine", engine.Instance.Name)
-		err := r.forceRemoveChaosResources(engine, request)
+		err := r.forceRemoveChaosResources(ctx, engine, request)
 		if err != nil {
 			r.Recorder.Eventf(engine.Instance, corev1.EventTypeWarning, "ChaosResourcesOperationFailed", "(chaos stop) Unable to delete chaos experiment pods")
 			return reconcile.Result{}, err
@@ -331,19 +393,40 @@ func (r *ChaosEngineReconciler) reconcileForDelete(engine *chaosTypes.EngineInfo
 	}
 
 	// update the chaos status in result for abort cases
-	if err := r.updateChaosStatus(engine, request); err != nil {
+	if err := r.updateChaosStatus(ctx, engine, request); err != nil {
 		return reconcile.Result{}, err
 	}
 
+	// Update ChaosEngine ExperimentStatuses, with aborted Status.
+	isAborted := false
+	err = r.patchEngineStatus(ctx, engine, func() {
+		updateExperimentStatusesForStop(engine)
+		isAborted = engine.Instance.Status.EngineStatus != litmuschaosv1alpha1.EngineStatusStopped
+		if isAborted {
+			now := v1.Now()
+			engine.Instance.Status.CompletionTime = &now
+		}
+		engine.Instance.Status.EngineStatus = litmuschaosv1alpha1.EngineStatusStopped
+	})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		r.Recorder.Eventf(engine.Instance, corev1.EventTypeWarning, "ChaosResourcesOperationFailed", "(chaos stop) Unable to update chaosengine")
+		return reconcile.Result{}, fmt.Errorf("unable to patch status of chaosEngine Resource, due to error: %v", err)
+	}
+
+	// the report of the stopped run is owned by the chaosengine, it is not written once the chaosengine is deleted
+	if isAborted && err == nil && engine.Instance.DeletionTimestamp == nil {
+		if err := r.updateRunReport(ctx, engine); err != nil {
+			r.Recorder.Eventf(engine.Instance, corev1.EventTypeWarning, "ChaosResourcesOperationFailed", "(chaos stop) Unable to update the run report")
+			return reconcile.Result{}, err
+		}
+	}
+
+	patch := client.MergeFrom(engine.Instance.DeepCopy())
 	if engine.Instance.ObjectMeta.Finalizers != nil {
 		engine.Instance.ObjectMeta.Finalizers = utils.RemoveString(engine.Instance.ObjectMeta.Finalizers, "chaosengine.litmuschaos.io/finalizer")
 	}
 
-	// Update ChaosEngine ExperimentStatuses, with aborted Status.
-	updateExperimentStatusesForStop(engine)
-	engine.Instance.Status.EngineStatus = litmuschaosv1alpha1.EngineStatusStopped
-
-	if err := r.Client.Patch(context.TODO(), engine.Instance, patch); err != nil && !k8serrors.IsNotFound(err) {
+	if err := r.Client.Patch(ctx, engine.Instance, patch); err != nil && !k8serrors.IsNotFound(err) {
 		r.Recorder.Eventf(engine.Instance, corev1.EventTypeWarning, "ChaosResourcesOperationFailed", "(chaos stop) Unable to update chaosengine")
 		return reconcile.Result{}, fmt.Errorf("unable to remove finalizer from chaosEngine Resource, due to error: %v", err)
 	}
@@ -353,12 +436,19 @@ func (r *ChaosEngineReconciler) reconcileForDelete(engine *chaosTypes.EngineInfo
 	if len(chaosPodList.Items) != 0 {
 		r.Recorder.Eventf(engine.Instance, corev1.EventTypeNormal, "ChaosEngineStopped", "Chaos resources deleted successfully")
 	}
+	if isAborted {
+		r.CloudEvents.Publish(cloudevents.NewEngineEvent(cloudevents.TypeEngineAborted, engine.Instance, ""))
+		r.audit(engine, audit.ActionStop, audit.OutcomeStopped, "")
+	}
 
 	return reconcile.Result{}, nil
 }
 
 // forceRemoveChaosResources force removes all chaos-related pods
-func (r *ChaosEngineReconciler) forceRemoveChaosResources(engine *chaosTypes.EngineInfo, request reconcile.Request) error {
+func (r *ChaosEngineReconciler) forceRemoveChaosResources(ctx context.Context, engine *chaosTypes.EngineInfo, request reconcile.Request) (err error) {
+	ctx, span := tracing.StartSpan(ctx, "forceRemoveChaosResources")
+	defer func() { tracing.EndSpan(span, err) }()
+
 	optsDelete := []client.DeleteAllOfOption{client.InNamespace(request.NamespacedName.Namespace), client.MatchingLabels{"chaosUID": string(engine.Instance.UID)}, client.PropagationPolicy(v1.DeletePropagationBackground)}
 	if engine.Instance.Spec.TerminationGracePeriodSeconds != 0 {
 		optsDelete = append(optsDelete, client.GracePeriodSeconds(engine.Instance.Spec.TerminationGracePeriodSeconds))
@@ -366,44 +456,78 @@ func (r *ChaosEngineReconciler) forceRemoveChaosResources(engine *chaosTypes.Eng
 
 	var (
 		deleteEvent []string
-		err         []error
+		errs        []error
 	)
 
-	if errJob := r.Client.DeleteAllOf(context.TODO(), &batchv1.Job{}, optsDelete...); errJob != nil {
-		err = append(err, errJob)
+	jobCtx, jobSpan := tracing.StartSpan(ctx, "DeleteAllOf Jobs")
+	errJob := r.Client.DeleteAllOf(jobCtx, &batchv1.Job{}, optsDelete...)
+	tracing.EndSpan(jobSpan, errJob)
+	if errJob != nil {
+		errs = append(errs, errJob)
 		deleteEvent = append(deleteEvent, "Jobs, ")
 	}
 
-	if errPod := r.Client.DeleteAllOf(context.TODO(), &corev1.Pod{}, optsDelete...); errPod != nil {
-		err = append(err, errPod)
+	podCtx, podSpan := tracing.StartSpan(ctx, "DeleteAllOf Pods")
+	errPod := r.Client.DeleteAllOf(podCtx, &corev1.Pod{}, optsDelete...)
+	tracing.EndSpan(podSpan, errPod)
+	if errPod != nil {
+		errs = append(errs, errPod)
 		deleteEvent = append(deleteEvent, "Pods, ")
 	}
-	if err != nil {
+	if errs != nil {
 		r.Recorder.Eventf(engine.Instance, corev1.EventTypeWarning, "ChaosResourcesOperationFailed", "(chaos stop) Unable to delete chaos resources: %v allocated to chaosengine", strings.Join(deleteEvent, ""))
-		return fmt.Errorf("unable to delete ChaosResources due to %v", err)
+		return fmt.Errorf("unable to delete ChaosResources due to %v", errs)
 	}
 
 	return nil
 }
 
 // updateEngineState updates Chaos Engine Status with given State
-func (r *ChaosEngineReconciler) updateEngineState(engine *chaosTypes.EngineInfo, state litmuschaosv1alpha1.EngineState) error {
+func (r *ChaosEngineReconciler) updateEngineState(ctx context.Context, engine *chaosTypes.EngineInfo, state litmuschaosv1alpha1.EngineState) error {
 	patch := client.MergeFrom(engine.Instance.DeepCopy())
 	engine.Instance.Spec.EngineState = state
 
-	if err := r.Client.Patch(context.TODO(), engine.Instance, patch); err != nil {
+	if err := r.Client.Patch(ctx, engine.Instance, patch); err != nil {
 		return fmt.Errorf("unable to patch state of chaosEngine Resource, due to error: %v", err)
 	}
 
 	return nil
 }
 
+// patchEngineStatus patches the status subresource of the chaosengine with the changes made by mutate.
+// The patch is rejected if the chaosengine has been modified in the meantime, e.g. by the chaos-runner
+// updating the experiment statuses, in which case mutate is applied again over the latest chaosengine
+func (r *ChaosEngineReconciler) patchEngineStatus(ctx context.Context, engine *chaosTypes.EngineInfo, mutate func()) error {
+	return k8sretry.RetryOnConflict(k8sretry.DefaultRetry, func() error {
+		patch := client.MergeFromWithOptions(engine.Instance.DeepCopy(), client.MergeFromWithOptimisticLock{})
+		mutate()
+		err := r.Client.Status().Patch(ctx, engine.Instance, patch)
+		if k8serrors.IsConflict(err) {
+			// the cache may still hold the conflicting chaosengine, the retry would then conflict again
+			latest := &litmuschaosv1alpha1.ChaosEngine{}
+			if err := r.apiReader().Get(ctx, types.NamespacedName{Name: engine.Instance.Name, Namespace: engine.Instance.Namespace}, latest); err != nil {
+				return err
+			}
+			engine.Instance = latest
+		}
+		return err
+	})
+}
+
+// apiReader returns the reader of the latest chaosengines
+func (r *ChaosEngineReconciler) apiReader() client.Reader {
+	if r.APIReader == nil {
+		return r.Client
+	}
+	return r.APIReader
+}
+
 // checkRunnerContainerCompletedStatus check for the runner pod's container status for Completed
-func (r *ChaosEngineReconciler) checkRunnerContainerCompletedStatus(engine *chaosTypes.EngineInfo) (bool, error) {
+func (r *ChaosEngineReconciler) checkRunnerContainerCompletedStatus(ctx context.Context, engine *chaosTypes.EngineInfo) (bool, error) {
 	runnerPod := corev1.Pod{}
 	isCompleted := false
 
-	err := r.Client.Get(context.TODO(), types.NamespacedName{Name: engine.Instance.Name + "-runner", Namespace: engine.Instance.Namespace}, &runnerPod)
+	err := r.Client.Get(ctx, types.NamespacedName{Name: engine.Instance.Name + "-runner", Namespace: engine.Instance.Namespace}, &runnerPod)
 	if err != nil {
 		return isCompleted, err
 	}
@@ -422,9 +546,9 @@ func (r *ChaosEngineReconciler) checkRunnerContainerCompletedStatus(engine *chao
 }
 
 // gracefullyRemoveDefaultChaosResources removes all chaos-resources gracefully
-func (r *ChaosEngineReconciler) gracefullyRemoveDefaultChaosResources(engine *chaosTypes.EngineInfo, request reconcile.Request) (reconcile.Result, error) {
+func (r *ChaosEngineReconciler) gracefullyRemoveDefaultChaosResources(ctx context.Context, engine *chaosTypes.EngineInfo, request reconcile.Request) (reconcile.Result, error) {
 	if engine.Instance.Spec.JobCleanUpPolicy == litmuschaosv1alpha1.CleanUpPolicyDelete {
-		if err := r.gracefullyRemoveChaosPods(engine, request); err != nil {
+		if err := r.gracefullyRemoveChaosPods(ctx, engine, request); err != nil {
 			return reconcile.Result{}, err
 		}
 	}
@@ -433,18 +557,18 @@ func (r *ChaosEngineReconciler) gracefullyRemoveDefaultChaosResources(engine *ch
 }
 
 // gracefullyRemoveChaosPods removes chaos default resources gracefully
-func (r *ChaosEngineReconciler) gracefullyRemoveChaosPods(engine *chaosTypes.EngineInfo, request reconcile.Request) error {
+func (r *ChaosEngineReconciler) gracefullyRemoveChaosPods(ctx context.Context, engine *chaosTypes.EngineInfo, request reconcile.Request) error {
 	optsList := []client.ListOption{
 		client.InNamespace(request.NamespacedName.Namespace), client.MatchingLabels{"app": engine.Instance.Name, "chaosUID": string(engine.Instance.UID)},
 	}
 
 	var podList corev1.PodList
-	if errList := r.Client.List(context.TODO(), &podList, optsList...); errList != nil {
+	if errList := r.Client.List(ctx, &podList, optsList...); errList != nil {
 		return errList
 	}
 
 	for _, v := range podList.Items {
-		if errDel := r.Client.Delete(context.TODO(), &v, []client.DeleteOption{}...); errDel != nil {
+		if errDel := r.Client.Delete(ctx, &v, []client.DeleteOption{}...); errDel != nil {
 			return errDel
 		}
 	}
@@ -453,27 +577,80 @@ func (r *ChaosEngineReconciler) gracefullyRemoveChaosPods(engine *chaosTypes.Eng
 }
 
 // reconcileForComplete reconciles for graceful completion of Chaos Engine
-func (r *ChaosEngineReconciler) reconcileForComplete(engine *chaosTypes.EngineInfo, request reconcile.Request) (reconcile.Result, error) {
-	if _, err := r.gracefullyRemoveDefaultChaosResources(engine, request); err != nil {
+func (r *ChaosEngineReconciler) reconcileForComplete(ctx context.Context, engine *chaosTypes.EngineInfo, request reconcile.Request) (_ reconcile.Result, err error) {
+	ctx, span := tracing.StartSpan(ctx, "reconcileForComplete")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	if _, err := r.gracefullyRemoveDefaultChaosResources(ctx, engine, request); err != nil {
 		r.Recorder.Eventf(engine.Instance, corev1.EventTypeWarning, "ChaosResourcesOperationFailed", "(chaos completion) Unable to delete chaos pods upon chaos completion")
 		return reconcile.Result{}, err
 	}
 
-	if err := r.updateEngineState(engine, litmuschaosv1alpha1.EngineStateStop); err != nil {
+	if err := r.updateEngineState(ctx, engine, litmuschaosv1alpha1.EngineStateStop); err != nil {
 		r.Recorder.Eventf(engine.Instance, corev1.EventTypeWarning, "ChaosResourcesOperationFailed", "(chaos completion) Unable to update chaosengine")
 		return reconcile.Result{}, fmt.Errorf("unable to Update Engine State: %v", err)
 	}
 
+	if err := r.updateRunReport(ctx, engine); err != nil {
+		r.Recorder.Eventf(engine.Instance, corev1.EventTypeWarning, "ChaosResourcesOperationFailed", "(chaos completion) Unable to update the run report")
+		return reconcile.Result{}, err
+	}
+
 	return reconcile.Result{}, nil
 }
 
+// updateRunReport creates or updates the report configmap of the completed ChaosEngine run,
+// containing the report in json and junit xml formats
+func (r *ChaosEngineReconciler) updateRunReport(ctx context.Context, engine *chaosTypes.EngineInfo) error {
+	chaosResults := &litmuschaosv1alpha1.ChaosResultList{}
+	if err := r.Client.List(ctx, chaosResults, client.InNamespace(engine.Instance.Namespace),
+		client.MatchingLabels{"chaosUID": string(engine.Instance.UID)}); err != nil {
+		return fmt.Errorf("unable to list chaosresults, due to error: %v", err)
+	}
+
+	runReport := report.Build(engine.Instance, chaosResults.Items)
+	jsonReport, err := runReport.JSON()
+	if err != nil {
+		return fmt.Errorf("unable to generate json report, due to error: %v", err)
+	}
+	junitReport, err := runReport.JUnit()
+	if err != nil {
+		return fmt.Errorf("unable to generate junit report, due to error: %v", err)
+	}
+
+	reportConfigMap := &corev1.ConfigMap{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      report.ConfigMapName(engine.Instance.Name),
+			Namespace: engine.Instance.Namespace,
+		},
+	}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, reportConfigMap, func() error {
+		reportConfigMap.Labels = map[string]string{
+			"chaosUID":                    string(engine.Instance.UID),
+			"app.kubernetes.io/component": "chaos-report",
+			"app.kubernetes.io/part-of":   "litmus",
+		}
+		reportConfigMap.Data = map[string]string{
+			report.JSONKey:  string(jsonReport),
+			report.JUnitKey: string(junitReport),
+		}
+		return controllerutil.SetControllerReference(engine.Instance, reportConfigMap, r.Scheme)
+	}); err != nil {
+		return fmt.Errorf("unable to update report configmap, due to error: %v", err)
+	}
+	return nil
+}
+
 // reconcileForRestartAfterAbort reconciles for restart of ChaosEngine after it was aborted previously
-func (r *ChaosEngineReconciler) reconcileForRestartAfterAbort(engine *chaosTypes.EngineInfo, request reconcile.Request) (reconcile.Result, error) {
-	if err := r.forceRemoveChaosResources(engine, request); err != nil {
+func (r *ChaosEngineReconciler) reconcileForRestartAfterAbort(ctx context.Context, engine *chaosTypes.EngineInfo, request reconcile.Request) (_ reconcile.Result, err error) {
+	ctx, span := tracing.StartSpan(ctx, "reconcileForRestartAfterAbort")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	if err := r.forceRemoveChaosResources(ctx, engine, request); err != nil {
 		return reconcile.Result{}, err
 	}
 
-	if requeue, err := r.updateEngineForRestart(engine); err != nil {
+	if requeue, err := r.updateEngineForRestart(ctx, engine); err != nil {
 		if requeue {
 			return reconcile.Result{Requeue: true}, nil
 		}
@@ -485,70 +662,108 @@ func (r *ChaosEngineReconciler) reconcileForRestartAfterAbort(engine *chaosTypes
 }
 
 // reconcileForRestartAfterComplete reconciles for restart of ChaosEngine after it has completed successfully
-func (r *ChaosEngineReconciler) reconcileForRestartAfterComplete(engine *chaosTypes.EngineInfo, request reconcile.Request) (reconcile.Result, error) {
-	patch := client.MergeFrom(engine.Instance.DeepCopy())
+func (r *ChaosEngineReconciler) reconcileForRestartAfterComplete(ctx context.Context, engine *chaosTypes.EngineInfo, request reconcile.Request) (_ reconcile.Result, err error) {
+	ctx, span := tracing.StartSpan(ctx, "reconcileForRestartAfterComplete")
+	defer func() { tracing.EndSpan(span, err) }()
 
-	if err := r.forceRemoveChaosResources(engine, request); err != nil {
+	if err := r.forceRemoveChaosResources(ctx, engine, request); err != nil {
 		return reconcile.Result{}, err
 	}
-
-	engine.Instance.Status.EngineStatus = litmuschaosv1alpha1.EngineStatusInitialized
-	engine.Instance.Status.Experiments = nil
+	metrics.DeleteResilienceScore(engine.Instance.Namespace, engine.Instance.Name)
 
 	// finalizers have been retained in a completed chaosengine till this point (as chaos pods may be "retained")
 	// as per the jobCleanUpPolicy. Stale finalizer is removed so that initEngine() generates the
 	// ChaosEngineInitialized event and re-adds the finalizer before starting chaos.
+	// It is removed before resetting the status, so that a failed status patch is retried by the next reconcile
 
 	if engine.Instance.ObjectMeta.Finalizers != nil {
+		patch := client.MergeFrom(engine.Instance.DeepCopy())
 		engine.Instance.ObjectMeta.Finalizers = utils.RemoveString(engine.Instance.ObjectMeta.Finalizers, "chaosengine.litmuschaos.io/finalizer")
+		if err := r.Client.Patch(ctx, engine.Instance, patch); err != nil {
+			r.Recorder.Eventf(engine.Instance, corev1.EventTypeWarning, "ChaosResourcesOperationFailed", "(chaos restart) Unable to update chaosengine")
+			return reconcile.Result{}, fmt.Errorf("unable to remove stale finalizer in chaosEngine Resource, due to error: %v", err)
+		}
 	}
 
-	if err := r.Client.Patch(context.TODO(), engine.Instance, patch); err != nil {
+	if err := r.patchEngineStatus(ctx, engine, func() {
+		engine.Instance.Status.EngineStatus = litmuschaosv1alpha1.EngineStatusInitialized
+		engine.Instance.Status.Experiments = nil
+		now := v1.Now()
+		engine.Instance.Status.StartTime = &now
+		engine.Instance.Status.CompletionTime = nil
+		engine.Instance.Status.ResilienceScore = ""
+	}); err != nil {
 		r.Recorder.Eventf(engine.Instance, corev1.EventTypeWarning, "ChaosResourcesOperationFailed", "(chaos restart) Unable to update chaosengine")
-		return reconcile.Result{}, fmt.Errorf("unable to patch state & remove stale finalizer in chaosEngine Resource, due to error: %v", err)
+		return reconcile.Result{}, fmt.Errorf("unable to patch status of chaosEngine Resource, due to error: %v", err)
 	}
+	r.audit(engine, audit.ActionRestart, audit.OutcomeRestarted, "")
 
 	return reconcile.Result{}, nil
 }
 
 // initEngine initialize Chaos Engine, and add a finalizer to it.
-func (r *ChaosEngineReconciler) initEngine(engine *chaosTypes.EngineInfo) (bool, error) {
-	if engine.Instance.Spec.EngineState == "" {
+func (r *ChaosEngineReconciler) initEngine(ctx context.Context, engine *chaosTypes.EngineInfo) (bool, error) {
+	patch := client.MergeFrom(engine.Instance.DeepCopy())
+	isDefaulted := engine.Instance.Spec.EngineState == ""
+	if isDefaulted {
 		engine.Instance.Spec.EngineState = litmuschaosv1alpha1.EngineStateActive
 	}
 
-	if engine.Instance.Spec.EngineState == litmuschaosv1alpha1.EngineStateActive && engine.Instance.Status.EngineStatus == "" {
-		engine.Instance.Status.EngineStatus = litmuschaosv1alpha1.EngineStatusInitialized
+	isInitialized := engine.Instance.Spec.EngineState == litmuschaosv1alpha1.EngineStateActive && engine.Instance.Status.EngineStatus == ""
+	if !isInitialized && engine.Instance.Status.EngineStatus != litmuschaosv1alpha1.EngineStatusInitialized {
+		return false, nil
 	}
 
-	if engine.Instance.Status.EngineStatus == litmuschaosv1alpha1.EngineStatusInitialized {
-		if engine.Instance.ObjectMeta.Finalizers == nil {
-			engine.Instance.ObjectMeta.Finalizers = append(engine.Instance.ObjectMeta.Finalizers, finalizer)
-			if err := r.Client.Update(context.TODO(), engine.Instance, &client.UpdateOptions{}); err != nil {
-				if k8serrors.IsConflict(err) {
-					return true, err
-				}
-				return false, fmt.Errorf("unable to initialize ChaosEngine, because of Update Error: %v", err)
+	// the engineState and the finalizer are patched separately from the status subresource
+	isFinalizerAdded := engine.Instance.ObjectMeta.Finalizers == nil
+	if isFinalizerAdded {
+		engine.Instance.ObjectMeta.Finalizers = append(engine.Instance.ObjectMeta.Finalizers, finalizer)
+	}
+	if isDefaulted || isFinalizerAdded {
+		if err := r.Client.Patch(ctx, engine.Instance, patch); err != nil {
+			return false, fmt.Errorf("unable to initialize ChaosEngine, because of Patch Error: %v", err)
+		}
+	}
+
+	if isInitialized {
+		if err := r.patchEngineStatus(ctx, engine, func() {
+			if engine.Instance.Status.EngineStatus == "" {
+				engine.Instance.Status.EngineStatus = litmuschaosv1alpha1.EngineStatusInitialized
+				now := v1.Now()
+				engine.Instance.Status.StartTime = &now
 			}
-			// generate the ChaosEngineInitialized event once finalizer has been added
-			r.Recorder.Eventf(engine.Instance, corev1.EventTypeNormal, "ChaosEngineInitialized", "Identifying app under test & launching %s", engine.Instance.Name+"-runner")
+		}); err != nil {
+			if k8serrors.IsConflict(err) {
+				return true, err
+			}
+			return false, fmt.Errorf("unable to initialize ChaosEngine, because of Status Patch Error: %v", err)
 		}
 	}
 
+	if isFinalizerAdded {
+		// generate the ChaosEngineInitialized event once finalizer has been added
+		r.Recorder.Eventf(engine.Instance, corev1.EventTypeNormal, "ChaosEngineInitialized", "Identifying app under test & launching %s", engine.Instance.Name+"-runner")
+		r.CloudEvents.Publish(cloudevents.NewEngineEvent(cloudevents.TypeEngineInitialized, engine.Instance, ""))
+		r.audit(engine, audit.ActionStart, audit.OutcomeInitialized, "")
+	}
+
 	return false, nil
 }
 
 // reconcileForCreationAndRunning reconciles for Chaos execution of Chaos Engine
-func (r *ChaosEngineReconciler) reconcileForCreationAndRunning(engine *chaosTypes.EngineInfo, reqLogger logr.Logger) (reconcile.Result, error) {
+func (r *ChaosEngineReconciler) reconcileForCreationAndRunning(ctx context.Context, engine *chaosTypes.EngineInfo, reqLogger logr.Logger) (_ reconcile.Result, err error) {
+	ctx, span := tracing.StartSpan(ctx, "reconcileForCreationAndRunning")
+	defer func() { tracing.EndSpan(span, err) }()
+
 	var runner corev1.Pod
-	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: engine.Instance.Name + "-runner", Namespace: engine.Instance.Namespace}, &runner); err != nil {
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: engine.Instance.Name + "-runner", Namespace: engine.Instance.Namespace}, &runner); err != nil {
 		if k8serrors.IsNotFound(err) {
-			return r.createRunnerPod(engine, reqLogger)
+			return r.createRunnerPod(ctx, engine, reqLogger)
 		}
 		return reconcile.Result{}, err
 	}
 
-	isCompleted, err := r.checkRunnerContainerCompletedStatus(engine)
+	isCompleted, err := r.checkRunnerContainerCompletedStatus(ctx, engine)
 	if err != nil {
 		if k8serrors.IsNotFound(err) {
 			return reconcile.Result{Requeue: true}, nil
@@ -558,7 +773,7 @@ func (r *ChaosEngineReconciler) reconcileForCreationAndRunning(engine *chaosType
 	}
 
 	if isCompleted {
-		if requeue, err := r.updateEngineForComplete(engine, isCompleted); err != nil {
+		if requeue, err := r.updateEngineForComplete(ctx, engine, isCompleted); err != nil {
 			if requeue {
 				return reconcile.Result{Requeue: true}, nil
 			}
@@ -572,17 +787,30 @@ func (r *ChaosEngineReconciler) reconcileForCreationAndRunning(engine *chaosType
 	return reconcile.Result{}, nil
 }
 
-func (r *ChaosEngineReconciler) createRunnerPod(engine *chaosTypes.EngineInfo, reqLogger logr.Logger) (reconcile.Result, error) {
-	if err := r.setExperimentDetails(engine); err != nil {
-		if updateEngineErr := r.updateEngineState(engine, litmuschaosv1alpha1.EngineStateStop); updateEngineErr != nil {
+func (r *ChaosEngineReconciler) createRunnerPod(ctx context.Context, engine *chaosTypes.EngineInfo, reqLogger logr.Logger) (_ reconcile.Result, err error) {
+	ctx, span := tracing.StartSpan(ctx, "createRunnerPod")
+	defer func() { tracing.EndSpan(span, err) }()
+	// the runner and the experiments join the trace of the reconcile which launched them
+	engine.TraceParent = tracing.TraceParent(ctx)
+
+	_, detailsSpan := tracing.StartSpan(ctx, "setExperimentDetails")
+	err = r.setExperimentDetails(engine)
+	tracing.EndSpan(detailsSpan, err)
+	if err != nil {
+		if updateEngineErr := r.updateEngineState(ctx, engine, litmuschaosv1alpha1.EngineStateStop); updateEngineErr != nil {
 			r.Recorder.Eventf(engine.Instance, corev1.EventTypeWarning, "ChaosResourcesOperationFailed", "(chaos stop) Unable to update chaosengine")
 			return reconcile.Result{}, fmt.Errorf("unable to Update Engine State: %v", err)
 		}
+		r.CloudEvents.Publish(cloudevents.NewEngineEvent(cloudevents.TypePolicyViolated, engine.Instance, err.Error()))
+		r.audit(engine, audit.ActionStart, audit.OutcomeRejected, err.Error())
 		return reconcile.Result{}, err
 	}
 
 	// Check if the engineRunner pod already exists, else create
-	if err := r.checkEngineRunnerPod(engine, reqLogger); err != nil {
+	runnerCtx, runnerSpan := tracing.StartSpan(ctx, "checkEngineRunnerPod")
+	err = r.checkEngineRunnerPod(runnerCtx, engine, reqLogger)
+	tracing.EndSpan(runnerSpan, err)
+	if err != nil {
 		r.Recorder.Eventf(engine.Instance, corev1.EventTypeWarning, "ChaosResourcesOperationFailed", "(chaos start) Unable to get chaos resources")
 		return reconcile.Result{}, err
 	}
@@ -591,7 +819,7 @@ func (r *ChaosEngineReconciler) createRunnerPod(engine *chaosTypes.EngineInfo, r
 
 func (r *ChaosEngineReconciler) setExperimentDetails(engine *chaosTypes.EngineInfo) error {
 	// Get the image for runner pod from chaosengine spec,operator env or default values.
-	setChaosResourceImage(engine)
+	setChaosResourceImage(engine, r.Config.Get().Runner)
 
 	if engine.Selectors != nil && engine.Selectors.Workloads == nil && engine.Selectors.Pods == nil {
 		return fmt.Errorf("specify one out of workloads or pods")
@@ -655,6 +883,29 @@ func getTargets(engine *chaosTypes.EngineInfo) string {
 	return strings.Join([]string{engine.AppInfo.AppKind, engine.AppInfo.Appns, fmt.Sprintf("[%v]", engine.AppInfo.Applabel)}, ":")
 }
 
+// reconcileForTTL deletes the finished ChaosEngine once its ttlSecondsAfterFinished expires
+// and requeues it for the time left otherwise
+func (r *ChaosEngineReconciler) reconcileForTTL(ctx context.Context, engine *chaosTypes.EngineInfo) (_ reconcile.Result, err error) {
+	ctx, span := tracing.StartSpan(ctx, "reconcileForTTL")
+	defer func() { tracing.EndSpan(span, err) }()
+
+	if engine.Instance.Spec.TTLSecondsAfterFinished == nil || engine.Instance.Status.CompletionTime == nil {
+		return reconcile.Result{}, nil
+	}
+
+	expiry := engine.Instance.Status.CompletionTime.Add(time.Duration(*engine.Instance.Spec.TTLSecondsAfterFinished) * time.Second)
+	if remaining := time.Until(expiry); remaining > 0 {
+		return reconcile.Result{RequeueAfter: remaining}, nil
+	}
+
+	chaosTypes.Log.Info("Deleting chaosengine as its ttlSecondsAfterFinished has expired", "chaosengine", engine.Instance.Name)
+	if err := r.Client.Delete(ctx, engine.Instance, client.PropagationPolicy(v1.DeletePropagationBackground)); err != nil && !k8serrors.IsNotFound(err) {
+		r.Recorder.Eventf(engine.Instance, corev1.EventTypeWarning, "ChaosResourcesOperationFailed", "(chaos expiry) Unable to delete chaosengine")
+		return reconcile.Result{}, fmt.Errorf("unable to delete expired chaosEngine Resource, due to error: %v", err)
+	}
+	return reconcile.Result{}, nil
+}
+
 // updateExperimentStatusesForStop updates ChaosEngine.Status.Experiment with Abort Status.
 func updateExperimentStatusesForStop(engine *chaosTypes.EngineInfo) {
 	for i := range engine.Instance.Status.Experiments {
@@ -673,65 +924,88 @@ func startReqLogger(request reconcile.Request) logr.Logger {
 	return reqLogger
 }
 
-func (r *ChaosEngineReconciler) updateEngineForComplete(engine *chaosTypes.EngineInfo, isCompleted bool) (bool, error) {
+func (r *ChaosEngineReconciler) updateEngineForComplete(ctx context.Context, engine *chaosTypes.EngineInfo, isCompleted bool) (bool, error) {
 	if engine.Instance.Status.EngineStatus != litmuschaosv1alpha1.EngineStatusCompleted {
-		engine.Instance.Status.EngineStatus = litmuschaosv1alpha1.EngineStatusCompleted
-		engine.Instance.Spec.EngineState = litmuschaosv1alpha1.EngineStateStop
-		if err := r.Client.Update(context.TODO(), engine.Instance, &client.UpdateOptions{}); err != nil {
+		// the engineState is patched before the status, so that a failure in between
+		// is reconciled as an abort of the completed run, rather than as its restart
+		if err := r.updateEngineState(ctx, engine, litmuschaosv1alpha1.EngineStateStop); err != nil {
+			return false, err
+		}
+		if err := r.patchEngineStatus(ctx, engine, func() {
+			now := v1.Now()
+			engine.Instance.Status.EngineStatus = litmuschaosv1alpha1.EngineStatusCompleted
+			engine.Instance.Status.CompletionTime = &now
+		}); err != nil {
 			if k8serrors.IsConflict(err) {
 				return true, err
 			}
-			return false, fmt.Errorf("unable to update ChaosEngine Status, due to update error: %v", err)
+			return false, fmt.Errorf("unable to update ChaosEngine Status, due to patch error: %v", err)
 		}
 		r.Recorder.Eventf(engine.Instance, corev1.EventTypeNormal, "ChaosEngineCompleted", "ChaosEngine completed, will delete or retain the resources according to jobCleanUpPolicy")
+		r.CloudEvents.Publish(cloudevents.NewEngineEvent(cloudevents.TypeEngineCompleted, engine.Instance, ""))
+		r.audit(engine, audit.ActionComplete, audit.OutcomeCompleted, "")
 	}
 
 	return false, nil
 }
 
-func (r *ChaosEngineReconciler) updateEngineForRestart(engine *chaosTypes.EngineInfo) (bool, error) {
+func (r *ChaosEngineReconciler) updateEngineForRestart(ctx context.Context, engine *chaosTypes.EngineInfo) (bool, error) {
 	r.Recorder.Eventf(engine.Instance, corev1.EventTypeNormal, "RestartInProgress", "ChaosEngine is restarted")
-	engine.Instance.Status.EngineStatus = litmuschaosv1alpha1.EngineStatusInitialized
-	engine.Instance.Status.Experiments = nil
-	if err := r.Client.Update(context.TODO(), engine.Instance, &client.UpdateOptions{}); err != nil {
+	metrics.DeleteResilienceScore(engine.Instance.Namespace, engine.Instance.Name)
+	if err := r.patchEngineStatus(ctx, engine, func() {
+		engine.Instance.Status.EngineStatus = litmuschaosv1alpha1.EngineStatusInitialized
+		engine.Instance.Status.Experiments = nil
+		now := v1.Now()
+		engine.Instance.Status.StartTime = &now
+		engine.Instance.Status.CompletionTime = nil
+		engine.Instance.Status.ResilienceScore = ""
+	}); err != nil {
 		if k8serrors.IsConflict(err) {
 			return true, err
 		}
-		return false, fmt.Errorf("unable to restart ChaosEngine, due to update error: %v", err)
+		return false, fmt.Errorf("unable to restart ChaosEngine, due to patch error: %v", err)
 	}
+	r.audit(engine, audit.ActionRestart, audit.OutcomeRestarted, "")
 
 	return false, nil
 }
 
+// audit appends the audit record of the action on the chaosengine to the audit sink, if configured
+func (r *ChaosEngineReconciler) audit(engine *chaosTypes.EngineInfo, action, outcome, message string) {
+	if r.Audit == nil {
+		return
+	}
+	if err := r.Audit.Write(audit.NewRecord(engine.Instance, action, getTargets(engine), outcome, message)); err != nil {
+		chaosTypes.Log.Error(err, "unable to write the audit record", "chaosengine", engine.Instance.Name, "action", action)
+	}
+}
+
 // updateChaosStatus update the chaos status inside the chaosresult
-func (r *ChaosEngineReconciler) updateChaosStatus(engine *chaosTypes.EngineInfo, request reconcile.Request) error {
-	if err := r.waitForChaosPodTermination(engine, request); err != nil {
+func (r *ChaosEngineReconciler) updateChaosStatus(ctx context.Context, engine *chaosTypes.EngineInfo, request reconcile.Request) error {
+	if err := r.waitForChaosPodTermination(ctx, engine, request); err != nil {
 		return err
 	}
 
-	// skipping CRD validation for the namespace scoped operator
-	if os.Getenv("WATCH_NAMESPACE") == "" {
-		found, err := isResultCRDAvailable()
-		if err != nil {
-			return err
-		}
-		if !found {
-			return nil
-		}
+	found, err := r.isResultCRDAvailable()
+	if err != nil {
+		return err
+	}
+	if !found {
+		return nil
 	}
 
-	return r.updateChaosResult(engine, request)
+	return r.updateChaosResult(ctx, engine, request)
 }
 
 // updateChaosResult update the chaosstatus and annotation inside the chaosresult
-func (r *ChaosEngineReconciler) updateChaosResult(engine *chaosTypes.EngineInfo, request reconcile.Request) error {
+func (r *ChaosEngineReconciler) updateChaosResult(ctx context.Context, engine *chaosTypes.EngineInfo, request reconcile.Request) error {
 	chaosresultList := &litmuschaosv1alpha1.ChaosResultList{}
 	opts := []client.ListOption{
 		client.InNamespace(request.NamespacedName.Namespace),
 		client.MatchingLabels{},
 	}
 
-	if err := r.Client.List(context.TODO(), chaosresultList, opts...); err != nil {
+	if err := r.Client.List(ctx, chaosresultList, opts...); err != nil {
 		return err
 	}
 
@@ -741,11 +1015,14 @@ func (r *ChaosEngineReconciler) updateChaosResult(engine *chaosTypes.EngineInfo,
 				return nil
 			}
 			targetsList, annotations := getChaosStatus(result)
+			if result.Status.History == nil {
+				result.Status.History = &litmuschaosv1alpha1.HistoryDetails{}
+			}
 			result.Status.History.Targets = targetsList
 			result.ObjectMeta.Annotations = annotations
 
 			chaosTypes.Log.Info("updating chaos status inside chaosresult", "chaosresult", result.Name)
-			return r.Client.Update(context.TODO(), &result, &client.UpdateOptions{})
+			return r.Client.Update(ctx, &result, &client.UpdateOptions{})
 		}
 	}
 
@@ -753,37 +1030,47 @@ func (r *ChaosEngineReconciler) updateChaosResult(engine *chaosTypes.EngineInfo,
 }
 
 // waitForChaosPodTermination wait until the termination of chaos pod after abort
-func (r *ChaosEngineReconciler) waitForChaosPodTermination(engine *chaosTypes.EngineInfo, request reconcile.Request) error {
+func (r *ChaosEngineReconciler) waitForChaosPodTermination(ctx context.Context, engine *chaosTypes.EngineInfo, request reconcile.Request) error {
 	opts := []client.ListOption{
 		client.InNamespace(request.NamespacedName.Namespace),
 		client.MatchingLabels{"chaosUID": string(engine.Instance.UID)},
 	}
 
-	return retry.
-		Times(uint(180)).
-		Wait(1 * time.Second).
-		Try(func(attempt uint) error {
-			chaosPodList := &corev1.PodList{}
-			if err := r.Client.List(context.TODO(), chaosPodList, opts...); err != nil {
-				return err
-			}
-			if len(chaosPodList.Items) != 0 {
-				return errors.Errorf("chaos pods are not deleted yet")
-			}
-			return nil
-		})
+	ctx, cancel := context.WithTimeout(ctx, r.Config.Get().Controller.ChaosPodTerminationTimeout.Duration)
+	defer cancel()
+
+	return retry.Retrier{
+		Backoff: retry.Constant(1 * time.Second),
+		OnRetry: retry.LogRetries(chaosTypes.Log, "waitForChaosPodTermination"),
+	}.Do(ctx, func(ctx context.Context, attempt uint) error {
+		chaosPodList := &corev1.PodList{}
+		if err := r.Client.List(ctx, chaosPodList, opts...); err != nil {
+			return err
+		}
+		if len(chaosPodList.Items) != 0 {
+			return errors.Errorf("chaos pods are not deleted yet")
+		}
+		return nil
+	})
 }
 
 // getChaosStatus return the target application details along with their chaos status
 func getChaosStatus(result litmuschaosv1alpha1.ChaosResult) ([]litmuschaosv1alpha1.TargetDetails, map[string]string) {
 	annotations := result.ObjectMeta.Annotations
 
-	targetsList := result.Status.History.Targets
+	var targetsList []litmuschaosv1alpha1.TargetDetails
+	if result.Status.History != nil {
+		targetsList = result.Status.History.Targets
+	}
 	for k, v := range annotations {
 		switch strings.ToLower(v) {
 		case "injected", "reverted", "targeted":
-			kind := strings.TrimSpace(strings.Split(k, "/")[0])
-			name := strings.TrimSpace(strings.Split(k, "/")[1])
+			// the chaos status annotations are keyed as <kind>/<name>, the other annotations are kept as is
+			kind, name, found := strings.Cut(k, "/")
+			kind, name = strings.TrimSpace(kind), strings.TrimSpace(name)
+			if !found || kind == "" || name == "" {
+				continue
+			}
 			if !updateTargets(name, v, &targetsList) {
 				targetsList = append(targetsList, litmuschaosv1alpha1.TargetDetails{
 					Name:        name,
@@ -798,34 +1085,18 @@ func getChaosStatus(result litmuschaosv1alpha1.ChaosResult) ([]litmuschaosv1alph
 	return targetsList, annotations
 }
 
-// isResultCRDAvailable check the existence of chaosresult CRD inside cluster
-func isResultCRDAvailable() (bool, error) {
-
-	dynamicClient, err := dynamicclientset.CreateClientSet()
-	if err != nil {
-		return false, err
-	}
-
-	// defining the gvr for the requested resource
-	gvr := schema.GroupVersionResource{
-		Group:    "apiextensions.k8s.io",
-		Version:  "v1",
-		Resource: "customresourcedefinitions",
-	}
-
-	resultList, err := (*dynamicClient).Resource(gvr).List(context.Background(), v1.ListOptions{})
-	if err != nil {
-		return false, err
-	}
-
-	// check the presence of chaosresult CRD inside cluster
-	for _, crd := range resultList.Items {
-		if crd.GetName() == chaosTypes.ResultCRDName {
-			return true, nil
+// isResultCRDAvailable check the existence of chaosresult CRD inside cluster, through the discovery
+// based RESTMapper of the client, which doesn't need any cluster scoped permissions
+func (r *ChaosEngineReconciler) isResultCRDAvailable() (bool, error) {
+	gvk := litmuschaosv1alpha1.SchemeGroupVersion.WithKind("ChaosResult")
+	if _, err := r.Client.RESTMapper().RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+		if meta.IsNoMatchError(err) {
+			return false, nil
 		}
+		return false, err
 	}
 
-	return false, nil
+	return true, nil
 }
 
 // updates the chaos status of targets which is already present inside history.targets
@@ -842,8 +1113,23 @@ func updateTargets(name, status string, data *[]litmuschaosv1alpha1.TargetDetail
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *ChaosEngineReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&litmuschaosv1alpha1.ChaosEngine{}).
-		Owns(&corev1.Pod{}).
-		Complete(r)
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &litmuschaosv1alpha1.ChaosEngine{}, engineUIDField, indexEngineUID); err != nil {
+		return fmt.Errorf("unable to index the chaosengines by uid, due to error: %v", err)
+	}
+
+	controllerConfig := r.Config.Get().Controller
+	// the experiment jobs and the chaosresults of a run enqueue its chaosengine, to react to their changes without polling
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&litmuschaosv1alpha1.ChaosEngine{}, builder.WithPredicates(engineChanged)).
+		Owns(&corev1.Pod{}, builder.WithPredicates(podStatusChanged)).
+		Watches(&batchv1.Job{}, handler.EnqueueRequestsFromMapFunc(r.enqueueEngineOfChaosUID), builder.WithPredicates(hasChaosUID, jobStatusChanged)).
+		Watches(&litmuschaosv1alpha1.ChaosResult{}, handler.EnqueueRequestsFromMapFunc(r.enqueueEngineOfChaosUID), builder.WithPredicates(hasChaosUID, resultStatusChanged)).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: controllerConfig.MaxConcurrentReconciles,
+			RateLimiter:             newRateLimiter(controllerConfig.RateLimiter),
+		})
+	if r.Shards != nil {
+		b = b.WatchesRawSource(r.Shards.Subscribe(&litmuschaosv1alpha1.ChaosEngineList{}), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(r)
 }
@@ -18,10 +18,15 @@ import (
 	"fmt"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
@@ -29,7 +34,10 @@ import (
 
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
+	k8sretry "k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	litmusFakeClientset "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	chaosTypes "github.com/litmuschaos/chaos-operator/pkg/types"
 
@@ -215,7 +223,7 @@ func TestUpdateEngineForComplete(t *testing.T) {
 				fmt.Printf("Unable to create engine: %v", err)
 			}
 
-			_, err = r.updateEngineForComplete(&mock.engine, true)
+			_, err = r.updateEngineForComplete(context.TODO(), &mock.engine, true)
 			if mock.isErr && err == nil {
 				t.Fatalf("Test %q failed: expected error not to be nil", name)
 			}
@@ -297,7 +305,7 @@ func TestUpdateEngineForRestart(t *testing.T) {
 				fmt.Printf("Unable to create engine: %v", err)
 			}
 
-			_, err = r.updateEngineForRestart(&mock.engine)
+			_, err = r.updateEngineForRestart(context.TODO(), &mock.engine)
 			if mock.isErr && err == nil {
 				t.Fatalf("Test %q failed: expected error not to be nil", name)
 			}
@@ -561,7 +569,7 @@ func TestInitEngine(t *testing.T) {
 	for name, mock := range tests {
 		t.Run(name, func(t *testing.T) {
 			r := CreateFakeClient(t)
-			_, err := r.initEngine(&mock.engine)
+			_, err := r.initEngine(context.TODO(), &mock.engine)
 			if mock.isErr && err == nil {
 				t.Fatalf("Test %q failed: expected error not to be nil", name)
 			}
@@ -650,7 +658,7 @@ func TestUpdateEngineState(t *testing.T) {
 			if err != nil {
 				fmt.Printf("Unable to create engine: %v", err)
 			}
-			err = r.updateEngineState(&mock.engine, mock.state)
+			err = r.updateEngineState(context.TODO(), &mock.engine, mock.state)
 			if mock.isErr && err == nil {
 				t.Fatalf("Test %q failed: expected error not to be nil", name)
 			}
@@ -743,7 +751,7 @@ func TestCheckRunnerPodCompletedStatus(t *testing.T) {
 			}); err != nil {
 				fmt.Printf("Unable to create engine: %v", err)
 			}
-			val, err := r.checkRunnerContainerCompletedStatus(&mock.engine)
+			val, err := r.checkRunnerContainerCompletedStatus(context.TODO(), &mock.engine)
 			if err != nil {
 				fmt.Printf("Unable to check status: %v", err)
 			}
@@ -803,7 +811,7 @@ func TestEngineRunnerPod(t *testing.T) {
 			if name == "Test Positive-2" {
 				require.NoError(t, mock.runner.r.Client.Create(context.TODO(), mock.runner.engineRunner))
 			}
-			err := engineRunnerPod(mock.runner)
+			err := engineRunnerPod(context.TODO(), mock.runner)
 			if mock.isErr && err == nil {
 				t.Fatalf("Test %q failed: expected error not to be nil", name)
 			}
@@ -931,7 +939,7 @@ func TestGetChaosEngineInstance(t *testing.T) {
 					fmt.Printf("Unable to create engine: %v", err)
 				}
 			}
-			err := r.getChaosEngineInstance(&mock.engine, mock.request)
+			err := r.getChaosEngineInstance(context.TODO(), &mock.engine, mock.request)
 			if mock.isErr && err == nil {
 				t.Fatalf("Test %q failed: expected error not to be nil", name)
 			}
@@ -1102,7 +1110,7 @@ func TestCheckEngineRunnerPod(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			r := CreateFakeClient(t)
 			reqLogger := chaosTypes.Log.WithValues()
-			err := r.checkEngineRunnerPod(&mock.engine, reqLogger)
+			err := r.checkEngineRunnerPod(context.TODO(), &mock.engine, reqLogger)
 			if mock.isErr && err == nil {
 				t.Fatalf("Test %q failed: expected error not to be nil", name)
 			}
@@ -1159,9 +1167,11 @@ func TestReconcileForDelete(t *testing.T) {
 		"Test Positive-2": {
 			engine: chaosTypes.EngineInfo{
 				Instance: &v1alpha1.ChaosEngine{
+					// the engine has been read from the cluster before its deletion
 					ObjectMeta: metav1.ObjectMeta{
-						Name:      "engine-instance-n1",
-						Namespace: "default",
+						Name:            "engine-instance-n1",
+						Namespace:       "default",
+						ResourceVersion: "1",
 					},
 					Spec: v1alpha1.ChaosEngineSpec{
 						Appinfo: v1alpha1.ApplicationParams{
@@ -1203,7 +1213,7 @@ func TestReconcileForDelete(t *testing.T) {
 					fmt.Printf("Unable to create engine: %v", err)
 				}
 			}
-			_, err := r.reconcileForDelete(&mock.engine, mock.request)
+			_, err := r.reconcileForDelete(context.TODO(), &mock.engine, mock.request)
 			if mock.isErr && err == nil {
 				t.Fatalf("Test %q failed: expected error not to be nil", name)
 			}
@@ -1289,7 +1299,7 @@ func TestForceRemoveAllChaosPods(t *testing.T) {
 			if err != nil {
 				fmt.Printf("Unable to create engine: %v", err)
 			}
-			err = r.forceRemoveChaosResources(&mock.engine, mock.request)
+			err = r.forceRemoveChaosResources(context.TODO(), &mock.engine, mock.request)
 			if mock.isErr && err == nil {
 				t.Fatalf("Test %q failed: expected error not to be nil", name)
 			}
@@ -1388,7 +1398,7 @@ func TestGracefullyRemoveDefaultChaosResources(t *testing.T) {
 			if err != nil {
 				fmt.Printf("Unable to create engine: %v", err)
 			}
-			_, err = r.gracefullyRemoveDefaultChaosResources(&mock.engine, mock.request)
+			_, err = r.gracefullyRemoveDefaultChaosResources(context.TODO(), &mock.engine, mock.request)
 			if mock.isErr && err == nil {
 				t.Fatalf("Test %q failed: expected error not to be nil", name)
 			}
@@ -1509,7 +1519,7 @@ func TestReconcileForCreationAndRunning(t *testing.T) {
 		t.Run(name, func(t *testing.T) {
 			r := CreateFakeClient(t)
 			reqLogger := chaosTypes.Log.WithValues()
-			_, err := r.reconcileForCreationAndRunning(&mock.engine, reqLogger)
+			_, err := r.reconcileForCreationAndRunning(context.TODO(), &mock.engine, reqLogger)
 			if mock.isErr && err == nil {
 				t.Fatalf("Test %q failed: expected error not to be nil", name)
 			}
@@ -1520,13 +1530,358 @@ func TestReconcileForCreationAndRunning(t *testing.T) {
 	}
 }
 
-func CreateFakeClient(t *testing.T) *ChaosEngineReconciler {
+func TestReconcileForTTL(t *testing.T) {
+	ttl := int32(60)
+	tests := map[string]struct {
+		ttl             *int32
+		completionTime  metav1.Time
+		isDeleted       bool
+		isRequeuedAfter bool
+	}{
+		"Test Positive-1": {
+			ttl:            &ttl,
+			completionTime: metav1.NewTime(time.Now().Add(-2 * time.Minute)),
+			isDeleted:      true,
+		},
+		"Test Positive-2": {
+			ttl:             &ttl,
+			completionTime:  metav1.Now(),
+			isRequeuedAfter: true,
+		},
+		"Test Negative-1": {
+			completionTime: metav1.NewTime(time.Now().Add(-2 * time.Minute)),
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := CreateFakeClient(t)
+			engine := chaosTypes.EngineInfo{
+				Instance: &v1alpha1.ChaosEngine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "engine-ttl",
+						Namespace: "default",
+					},
+					Spec: v1alpha1.ChaosEngineSpec{
+						EngineState:             v1alpha1.EngineStateStop,
+						TTLSecondsAfterFinished: mock.ttl,
+					},
+					Status: v1alpha1.ChaosEngineStatus{
+						EngineStatus:   v1alpha1.EngineStatusCompleted,
+						CompletionTime: &mock.completionTime,
+					},
+				},
+			}
+			if err := r.Client.Create(context.TODO(), engine.Instance); err != nil {
+				t.Fatalf("Test %q failed: unable to create engine: %v", name, err)
+			}
+
+			result, err := r.reconcileForTTL(context.TODO(), &engine)
+			if err != nil {
+				t.Fatalf("Test %q failed: expected error to be nil, received %v", name, err)
+			}
+			if (result.RequeueAfter > 0) != mock.isRequeuedAfter {
+				t.Fatalf("Test %q failed: expected requeue after ttl to be %v, received %v", name, mock.isRequeuedAfter, result.RequeueAfter)
+			}
+			err = r.Client.Get(context.TODO(), types.NamespacedName{Name: "engine-ttl", Namespace: "default"}, &v1alpha1.ChaosEngine{})
+			if (err != nil) != mock.isDeleted {
+				t.Fatalf("Test %q failed: expected engine deletion to be %v, received error %v", name, mock.isDeleted, err)
+			}
+		})
+	}
+}
 
-	fakeClient := litmusFakeClientset.NewFakeClient()
-	if fakeClient == nil {
-		fmt.Println("litmusClient is not created")
+func TestUpdateRunReport(t *testing.T) {
+	r := CreateFakeClient(t)
+	engine := chaosTypes.EngineInfo{
+		Instance: &v1alpha1.ChaosEngine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "engine-report",
+				Namespace: "default",
+				UID:       "engine-report-uid",
+			},
+			Status: v1alpha1.ChaosEngineStatus{
+				EngineStatus: v1alpha1.EngineStatusCompleted,
+				Experiments: []v1alpha1.ExperimentStatuses{
+					{Name: "pod-delete", Verdict: "Pass"},
+				},
+			},
+		},
+	}
+	if err := r.Client.Create(context.TODO(), engine.Instance); err != nil {
+		t.Fatalf("Test failed: unable to create engine: %v", err)
+	}
+
+	// the report is updated on every reconcile of the completed chaosengine
+	for i := 0; i < 2; i++ {
+		if err := r.updateRunReport(context.TODO(), &engine); err != nil {
+			t.Fatalf("Test failed: expected error to be nil, received %v", err)
+		}
 	}
 
+	reportConfigMap := &corev1.ConfigMap{}
+	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: "engine-report-report", Namespace: "default"}, reportConfigMap); err != nil {
+		t.Fatalf("Test failed: unable to get report configmap: %v", err)
+	}
+	if !strings.Contains(reportConfigMap.Data["report.json"], `"name": "pod-delete"`) || !strings.Contains(reportConfigMap.Data["junit.xml"], "<testcase") {
+		t.Fatalf("Test failed: unexpected report configmap data: %v", reportConfigMap.Data)
+	}
+	if len(reportConfigMap.OwnerReferences) != 1 || reportConfigMap.OwnerReferences[0].UID != engine.Instance.UID {
+		t.Fatalf("Test failed: expected report configmap to be owned by the engine, received %v", reportConfigMap.OwnerReferences)
+	}
+}
+
+func TestPatchEngineStatus(t *testing.T) {
+	tests := map[string]struct {
+		engine       *v1alpha1.ChaosEngine
+		update       func(r *ChaosEngineReconciler, engine *chaosTypes.EngineInfo) error
+		engineState  v1alpha1.EngineState
+		engineStatus v1alpha1.EngineStatus
+	}{
+		"Test Positive-1": {
+			engine: &v1alpha1.ChaosEngine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "engine-status-p1",
+					Namespace:  "default",
+					UID:        "engine-status-p1-uid",
+					Finalizers: []string{finalizer},
+				},
+				Spec: v1alpha1.ChaosEngineSpec{
+					EngineState: v1alpha1.EngineStateActive,
+					Experiments: []v1alpha1.ExperimentList{{Name: "pod-delete"}},
+				},
+				Status: v1alpha1.ChaosEngineStatus{
+					EngineStatus: v1alpha1.EngineStatusInitialized,
+				},
+			},
+			update: func(r *ChaosEngineReconciler, engine *chaosTypes.EngineInfo) error {
+				_, err := r.updateEngineForComplete(context.TODO(), engine, true)
+				return err
+			},
+			engineState:  v1alpha1.EngineStateStop,
+			engineStatus: v1alpha1.EngineStatusCompleted,
+		},
+		"Test Positive-2": {
+			engine: &v1alpha1.ChaosEngine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "engine-status-p2",
+					Namespace: "default",
+					UID:       "engine-status-p2-uid",
+				},
+				Spec: v1alpha1.ChaosEngineSpec{
+					Experiments: []v1alpha1.ExperimentList{{Name: "pod-delete"}},
+				},
+			},
+			update: func(r *ChaosEngineReconciler, engine *chaosTypes.EngineInfo) error {
+				_, err := r.initEngine(context.TODO(), engine)
+				return err
+			},
+			engineState:  v1alpha1.EngineStateActive,
+			engineStatus: v1alpha1.EngineStatusInitialized,
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := CreateFakeClient(t)
+			if err := r.Client.Create(context.TODO(), mock.engine); err != nil {
+				t.Fatalf("Test %q failed: unable to create engine: %v", name, err)
+			}
+
+			// the runner updates the experiment statuses between the read and the status patch of the operator,
+			// while the cache of the client still holds the chaosengine created above
+			isWritten := false
+			apiServer := r.Client
+			r.APIReader = apiServer
+			r.Client = interceptor.NewClient(r.Client.(client.WithWatch), interceptor.Funcs{
+				Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					if engine, ok := obj.(*v1alpha1.ChaosEngine); ok {
+						if err := c.Get(ctx, key, engine, opts...); err != nil {
+							return err
+						}
+						engine.ResourceVersion, engine.Status = mock.engine.ResourceVersion, mock.engine.Status
+						return nil
+					}
+					return c.Get(ctx, key, obj, opts...)
+				},
+				SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+					if !isWritten {
+						isWritten = true
+						if err := writeExperimentStatus(c, mock.engine.Name, "pod-delete"); err != nil {
+							return err
+						}
+					}
+					// the fake client does not check the resourceVersion of the status patches, unlike the apiserver
+					latest := &v1alpha1.ChaosEngine{}
+					if err := c.Get(ctx, client.ObjectKeyFromObject(obj), latest); err != nil {
+						return err
+					}
+					if latest.ResourceVersion != obj.GetResourceVersion() {
+						return k8serrors.NewConflict(v1alpha1.Resource("chaosengines"), obj.GetName(), fmt.Errorf("the object has been modified"))
+					}
+					return c.SubResource(subResourceName).Patch(ctx, obj, patch, opts...)
+				},
+			})
+
+			engine := &chaosTypes.EngineInfo{Instance: mock.engine.DeepCopy()}
+			if err := mock.update(r, engine); err != nil {
+				t.Fatalf("Test %q failed: expected error to be nil, received %v", name, err)
+			}
+			if err := reconcileResults(apiServer, mock.engine.Name, "pod-delete"); err != nil {
+				t.Fatalf("Test %q failed: unable to reconcile the chaosresults, due to error: %v", name, err)
+			}
+
+			updated := &v1alpha1.ChaosEngine{}
+			if err := apiServer.Get(context.TODO(), types.NamespacedName{Name: mock.engine.Name, Namespace: mock.engine.Namespace}, updated); err != nil {
+				t.Fatalf("Test %q failed: unable to get engine: %v", name, err)
+			}
+			if updated.Spec.EngineState != mock.engineState || updated.Status.EngineStatus != mock.engineStatus {
+				t.Fatalf("Test %q failed: expected %v/%v, received %v/%v", name, mock.engineState, mock.engineStatus, updated.Spec.EngineState, updated.Status.EngineStatus)
+			}
+			if len(updated.Status.Experiments) != 1 || updated.Status.Experiments[0].Name != "pod-delete" {
+				t.Fatalf("Test %q failed: expected the experiment status of the runner, received %v", name, updated.Status.Experiments)
+			}
+		})
+	}
+}
+
+func TestConcurrentStatusWrites(t *testing.T) {
+	r := CreateFakeClient(t)
+	instance := &v1alpha1.ChaosEngine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "engine-concurrent",
+			Namespace:  "default",
+			UID:        "engine-concurrent-uid",
+			Finalizers: []string{finalizer},
+		},
+		Spec: v1alpha1.ChaosEngineSpec{
+			EngineState: v1alpha1.EngineStateActive,
+		},
+		Status: v1alpha1.ChaosEngineStatus{
+			EngineStatus: v1alpha1.EngineStatusInitialized,
+		},
+	}
+	experiments := []string{"pod-delete", "pod-cpu-hog", "pod-memory-hog", "pod-network-loss", "container-kill"}
+	for _, experiment := range experiments {
+		instance.Spec.Experiments = append(instance.Spec.Experiments, v1alpha1.ExperimentList{Name: experiment})
+	}
+	if err := r.Client.Create(context.TODO(), instance); err != nil {
+		t.Fatalf("Test failed: unable to create engine: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, len(experiments)+1)
+	for _, experiment := range experiments {
+		wg.Add(1)
+		go func(experiment string) {
+			defer wg.Done()
+			errs <- writeExperimentStatus(r.Client, instance.Name, experiment)
+		}(experiment)
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		engine := &chaosTypes.EngineInfo{Instance: instance.DeepCopy()}
+		_, err := r.updateEngineForComplete(context.TODO(), engine, true)
+		errs <- err
+	}()
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("Test failed: expected error to be nil, received %v", err)
+		}
+	}
+	if err := reconcileResults(r.Client, instance.Name, experiments...); err != nil {
+		t.Fatalf("Test failed: unable to reconcile the chaosresults, due to error: %v", err)
+	}
+
+	updated := &v1alpha1.ChaosEngine{}
+	if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: instance.Name, Namespace: instance.Namespace}, updated); err != nil {
+		t.Fatalf("Test failed: unable to get engine: %v", err)
+	}
+	if updated.Spec.EngineState != v1alpha1.EngineStateStop || updated.Status.EngineStatus != v1alpha1.EngineStatusCompleted {
+		t.Fatalf("Test failed: expected stop/completed, received %v/%v", updated.Spec.EngineState, updated.Status.EngineStatus)
+	}
+	if len(updated.Status.Experiments) != len(experiments) {
+		t.Fatalf("Test failed: expected %d experiment statuses, received %v", len(experiments), updated.Status.Experiments)
+	}
+}
+
+func TestIsResultCRDAvailable(t *testing.T) {
+	tests := map[string]struct {
+		isServed bool
+	}{
+		"Test Positive-1": {
+			isServed: true,
+		},
+		"Test Positive-2": {
+			isServed: false,
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{v1alpha1.SchemeGroupVersion})
+			if mock.isServed {
+				mapper.Add(v1alpha1.SchemeGroupVersion.WithKind("ChaosResult"), meta.RESTScopeNamespace)
+			}
+			r := &ChaosEngineReconciler{Client: litmusFakeClientset.NewClientBuilder().WithRESTMapper(mapper).Build()}
+
+			found, err := r.isResultCRDAvailable()
+			if err != nil {
+				t.Fatalf("Test %q failed: expected error to be nil, received %v", name, err)
+			}
+			if found != mock.isServed {
+				t.Fatalf("Test %q failed: expected %v, received %v", name, mock.isServed, found)
+			}
+		})
+	}
+}
+
+// writeExperimentStatus adds the running experiment status to the chaosengine as the chaos-runner does, i.e. along
+// with the spec, which the status subresource drops, and creates the chaosresult of the experiment as it does
+func writeExperimentStatus(c client.Client, name, experiment string) error {
+	engine := &v1alpha1.ChaosEngine{}
+	err := k8sretry.RetryOnConflict(k8sretry.DefaultRetry, func() error {
+		if err := c.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: "default"}, engine); err != nil {
+			return err
+		}
+		engine.Status.Experiments = append(engine.Status.Experiments, v1alpha1.ExperimentStatuses{
+			Name:           experiment,
+			Status:         v1alpha1.ExperimentStatusRunning,
+			LastUpdateTime: metav1.Now(),
+		})
+		return c.Update(context.TODO(), engine)
+	})
+	if err != nil {
+		return err
+	}
+	return c.Create(context.TODO(), &v1alpha1.ChaosResult{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name + "-" + experiment,
+			Namespace: "default",
+			Labels:    map[string]string{"chaosUID": string(engine.UID)},
+		},
+		Spec: v1alpha1.ChaosResultSpec{EngineName: name, ExperimentName: experiment},
+		Status: v1alpha1.ChaosResultStatus{
+			ExperimentStatus: v1alpha1.TestStatus{Phase: v1alpha1.ResultPhaseRunning, Verdict: v1alpha1.ResultVerdictAwaited},
+		},
+	})
+}
+
+// reconcileResults reconciles the chaosresults of the experiments of the chaosengine, as the operator does once
+// they are written
+func reconcileResults(c client.Client, name string, experiments ...string) error {
+	resultReconciler := &ChaosResultReconciler{Client: c}
+	for _, experiment := range experiments {
+		request := reconcile.Request{NamespacedName: types.NamespacedName{Name: name + "-" + experiment, Namespace: "default"}}
+		if _, err := resultReconciler.Reconcile(context.TODO(), request); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func CreateFakeClient(t *testing.T) *ChaosEngineReconciler {
+
 	s := scheme.Scheme
 
 	engineR := &v1alpha1.ChaosEngine{
@@ -1540,7 +1895,10 @@ func CreateFakeClient(t *testing.T) *ChaosEngineReconciler {
 		Items: []v1alpha1.ChaosResult{},
 	}
 
-	s.AddKnownTypes(v1alpha1.SchemeGroupVersion, engineR, chaosResultList)
+	s.AddKnownTypes(v1alpha1.SchemeGroupVersion, engineR, chaosResultList, &v1alpha1.ChaosResult{})
+
+	// the chaosengine status is written through the status subresource, as in the CRD
+	fakeClient := litmusFakeClientset.NewClientBuilder().WithScheme(s).WithStatusSubresource(&v1alpha1.ChaosEngine{}).Build()
 
 	recorder := record.NewFakeRecorder(1024)
 
@@ -0,0 +1,438 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-logr/logr"
+	litmuschaosv1alpha1 "github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/chaos-operator/pkg/analytics"
+	"github.com/litmuschaos/chaos-operator/pkg/cloudevents"
+	"github.com/litmuschaos/chaos-operator/pkg/config"
+	"github.com/litmuschaos/chaos-operator/pkg/health"
+	"github.com/litmuschaos/chaos-operator/pkg/metrics"
+	"github.com/litmuschaos/chaos-operator/pkg/sharding"
+	"github.com/litmuschaos/chaos-operator/pkg/timeout"
+	chaosTypes "github.com/litmuschaos/chaos-operator/pkg/types"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// historyBaselineAnnotation holds the passed/failed/stopped run counts of a chaosresult,
+// as observed when the current run was first seen in running phase
+const historyBaselineAnnotation = "litmuschaos.io/history-baseline"
+
+// defaultExperimentWeight is the weight of the experiments in the resilience score, if not specified in the engine
+const defaultExperimentWeight uint32 = 10
+
+// ChaosResultReconciler reconciles a ChaosResult object
+type ChaosResultReconciler struct {
+	// This client, initialized using mgr.Client() above, is a split client
+	// that reads objects from the cache and writes to the apiserver
+	client.Client
+	// Used for serializing and deserializing API objects(group, version, and kind)
+	Scheme *runtime.Scheme
+	// CloudEvents publishes the experiment status changes, it is optional
+	CloudEvents *cloudevents.Publisher
+	// Shards restricts the reconcile to the namespaces of the shards owned by this replica, it is optional
+	Shards *sharding.Sharder
+	// Config holds the effective operator config, the defaults are used if it is not set
+	Config *config.Store
+	// Watchdog tracks the running reconciles for the liveness check, it is optional
+	Watchdog *health.Watchdog
+	// Analytics counts the completed experiment runs, it is optional
+	Analytics *analytics.Collector
+}
+
+//+kubebuilder:rbac:groups=litmuschaos.io,resources=chaosresults,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=litmuschaos.io,resources=chaosresults/status,verbs=get;update;patch
+
+// Reconcile reads the state of a ChaosResult created for a ChaosEngine (labeled with chaosUID),
+// keeps its history consistent and mirrors the experiment status back into the owning ChaosEngine
+func (r *ChaosResultReconciler) Reconcile(ctx context.Context, request ctrl.Request) (_ ctrl.Result, err error) {
+	defer r.Watchdog.Track()()
+
+	ctx, cancel := timeout.WithReconcileTimeout(ctx, r.Config)
+	defer func() {
+		err = timeout.Observe(ctx, timeout.OperationReconcile, "ChaosResult", err)
+		cancel()
+	}()
+
+	if owned, err := r.Shards.Owns(ctx, request.Namespace); err != nil || !owned {
+		return reconcile.Result{}, err
+	}
+
+	reqLogger := chaosTypes.Log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("Reconciling ChaosResult")
+
+	result := &litmuschaosv1alpha1.ChaosResult{}
+	if err := r.Client.Get(ctx, request.NamespacedName, result); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if result.Labels["chaosUID"] == "" {
+		return reconcile.Result{}, nil
+	}
+
+	if requeue, err := r.updateResultHistory(ctx, result); err != nil {
+		if requeue {
+			return reconcile.Result{Requeue: true}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	if requeue, err := r.updateEngineExperimentStatus(ctx, result, reqLogger); err != nil {
+		if requeue {
+			return reconcile.Result{Requeue: true}, nil
+		}
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// updateResultHistory moves the chaos status annotations into history.targets and
+// keeps the passed, failed and stopped run counts consistent with the verdict
+func (r *ChaosResultReconciler) updateResultHistory(ctx context.Context, result *litmuschaosv1alpha1.ChaosResult) (bool, error) {
+	updated := result.DeepCopy()
+	if updated.Status.History == nil {
+		updated.Status.History = &litmuschaosv1alpha1.HistoryDetails{}
+	}
+
+	if len(updated.ObjectMeta.Annotations) != 0 {
+		targetsList, annotations := getChaosStatus(*updated)
+		updated.Status.History.Targets = targetsList
+		updated.ObjectMeta.Annotations = annotations
+	}
+	updateHistoryCounts(updated)
+
+	if reflect.DeepEqual(result, updated) {
+		return false, nil
+	}
+
+	chaosTypes.Log.Info("updating history inside chaosresult", "chaosresult", result.Name)
+	if err := r.Client.Update(ctx, updated, &client.UpdateOptions{}); err != nil {
+		if k8serrors.IsConflict(err) {
+			return true, err
+		}
+		return false, fmt.Errorf("unable to update ChaosResult history, due to update error: %v", err)
+	}
+	// the baseline is dropped once the verdict of the run is counted into the history
+	if _, running := result.Annotations[historyBaselineAnnotation]; running {
+		if _, found := updated.Annotations[historyBaselineAnnotation]; !found {
+			r.Analytics.RecordRun(string(updated.Status.ExperimentStatus.Verdict))
+		}
+	}
+	*result = *updated
+	return false, nil
+}
+
+// updateEngineExperimentStatus mirrors the verdict, phase and probe success percentage of the
+// chaosresult into the experiment status of the chaosengine which launched the experiment
+func (r *ChaosResultReconciler) updateEngineExperimentStatus(ctx context.Context, result *litmuschaosv1alpha1.ChaosResult, reqLogger logr.Logger) (bool, error) {
+	if result.Spec.EngineName == "" {
+		return false, nil
+	}
+
+	engine := &litmuschaosv1alpha1.ChaosEngine{}
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: result.Spec.EngineName, Namespace: result.Namespace}, engine); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	// skip the chaosresults which belong to an older instance of the chaosengine
+	if string(engine.UID) != result.Labels["chaosUID"] {
+		return false, nil
+	}
+
+	patch := client.MergeFromWithOptions(engine.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	isMirrored := mirrorExperimentStatus(engine, result)
+	isUpdated := isMirrored
+	if score, isScored := resilienceScore(engine); isScored {
+		metrics.SetResilienceScore(engine.Namespace, engine.Name, score)
+		if formatted := strconv.FormatFloat(score, 'f', 2, 64); engine.Status.ResilienceScore != formatted {
+			engine.Status.ResilienceScore = formatted
+			isUpdated = true
+		}
+	}
+	if !isUpdated {
+		return false, nil
+	}
+
+	reqLogger.Info("updating experiment status inside chaosengine", "chaosengine", engine.Name, "experiment", result.Spec.ExperimentName)
+	if err := r.Client.Status().Patch(ctx, engine, patch); err != nil {
+		if k8serrors.IsConflict(err) {
+			return true, err
+		}
+		return false, fmt.Errorf("unable to patch experiment status of chaosEngine Resource, due to error: %v", err)
+	}
+
+	if isMirrored {
+		for _, expStatus := range engine.Status.Experiments {
+			if expStatus.Name == result.Spec.ExperimentName {
+				r.CloudEvents.Publish(cloudevents.NewExperimentEvent(engine, expStatus))
+			}
+		}
+	}
+	return false, nil
+}
+
+// mirrorExperimentStatus copies the chaosresult details into the matching experiment status of the chaosengine.
+// The experiment status is added from the chaosresult if the chaos-runner has not written it, as the older
+// chaos-runners update the status along with the spec, which the status subresource drops
+// it returns true if the experiment status has been changed
+func mirrorExperimentStatus(engine *litmuschaosv1alpha1.ChaosEngine, result *litmuschaosv1alpha1.ChaosResult) bool {
+	expStatus := findExperimentStatus(engine, result.Spec.ExperimentName)
+	if expStatus == nil {
+		if !isEngineExperiment(engine, result.Spec.ExperimentName) {
+			return false
+		}
+		engine.Status.Experiments = append(engine.Status.Experiments, litmuschaosv1alpha1.ExperimentStatuses{
+			Name:    result.Spec.ExperimentName,
+			Runner:  engine.Name + "-runner",
+			Verdict: string(litmuschaosv1alpha1.ResultVerdictAwaited),
+		})
+		expStatus = &engine.Status.Experiments[len(engine.Status.Experiments)-1]
+	}
+
+	verdict := string(result.Status.ExperimentStatus.Verdict)
+	if verdict == "" {
+		verdict = expStatus.Verdict
+	}
+	status := experimentStatusOf(expStatus.Status, result.Status.ExperimentStatus.Phase)
+	if expStatus.Status == status &&
+		expStatus.Verdict == verdict &&
+		expStatus.ResultPhase == result.Status.ExperimentStatus.Phase &&
+		expStatus.ProbeSuccessPercentage == result.Status.ExperimentStatus.ProbeSuccessPercentage {
+		return false
+	}
+
+	expStatus.Status = status
+	expStatus.Verdict = verdict
+	expStatus.ResultPhase = result.Status.ExperimentStatus.Phase
+	expStatus.ProbeSuccessPercentage = result.Status.ExperimentStatus.ProbeSuccessPercentage
+	expStatus.LastUpdateTime = v1.Now()
+	return true
+}
+
+// findExperimentStatus returns the status of the experiment in the chaosengine, or nil if it is not found
+func findExperimentStatus(engine *litmuschaosv1alpha1.ChaosEngine, experiment string) *litmuschaosv1alpha1.ExperimentStatuses {
+	for i := range engine.Status.Experiments {
+		if engine.Status.Experiments[i].Name == experiment {
+			return &engine.Status.Experiments[i]
+		}
+	}
+	return nil
+}
+
+// isEngineExperiment returns true if the experiment is listed in the chaosengine spec
+func isEngineExperiment(engine *litmuschaosv1alpha1.ChaosEngine, experiment string) bool {
+	for _, exp := range engine.Spec.Experiments {
+		if exp.Name == experiment {
+			return true
+		}
+	}
+	return false
+}
+
+// experimentStatusOf derives the experiment status from the phase of its chaosresult, the aborted experiments
+// and the chaosresults without a phase keep their current status
+func experimentStatusOf(current litmuschaosv1alpha1.ExperimentStatus, phase litmuschaosv1alpha1.ResultPhase) litmuschaosv1alpha1.ExperimentStatus {
+	if current == litmuschaosv1alpha1.ExperimentStatusAborted {
+		return current
+	}
+	switch phase {
+	case "":
+		return current
+	case litmuschaosv1alpha1.ResultPhaseRunning:
+		return litmuschaosv1alpha1.ExperimentStatusRunning
+	case litmuschaosv1alpha1.ResultPhaseStopped:
+		return litmuschaosv1alpha1.ExperimentStatusAborted
+	default:
+		return litmuschaosv1alpha1.ExperimentStatusCompleted
+	}
+}
+
+// resilienceScore returns the average of the probe success percentages of the finished experiments of the chaosengine,
+// weighted as per the experiment weights. It returns false if none of the experiments has been scored yet
+func resilienceScore(engine *litmuschaosv1alpha1.ChaosEngine) (float64, bool) {
+	weights := make(map[string]uint32, len(engine.Spec.Experiments))
+	for _, exp := range engine.Spec.Experiments {
+		weights[exp.Name] = defaultExperimentWeight
+		if exp.Spec.Weight != nil {
+			weights[exp.Name] = *exp.Spec.Weight
+		}
+	}
+
+	var weightedSum, totalWeight float64
+	for _, expStatus := range engine.Status.Experiments {
+		percentage, isScored := experimentScore(expStatus)
+		if !isScored {
+			continue
+		}
+		weight, found := weights[expStatus.Name]
+		if !found {
+			weight = defaultExperimentWeight
+		}
+		weightedSum += float64(weight) * percentage
+		totalWeight += float64(weight)
+	}
+	if totalWeight == 0 {
+		return 0, false
+	}
+	return weightedSum / totalWeight, true
+}
+
+// experimentScore returns the probe success percentage of the finished experiment
+// the verdict is used for the experiments which do not report the probe success percentage,
+// while the stopped experiments are not scored at all
+func experimentScore(expStatus litmuschaosv1alpha1.ExperimentStatuses) (float64, bool) {
+	switch expStatus.ResultPhase {
+	case litmuschaosv1alpha1.ResultPhaseCompleted, litmuschaosv1alpha1.ResultPhaseCompletedWithError,
+		litmuschaosv1alpha1.ResultPhaseCompletedWithProbeFailure, litmuschaosv1alpha1.ResultPhaseError:
+	default:
+		return 0, false
+	}
+
+	if percentage, err := strconv.ParseFloat(expStatus.ProbeSuccessPercentage, 64); err == nil {
+		switch {
+		case percentage < 0:
+			return 0, true
+		case percentage > 100:
+			return 100, true
+		}
+		return percentage, true
+	}
+
+	switch litmuschaosv1alpha1.ResultVerdict(expStatus.Verdict) {
+	case litmuschaosv1alpha1.ResultVerdictPassed:
+		return 100, true
+	case litmuschaosv1alpha1.ResultVerdictFailed, litmuschaosv1alpha1.ResultVerdictError:
+		return 0, true
+	}
+	return 0, false
+}
+
+// updateHistoryCounts keeps the passed, failed and stopped run counts of the chaosresult consistent.
+// The counts are snapshotted when a run is observed in running phase; once the run reaches a terminal
+// phase the count matching its verdict is ensured to be one above the snapshot, so runs which were
+// never accounted (say, an aborted experiment) are counted without counting the others twice.
+func updateHistoryCounts(result *litmuschaosv1alpha1.ChaosResult) {
+	history := result.Status.History
+	history.PassedRuns = nonNegative(history.PassedRuns)
+	history.FailedRuns = nonNegative(history.FailedRuns)
+	history.StoppedRuns = nonNegative(history.StoppedRuns)
+
+	baseline, found := result.Annotations[historyBaselineAnnotation]
+
+	switch result.Status.ExperimentStatus.Phase {
+	case litmuschaosv1alpha1.ResultPhaseRunning:
+		if !found {
+			if result.Annotations == nil {
+				result.Annotations = map[string]string{}
+			}
+			result.Annotations[historyBaselineAnnotation] = formatHistoryBaseline(history)
+		}
+	case litmuschaosv1alpha1.ResultPhaseCompleted, litmuschaosv1alpha1.ResultPhaseCompletedWithError,
+		litmuschaosv1alpha1.ResultPhaseCompletedWithProbeFailure, litmuschaosv1alpha1.ResultPhaseStopped,
+		litmuschaosv1alpha1.ResultPhaseError:
+		if !found {
+			return
+		}
+		if passed, failed, stopped, err := parseHistoryBaseline(baseline); err == nil {
+			switch result.Status.ExperimentStatus.Verdict {
+			case litmuschaosv1alpha1.ResultVerdictPassed:
+				history.PassedRuns = atLeast(history.PassedRuns, passed+1)
+			case litmuschaosv1alpha1.ResultVerdictFailed, litmuschaosv1alpha1.ResultVerdictError:
+				history.FailedRuns = atLeast(history.FailedRuns, failed+1)
+			case litmuschaosv1alpha1.ResultVerdictStopped:
+				history.StoppedRuns = atLeast(history.StoppedRuns, stopped+1)
+			default:
+				// verdict is yet to be evaluated, retain the baseline till then
+				return
+			}
+		}
+		delete(result.Annotations, historyBaselineAnnotation)
+	}
+}
+
+// formatHistoryBaseline formats the run counts as passed/failed/stopped
+func formatHistoryBaseline(history *litmuschaosv1alpha1.HistoryDetails) string {
+	return fmt.Sprintf("%d/%d/%d", history.PassedRuns, history.FailedRuns, history.StoppedRuns)
+}
+
+// parseHistoryBaseline parses the run counts formatted by formatHistoryBaseline
+func parseHistoryBaseline(baseline string) (int, int, int, error) {
+	fields := strings.Split(baseline, "/")
+	if len(fields) != 3 {
+		return 0, 0, 0, fmt.Errorf("invalid history baseline %q", baseline)
+	}
+	counts := make([]int, len(fields))
+	for i := range fields {
+		count, err := strconv.Atoi(fields[i])
+		if err != nil {
+			return 0, 0, 0, fmt.Errorf("invalid history baseline %q, err: %v", baseline, err)
+		}
+		counts[i] = count
+	}
+	return counts[0], counts[1], counts[2], nil
+}
+
+// nonNegative returns zero for the negative counts
+func nonNegative(count int) int {
+	return atLeast(count, 0)
+}
+
+// atLeast returns the count, raised to the given minimum
+func atLeast(count, minimum int) int {
+	if count < minimum {
+		return minimum
+	}
+	return count
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ChaosResultReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	b := ctrl.NewControllerManagedBy(mgr).
+		For(&litmuschaosv1alpha1.ChaosResult{}, builder.WithPredicates(hasChaosUID)).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: r.Config.Get().Controller.MaxConcurrentReconciles,
+			RateLimiter:             newRateLimiter(r.Config.Get().Controller.RateLimiter),
+		})
+	if r.Shards != nil {
+		b = b.WatchesRawSource(r.Shards.Subscribe(&litmuschaosv1alpha1.ChaosResultList{}), &handler.EnqueueRequestForObject{})
+	}
+	return b.Complete(r)
+}
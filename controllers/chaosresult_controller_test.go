@@ -0,0 +1,323 @@
+/*
+Copyright 2019 LitmusChaos Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+   http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestUpdateHistoryCounts(t *testing.T) {
+	tests := map[string]struct {
+		phase       v1alpha1.ResultPhase
+		verdict     v1alpha1.ResultVerdict
+		history     v1alpha1.HistoryDetails
+		annotations map[string]string
+		expected    v1alpha1.HistoryDetails
+		isBaseline  bool
+	}{
+		"Test Positive-1": {
+			phase:      v1alpha1.ResultPhaseRunning,
+			verdict:    v1alpha1.ResultVerdictAwaited,
+			history:    v1alpha1.HistoryDetails{PassedRuns: 2, FailedRuns: 1},
+			expected:   v1alpha1.HistoryDetails{PassedRuns: 2, FailedRuns: 1},
+			isBaseline: true,
+		},
+		"Test Positive-2": {
+			phase:       v1alpha1.ResultPhaseCompleted,
+			verdict:     v1alpha1.ResultVerdictPassed,
+			history:     v1alpha1.HistoryDetails{PassedRuns: 3, FailedRuns: 1},
+			annotations: map[string]string{historyBaselineAnnotation: "2/1/0"},
+			expected:    v1alpha1.HistoryDetails{PassedRuns: 3, FailedRuns: 1},
+		},
+		"Test Positive-3": {
+			phase:       v1alpha1.ResultPhaseStopped,
+			verdict:     v1alpha1.ResultVerdictStopped,
+			history:     v1alpha1.HistoryDetails{PassedRuns: 2, FailedRuns: 1},
+			annotations: map[string]string{historyBaselineAnnotation: "2/1/0"},
+			expected:    v1alpha1.HistoryDetails{PassedRuns: 2, FailedRuns: 1, StoppedRuns: 1},
+		},
+		"Test Positive-4": {
+			phase:       v1alpha1.ResultPhaseCompleted,
+			verdict:     v1alpha1.ResultVerdictAwaited,
+			history:     v1alpha1.HistoryDetails{PassedRuns: 2},
+			annotations: map[string]string{historyBaselineAnnotation: "2/0/0"},
+			expected:    v1alpha1.HistoryDetails{PassedRuns: 2},
+			isBaseline:  true,
+		},
+		"Test Negative-1": {
+			phase:    v1alpha1.ResultPhaseCompleted,
+			verdict:  v1alpha1.ResultVerdictFailed,
+			history:  v1alpha1.HistoryDetails{PassedRuns: -1, FailedRuns: 4},
+			expected: v1alpha1.HistoryDetails{PassedRuns: 0, FailedRuns: 4},
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			history := mock.history
+			result := &v1alpha1.ChaosResult{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "engine-pod-delete",
+					Annotations: mock.annotations,
+				},
+				Status: v1alpha1.ChaosResultStatus{
+					ExperimentStatus: v1alpha1.TestStatus{
+						Phase:   mock.phase,
+						Verdict: mock.verdict,
+					},
+					History: &history,
+				},
+			}
+			updateHistoryCounts(result)
+			if !reflect.DeepEqual(*result.Status.History, mock.expected) {
+				t.Fatalf("Test %q failed: expected history %v, received %v", name, mock.expected, *result.Status.History)
+			}
+			if _, found := result.Annotations[historyBaselineAnnotation]; found != mock.isBaseline {
+				t.Fatalf("Test %q failed: expected baseline annotation presence to be %v", name, mock.isBaseline)
+			}
+		})
+	}
+}
+
+func TestChaosResultReconcile(t *testing.T) {
+	tests := map[string]struct {
+		engineUID        types.UID
+		expectedVerdict  string
+		expectedTargets  int
+		expectedMirrored bool
+	}{
+		"Test Positive-1": {
+			engineUID:        "engine-uid-1",
+			expectedVerdict:  "Pass",
+			expectedTargets:  1,
+			expectedMirrored: true,
+		},
+		"Test Negative-1": {
+			engineUID:        "engine-uid-stale",
+			expectedVerdict:  "Awaited",
+			expectedTargets:  1,
+			expectedMirrored: false,
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := CreateFakeClient(t)
+			resultReconciler := &ChaosResultReconciler{Client: r.Client, Scheme: r.Scheme}
+
+			engine := &v1alpha1.ChaosEngine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "engine-result",
+					Namespace: "default",
+					UID:       mock.engineUID,
+				},
+				Status: v1alpha1.ChaosEngineStatus{
+					EngineStatus: v1alpha1.EngineStatusInitialized,
+					Experiments: []v1alpha1.ExperimentStatuses{
+						{
+							Name:    "pod-delete",
+							Status:  v1alpha1.ExperimentStatusRunning,
+							Verdict: "Awaited",
+						},
+					},
+				},
+			}
+			result := &v1alpha1.ChaosResult{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "engine-result-pod-delete",
+					Namespace: "default",
+					Labels:    map[string]string{"chaosUID": "engine-uid-1"},
+					Annotations: map[string]string{
+						"pod/nginx-0": "injected",
+					},
+				},
+				Spec: v1alpha1.ChaosResultSpec{
+					EngineName:     "engine-result",
+					ExperimentName: "pod-delete",
+				},
+				Status: v1alpha1.ChaosResultStatus{
+					ExperimentStatus: v1alpha1.TestStatus{
+						Phase:                  v1alpha1.ResultPhaseCompleted,
+						Verdict:                v1alpha1.ResultVerdictPassed,
+						ProbeSuccessPercentage: "100",
+					},
+				},
+			}
+			if err := r.Client.Create(context.TODO(), engine); err != nil {
+				t.Fatalf("Test %q failed: unable to create engine: %v", name, err)
+			}
+			if err := r.Client.Create(context.TODO(), result); err != nil {
+				t.Fatalf("Test %q failed: unable to create result: %v", name, err)
+			}
+
+			request := reconcile.Request{NamespacedName: types.NamespacedName{Name: result.Name, Namespace: result.Namespace}}
+			if _, err := resultReconciler.Reconcile(context.TODO(), request); err != nil {
+				t.Fatalf("Test %q failed: expected error to be nil, received %v", name, err)
+			}
+
+			updatedResult := &v1alpha1.ChaosResult{}
+			if err := r.Client.Get(context.TODO(), request.NamespacedName, updatedResult); err != nil {
+				t.Fatalf("Test %q failed: unable to get result: %v", name, err)
+			}
+			if len(updatedResult.Status.History.Targets) != mock.expectedTargets {
+				t.Fatalf("Test %q failed: expected %d targets, received %d", name, mock.expectedTargets, len(updatedResult.Status.History.Targets))
+			}
+			if _, found := updatedResult.Annotations["pod/nginx-0"]; found {
+				t.Fatalf("Test %q failed: expected chaos status annotation to be removed", name)
+			}
+
+			updatedEngine := &v1alpha1.ChaosEngine{}
+			if err := r.Client.Get(context.TODO(), types.NamespacedName{Name: engine.Name, Namespace: engine.Namespace}, updatedEngine); err != nil {
+				t.Fatalf("Test %q failed: unable to get engine: %v", name, err)
+			}
+			expStatus := updatedEngine.Status.Experiments[0]
+			if expStatus.Verdict != mock.expectedVerdict {
+				t.Fatalf("Test %q failed: expected verdict %q, received %q", name, mock.expectedVerdict, expStatus.Verdict)
+			}
+			if (expStatus.ProbeSuccessPercentage == "100") != mock.expectedMirrored {
+				t.Fatalf("Test %q failed: expected probe success percentage to be mirrored: %v", name, mock.expectedMirrored)
+			}
+		})
+	}
+}
+
+func TestResilienceScore(t *testing.T) {
+	weight := uint32(30)
+	tests := map[string]struct {
+		experiments []v1alpha1.ExperimentList
+		statuses    []v1alpha1.ExperimentStatuses
+		expected    float64
+		isScored    bool
+	}{
+		"Test Positive-1": {
+			experiments: []v1alpha1.ExperimentList{
+				{Name: "pod-delete", Spec: v1alpha1.ExperimentAttributes{Weight: &weight}},
+				{Name: "pod-cpu-hog"},
+			},
+			statuses: []v1alpha1.ExperimentStatuses{
+				{Name: "pod-delete", ResultPhase: v1alpha1.ResultPhaseCompleted, ProbeSuccessPercentage: "100"},
+				{Name: "pod-cpu-hog", ResultPhase: v1alpha1.ResultPhaseCompletedWithProbeFailure, ProbeSuccessPercentage: "50"},
+			},
+			expected: 87.5,
+			isScored: true,
+		},
+		"Test Positive-2": {
+			experiments: []v1alpha1.ExperimentList{{Name: "pod-delete"}, {Name: "pod-cpu-hog"}},
+			statuses: []v1alpha1.ExperimentStatuses{
+				{Name: "pod-delete", ResultPhase: v1alpha1.ResultPhaseCompleted, Verdict: "Fail", ProbeSuccessPercentage: "Awaited"},
+				{Name: "pod-cpu-hog", ResultPhase: v1alpha1.ResultPhaseRunning, ProbeSuccessPercentage: "100"},
+			},
+			expected: 0,
+			isScored: true,
+		},
+		"Test Negative-1": {
+			experiments: []v1alpha1.ExperimentList{{Name: "pod-delete"}},
+			statuses: []v1alpha1.ExperimentStatuses{
+				{Name: "pod-delete", ResultPhase: v1alpha1.ResultPhaseStopped, Verdict: "Stopped"},
+			},
+			isScored: false,
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			engine := &v1alpha1.ChaosEngine{
+				Spec:   v1alpha1.ChaosEngineSpec{Experiments: mock.experiments},
+				Status: v1alpha1.ChaosEngineStatus{Experiments: mock.statuses},
+			}
+			score, isScored := resilienceScore(engine)
+			if isScored != mock.isScored {
+				t.Fatalf("Test %q failed: expected scored to be %v, received %v", name, mock.isScored, isScored)
+			}
+			if score != mock.expected {
+				t.Fatalf("Test %q failed: expected score %v, received %v", name, mock.expected, score)
+			}
+		})
+	}
+}
+
+func TestMirrorExperimentStatus(t *testing.T) {
+	tests := map[string]struct {
+		statuses   []v1alpha1.ExperimentStatuses
+		experiment string
+		result     v1alpha1.TestStatus
+		isChanged  bool
+		expected   []v1alpha1.ExperimentStatuses
+	}{
+		"Test Positive-1": {
+			result:    v1alpha1.TestStatus{Phase: v1alpha1.ResultPhaseRunning, Verdict: v1alpha1.ResultVerdictAwaited},
+			isChanged: true,
+			expected: []v1alpha1.ExperimentStatuses{
+				{Name: "pod-delete", Runner: "engine-runner", Status: v1alpha1.ExperimentStatusRunning, Verdict: "Awaited", ResultPhase: v1alpha1.ResultPhaseRunning},
+			},
+		},
+		"Test Positive-2": {
+			statuses:  []v1alpha1.ExperimentStatuses{{Name: "pod-delete", Runner: "engine-runner", Status: v1alpha1.ExperimentStatusRunning, Verdict: "Awaited"}},
+			result:    v1alpha1.TestStatus{Phase: v1alpha1.ResultPhaseCompleted, Verdict: v1alpha1.ResultVerdictPassed, ProbeSuccessPercentage: "100"},
+			isChanged: true,
+			expected: []v1alpha1.ExperimentStatuses{
+				{Name: "pod-delete", Runner: "engine-runner", Status: v1alpha1.ExperimentStatusCompleted, Verdict: "Pass", ResultPhase: v1alpha1.ResultPhaseCompleted, ProbeSuccessPercentage: "100"},
+			},
+		},
+		"Test Positive-3": {
+			statuses:  []v1alpha1.ExperimentStatuses{{Name: "pod-delete", Status: v1alpha1.ExperimentStatusAborted, Verdict: "Awaited"}},
+			result:    v1alpha1.TestStatus{Phase: v1alpha1.ResultPhaseCompleted, Verdict: v1alpha1.ResultVerdictFailed},
+			isChanged: true,
+			expected: []v1alpha1.ExperimentStatuses{
+				{Name: "pod-delete", Status: v1alpha1.ExperimentStatusAborted, Verdict: "Fail", ResultPhase: v1alpha1.ResultPhaseCompleted},
+			},
+		},
+		"Test Negative-1": {
+			statuses:  []v1alpha1.ExperimentStatuses{{Name: "pod-delete", Status: v1alpha1.ExperimentStatusCompleted, Verdict: "Pass", ResultPhase: v1alpha1.ResultPhaseCompleted}},
+			result:    v1alpha1.TestStatus{Phase: v1alpha1.ResultPhaseCompleted, Verdict: v1alpha1.ResultVerdictPassed},
+			isChanged: false,
+			expected:  []v1alpha1.ExperimentStatuses{{Name: "pod-delete", Status: v1alpha1.ExperimentStatusCompleted, Verdict: "Pass", ResultPhase: v1alpha1.ResultPhaseCompleted}},
+		},
+		"Test Negative-2": {
+			experiment: "container-kill",
+			result:     v1alpha1.TestStatus{Phase: v1alpha1.ResultPhaseCompleted, Verdict: v1alpha1.ResultVerdictPassed},
+			isChanged:  false,
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			engine := &v1alpha1.ChaosEngine{
+				ObjectMeta: metav1.ObjectMeta{Name: "engine"},
+				Spec:       v1alpha1.ChaosEngineSpec{Experiments: []v1alpha1.ExperimentList{{Name: "pod-delete"}}},
+				Status:     v1alpha1.ChaosEngineStatus{Experiments: mock.statuses},
+			}
+			experiment := mock.experiment
+			if experiment == "" {
+				experiment = "pod-delete"
+			}
+			result := &v1alpha1.ChaosResult{
+				Spec:   v1alpha1.ChaosResultSpec{EngineName: "engine", ExperimentName: experiment},
+				Status: v1alpha1.ChaosResultStatus{ExperimentStatus: mock.result},
+			}
+			if isChanged := mirrorExperimentStatus(engine, result); isChanged != mock.isChanged {
+				t.Fatalf("Test %q failed: expected changed to be %v, received %v", name, mock.isChanged, isChanged)
+			}
+			for i := range engine.Status.Experiments {
+				engine.Status.Experiments[i].LastUpdateTime = metav1.Time{}
+			}
+			if !reflect.DeepEqual(engine.Status.Experiments, mock.expected) {
+				t.Fatalf("Test %q failed: expected the experiment statuses %+v, received %+v", name, mock.expected, engine.Status.Experiments)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	litmuschaosv1alpha1 "github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/chaos-operator/pkg/config"
+	"github.com/litmuschaos/chaos-operator/pkg/retention"
+	"github.com/litmuschaos/chaos-operator/pkg/sharding"
+	chaosTypes "github.com/litmuschaos/chaos-operator/pkg/types"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// defaultGarbageCollectionInterval is the interval between two chaosresult garbage collection runs
+const defaultGarbageCollectionInterval = 10 * time.Minute
+
+// ChaosResultGarbageCollector periodically prunes the chaosresults as per the retention policy,
+// archiving them before deletion if an archiver is configured
+type ChaosResultGarbageCollector struct {
+	client.Client
+	// Policy is the retention policy of the chaosresults
+	Policy retention.Policy
+	// Archiver archives the chaosresults before they are deleted, it is optional
+	Archiver retention.Archiver
+	// Interval between two garbage collection runs
+	Interval time.Duration
+	// Shards restricts the garbage collection to the namespaces of the shards owned by this replica, it is optional
+	Shards *sharding.Sharder
+	// Config holds the effective operator config, its retention policy replaces Policy if it is set
+	Config *config.Store
+}
+
+//+kubebuilder:rbac:groups=litmuschaos.io,resources=chaosresults,verbs=get;list;watch;delete
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;create;update
+
+// Start runs the garbage collection till the context is cancelled
+func (gc *ChaosResultGarbageCollector) Start(ctx context.Context) error {
+	interval := gc.Interval
+	if interval <= 0 {
+		interval = defaultGarbageCollectionInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := gc.collect(ctx); err != nil {
+			chaosTypes.Log.Error(err, "unable to garbage collect chaosresults")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection makes the garbage collection run only on the leader,
+// the replicas run it for their own shards if the sharding is enabled, as the leader election is disabled then
+func (gc *ChaosResultGarbageCollector) NeedLeaderElection() bool {
+	return true
+}
+
+// collect archives and deletes the chaosresults which are prunable as per the retention policy
+func (gc *ChaosResultGarbageCollector) collect(ctx context.Context) error {
+	policy := gc.policy()
+	if !policy.IsEnabled() {
+		return nil
+	}
+	chaosresultList := &litmuschaosv1alpha1.ChaosResultList{}
+	if err := gc.Client.List(ctx, chaosresultList); err != nil {
+		return err
+	}
+
+	for _, result := range policy.Prunable(chaosresultList.Items, time.Now()) {
+		result := result
+		if owned, err := gc.Shards.Owns(ctx, result.Namespace); err != nil || !owned {
+			continue
+		}
+		if gc.Archiver != nil {
+			if err := gc.Archiver.Archive(ctx, &result); err != nil {
+				// retain the chaosresult, it is retried in the next run
+				chaosTypes.Log.Error(err, "unable to archive chaosresult", "chaosresult", result.Name, "namespace", result.Namespace)
+				continue
+			}
+		}
+		if err := gc.Client.Delete(ctx, &result, client.Preconditions{UID: &result.UID}); err != nil && !k8serrors.IsNotFound(err) {
+			chaosTypes.Log.Error(err, "unable to delete chaosresult", "chaosresult", result.Name, "namespace", result.Namespace)
+			continue
+		}
+		chaosTypes.Log.Info("pruned chaosresult as per the retention policy", "chaosresult", result.Name, "namespace", result.Namespace)
+	}
+	return nil
+}
+
+// policy returns the retention policy of the operator config if it is set, so that its reloads take effect
+func (gc *ChaosResultGarbageCollector) policy() retention.Policy {
+	if gc.Config != nil {
+		return gc.Config.Get().ChaosResultRetention.Policy()
+	}
+	return gc.Policy
+}
+
+// SetupWithManager adds the garbage collector to the Manager.
+func (gc *ChaosResultGarbageCollector) SetupWithManager(mgr ctrl.Manager) error {
+	return mgr.Add(gc)
+}
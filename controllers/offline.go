@@ -0,0 +1,80 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"strings"
+
+	litmuschaosv1alpha1 "github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/chaos-operator/pkg/config"
+	chaosTypes "github.com/litmuschaos/chaos-operator/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// EvaluateEngine derives the targets and the experiments of the chaosengine with the checks of the
+// reconcile, without a cluster. The returned error is the one on which the reconcile stops the chaosengine
+func EvaluateEngine(instance *litmuschaosv1alpha1.ChaosEngine, operatorConfig *config.OperatorConfig) (*chaosTypes.EngineInfo, error) {
+	r := &ChaosEngineReconciler{Config: config.NewStore(operatorConfig)}
+	engine := &chaosTypes.EngineInfo{
+		Instance:  instance.DeepCopy(),
+		AppInfo:   instance.Spec.Appinfo,
+		Selectors: instance.Spec.Selectors,
+	}
+	if err := r.setExperimentDetails(engine); err != nil {
+		return engine, err
+	}
+	if len(engine.AppExperiments) == 0 {
+		return engine, errEmptyExperimentList
+	}
+	return engine, nil
+}
+
+// RenderRunnerPod returns the runner pod which the reconcile creates for the evaluated chaosengine
+func RenderRunnerPod(engine *chaosTypes.EngineInfo, operatorConfig *config.OperatorConfig, scheme *runtime.Scheme) (*corev1.Pod, error) {
+	r := &ChaosEngineReconciler{Scheme: scheme, Config: config.NewStore(operatorConfig)}
+	return r.newGoRunnerPodForCR(engine)
+}
+
+// Target is a decoded target of a chaosengine, as passed to the runner in the TARGETS env
+type Target struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	// Filter contains the names or the labels selecting the targets
+	Filter string `json:"filter"`
+}
+
+// DecodeTargets decodes the TARGETS env of the runner, formatted as kind:namespace:[filter] joined by ;
+// The entries which are not of that format are skipped
+func DecodeTargets(targets string) []Target {
+	decoded := []Target{}
+	if targets == "" {
+		return decoded
+	}
+	for _, target := range strings.Split(targets, ";") {
+		parts := strings.SplitN(target, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		decoded = append(decoded, Target{
+			Kind:      parts[0],
+			Namespace: parts[1],
+			Filter:    strings.TrimSuffix(strings.TrimPrefix(parts[2], "["), "]"),
+		})
+	}
+	return decoded
+}
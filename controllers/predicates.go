@@ -0,0 +1,129 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+
+	litmuschaosv1alpha1 "github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/chaos-operator/pkg/config"
+	chaosTypes "github.com/litmuschaos/chaos-operator/pkg/types"
+	"golang.org/x/time/rate"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/ratelimiter"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// engineUIDField indexes the chaosengines by their uid, which the jobs and the chaosresults carry in the chaosUID label
+const engineUIDField = "metadata.uid"
+
+// indexEngineUID returns the index value of the engineUIDField
+func indexEngineUID(obj client.Object) []string {
+	return []string{string(obj.GetUID())}
+}
+
+// newRateLimiter returns the rate limiter of the requeues, delaying them by the larger of the
+// per-item exponential backoff and the overall token bucket
+func newRateLimiter(rateLimiterConfig config.RateLimiterConfig) ratelimiter.RateLimiter {
+	return workqueue.NewMaxOfRateLimiter(
+		workqueue.NewItemExponentialFailureRateLimiter(rateLimiterConfig.BaseDelay.Duration, rateLimiterConfig.MaxDelay.Duration),
+		&workqueue.BucketRateLimiter{Limiter: rate.NewLimiter(rate.Limit(rateLimiterConfig.QPS), rateLimiterConfig.Burst)},
+	)
+}
+
+// engineChanged skips the updates of the chaosengines which change neither the spec, the engine status
+// nor the deletion, e.g. the resyncs and the updates of the experiment statuses made by the operator
+var engineChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldEngine, oldOk := e.ObjectOld.(*litmuschaosv1alpha1.ChaosEngine)
+		newEngine, newOk := e.ObjectNew.(*litmuschaosv1alpha1.ChaosEngine)
+		if !oldOk || !newOk {
+			return true
+		}
+		return oldEngine.Generation != newEngine.Generation ||
+			oldEngine.Status.EngineStatus != newEngine.Status.EngineStatus ||
+			!oldEngine.DeletionTimestamp.Equal(newEngine.DeletionTimestamp)
+	},
+}
+
+// podStatusChanged skips the updates of the runner pods which change neither the phase nor the container statuses
+var podStatusChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldPod, oldOk := e.ObjectOld.(*corev1.Pod)
+		newPod, newOk := e.ObjectNew.(*corev1.Pod)
+		if !oldOk || !newOk {
+			return true
+		}
+		return oldPod.Status.Phase != newPod.Status.Phase ||
+			!reflect.DeepEqual(oldPod.Status.ContainerStatuses, newPod.Status.ContainerStatuses)
+	},
+}
+
+// jobStatusChanged skips the updates of the experiment jobs which do not change their status
+var jobStatusChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldJob, oldOk := e.ObjectOld.(*batchv1.Job)
+		newJob, newOk := e.ObjectNew.(*batchv1.Job)
+		if !oldOk || !newOk {
+			return true
+		}
+		return !reflect.DeepEqual(oldJob.Status, newJob.Status)
+	},
+}
+
+// resultStatusChanged skips the updates of the chaosresults which change neither the experiment
+// status nor the annotations, which carry the chaos status of the targets
+var resultStatusChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldResult, oldOk := e.ObjectOld.(*litmuschaosv1alpha1.ChaosResult)
+		newResult, newOk := e.ObjectNew.(*litmuschaosv1alpha1.ChaosResult)
+		if !oldOk || !newOk {
+			return true
+		}
+		return !equality.Semantic.DeepEqual(oldResult.Status.ExperimentStatus, newResult.Status.ExperimentStatus) ||
+			!reflect.DeepEqual(oldResult.Annotations, newResult.Annotations)
+	},
+}
+
+// hasChaosUID selects the objects created for a chaosengine run
+var hasChaosUID = predicate.NewPredicateFuncs(func(obj client.Object) bool {
+	return obj.GetLabels()["chaosUID"] != ""
+})
+
+// enqueueEngineOfChaosUID maps the jobs and the chaosresults to the chaosengine of their chaosUID label
+func (r *ChaosEngineReconciler) enqueueEngineOfChaosUID(ctx context.Context, obj client.Object) []reconcile.Request {
+	engines := &litmuschaosv1alpha1.ChaosEngineList{}
+	if err := r.Client.List(ctx, engines, client.InNamespace(obj.GetNamespace()),
+		client.MatchingFields{engineUIDField: obj.GetLabels()["chaosUID"]}); err != nil {
+		chaosTypes.Log.Error(err, "unable to list the chaosengine of the chaosUID", "namespace", obj.GetNamespace(), "chaosUID", obj.GetLabels()["chaosUID"])
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(engines.Items))
+	for _, engine := range engines.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: engine.Namespace, Name: engine.Name}})
+	}
+	return requests
+}
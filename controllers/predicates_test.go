@@ -0,0 +1,192 @@
+/*
+Copyright 2019 LitmusChaos Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+   http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+func TestUpdatePredicates(t *testing.T) {
+	engine := &v1alpha1.ChaosEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "engine", Generation: 1, ResourceVersion: "1"},
+		Status:     v1alpha1.ChaosEngineStatus{EngineStatus: v1alpha1.EngineStatusInitialized},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "engine-runner"},
+		Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+
+	tests := map[string]struct {
+		predicate predicate.Funcs
+		old, new  client.Object
+		expected  bool
+	}{
+		"Test Positive-1": {
+			predicate: engineChanged,
+			old:       engine,
+			new: func() client.Object {
+				updated := engine.DeepCopy()
+				updated.Generation = 2
+				return updated
+			}(),
+			expected: true,
+		},
+		"Test Positive-2": {
+			predicate: engineChanged,
+			old:       engine,
+			new: func() client.Object {
+				updated := engine.DeepCopy()
+				updated.Status.EngineStatus = v1alpha1.EngineStatusCompleted
+				return updated
+			}(),
+			expected: true,
+		},
+		"Test Positive-3": {
+			predicate: engineChanged,
+			old:       engine,
+			new: func() client.Object {
+				updated := engine.DeepCopy()
+				updated.DeletionTimestamp = &metav1.Time{}
+				return updated
+			}(),
+			expected: true,
+		},
+		"Test Positive-4": {
+			predicate: podStatusChanged,
+			old:       pod,
+			new: func() client.Object {
+				updated := pod.DeepCopy()
+				updated.Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "chaos-runner", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}}}}
+				return updated
+			}(),
+			expected: true,
+		},
+		"Test Positive-5": {
+			predicate: jobStatusChanged,
+			old:       &batchv1.Job{},
+			new:       &batchv1.Job{Status: batchv1.JobStatus{Succeeded: 1}},
+			expected:  true,
+		},
+		"Test Positive-6": {
+			predicate: resultStatusChanged,
+			old:       &v1alpha1.ChaosResult{},
+			new:       &v1alpha1.ChaosResult{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"pod/nginx": "injected"}}},
+			expected:  true,
+		},
+		"Test Positive-7": {
+			predicate: resultStatusChanged,
+			old:       &v1alpha1.ChaosResult{Status: v1alpha1.ChaosResultStatus{ExperimentStatus: v1alpha1.TestStatus{ErrorOutput: &v1alpha1.ErrorOutput{ErrorCode: "STATUS_CHECKS_ERROR"}}}},
+			new:       &v1alpha1.ChaosResult{Status: v1alpha1.ChaosResultStatus{ExperimentStatus: v1alpha1.TestStatus{ErrorOutput: &v1alpha1.ErrorOutput{ErrorCode: "CHAOS_INJECT_ERROR"}}}},
+			expected:  true,
+		},
+		"Test Negative-1": {
+			predicate: engineChanged,
+			old:       engine,
+			new: func() client.Object {
+				updated := engine.DeepCopy()
+				updated.ResourceVersion = "2"
+				updated.Status.Experiments = []v1alpha1.ExperimentStatuses{{Name: "pod-delete"}}
+				return updated
+			}(),
+		},
+		"Test Negative-2": {
+			predicate: podStatusChanged,
+			old:       pod,
+			new: func() client.Object {
+				updated := pod.DeepCopy()
+				updated.Annotations = map[string]string{"updated": "true"}
+				return updated
+			}(),
+		},
+		"Test Negative-3": {
+			predicate: jobStatusChanged,
+			old:       &batchv1.Job{},
+			new:       &batchv1.Job{ObjectMeta: metav1.ObjectMeta{ResourceVersion: "2"}},
+		},
+		"Test Negative-4": {
+			predicate: resultStatusChanged,
+			old:       &v1alpha1.ChaosResult{},
+			new:       &v1alpha1.ChaosResult{ObjectMeta: metav1.ObjectMeta{ResourceVersion: "2"}},
+		},
+		"Test Negative-5": {
+			predicate: resultStatusChanged,
+			old:       &v1alpha1.ChaosResult{Status: v1alpha1.ChaosResultStatus{ExperimentStatus: v1alpha1.TestStatus{ErrorOutput: &v1alpha1.ErrorOutput{ErrorCode: "STATUS_CHECKS_ERROR"}}}},
+			new:       &v1alpha1.ChaosResult{ObjectMeta: metav1.ObjectMeta{ResourceVersion: "2"}, Status: v1alpha1.ChaosResultStatus{ExperimentStatus: v1alpha1.TestStatus{ErrorOutput: &v1alpha1.ErrorOutput{ErrorCode: "STATUS_CHECKS_ERROR"}}}},
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			if received := mock.predicate.Update(event.UpdateEvent{ObjectOld: mock.old, ObjectNew: mock.new}); received != mock.expected {
+				t.Fatalf("Test %q failed: expected %v, received %v", name, mock.expected, received)
+			}
+		})
+	}
+}
+
+func TestEnqueueEngineOfChaosUID(t *testing.T) {
+	s := scheme.Scheme
+	s.AddKnownTypes(v1alpha1.SchemeGroupVersion, &v1alpha1.ChaosEngine{}, &v1alpha1.ChaosEngineList{})
+	r := &ChaosEngineReconciler{
+		Client: fake.NewClientBuilder().WithScheme(s).
+			WithIndex(&v1alpha1.ChaosEngine{}, engineUIDField, indexEngineUID).
+			WithObjects(
+				&v1alpha1.ChaosEngine{ObjectMeta: metav1.ObjectMeta{Name: "engine-1", Namespace: "default", UID: "uid-1"}},
+				&v1alpha1.ChaosEngine{ObjectMeta: metav1.ObjectMeta{Name: "engine-2", Namespace: "default", UID: "uid-2"}},
+				&v1alpha1.ChaosEngine{ObjectMeta: metav1.ObjectMeta{Name: "engine-1", Namespace: "litmus", UID: "uid-3"}},
+			).Build(),
+		Scheme: s,
+	}
+
+	tests := map[string]struct {
+		obj      client.Object
+		expected string
+	}{
+		"Test Positive-1": {
+			obj:      &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "pod-delete-abcd", Namespace: "default", Labels: map[string]string{"chaosUID": "uid-2"}}},
+			expected: "default/engine-2",
+		},
+		"Test Positive-2": {
+			obj:      &v1alpha1.ChaosResult{ObjectMeta: metav1.ObjectMeta{Name: "engine-1-pod-delete", Namespace: "litmus", Labels: map[string]string{"chaosUID": "uid-3"}}},
+			expected: "litmus/engine-1",
+		},
+		"Test Negative-1": {
+			obj: &batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: "pod-delete-abcd", Namespace: "litmus", Labels: map[string]string{"chaosUID": "uid-1"}}},
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			requests := r.enqueueEngineOfChaosUID(context.TODO(), mock.obj)
+			if mock.expected == "" {
+				if len(requests) != 0 {
+					t.Fatalf("Test %q failed: expected no requests, received %v", name, requests)
+				}
+				return
+			}
+			if len(requests) != 1 || requests[0].String() != mock.expected {
+				t.Fatalf("Test %q failed: expected the request of %v, received %v", name, mock.expected, requests)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"errors"
+	"flag"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/chaos-operator/pkg/analytics"
+	"github.com/litmuschaos/chaos-operator/pkg/config"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// update rewrites the golden files with the rendered output, run as: go test ./controllers -run Golden -update
+var update = flag.Bool("update", false, "update the golden files of the tests")
+
+// runnerPodTestdata contains a directory per case, with the engine.yaml input, the optional config.yaml
+// of the operator and the pod.golden.yaml runner pod
+const runnerPodTestdata = "testdata/runner-pod"
+
+func TestNewGoRunnerPodForCRGolden(t *testing.T) {
+	clientUUID := analytics.ClientUUID
+	analytics.ClientUUID = "12345678-9012-3456-7890-123456789012"
+	t.Cleanup(func() { analytics.ClientUUID = clientUUID })
+
+	s := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(s); err != nil {
+		t.Fatalf("unable to build the scheme, due to error: %v", err)
+	}
+
+	cases, err := os.ReadDir(runnerPodTestdata)
+	if err != nil {
+		t.Fatalf("unable to read the testdata, due to error: %v", err)
+	}
+	for _, c := range cases {
+		if !c.IsDir() {
+			continue
+		}
+		name := c.Name()
+		dir := filepath.Join(runnerPodTestdata, name)
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile(filepath.Join(dir, "engine.yaml"))
+			if err != nil {
+				t.Fatalf("Test %q failed: unable to read the chaosengine, due to error: %v", name, err)
+			}
+			instance := &v1alpha1.ChaosEngine{}
+			if err := yaml.UnmarshalStrict(data, instance); err != nil {
+				t.Fatalf("Test %q failed: unable to decode the chaosengine, due to error: %v", name, err)
+			}
+
+			operatorConfig := config.Default()
+			if _, err := os.Stat(filepath.Join(dir, "config.yaml")); err == nil {
+				if operatorConfig, err = config.Load(filepath.Join(dir, "config.yaml")); err != nil {
+					t.Fatalf("Test %q failed: unable to load the operator config, due to error: %v", name, err)
+				}
+			}
+
+			engine, err := EvaluateEngine(instance, operatorConfig)
+			if err != nil {
+				t.Fatalf("Test %q failed: unable to evaluate the chaosengine, due to error: %v", name, err)
+			}
+			r := &ChaosEngineReconciler{Scheme: s, Config: config.NewStore(operatorConfig)}
+			runnerPod, err := r.newGoRunnerPodForCR(engine)
+			if err != nil {
+				t.Fatalf("Test %q failed: unable to render the runner pod, due to error: %v", name, err)
+			}
+			rendered, err := yaml.Marshal(runnerPod)
+			if err != nil {
+				t.Fatalf("Test %q failed: unable to encode the runner pod, due to error: %v", name, err)
+			}
+
+			golden := filepath.Join(dir, "pod.golden.yaml")
+			if *update {
+				if err := os.WriteFile(golden, rendered, 0o644); err != nil {
+					t.Fatalf("Test %q failed: unable to update the golden file, due to error: %v", name, err)
+				}
+				return
+			}
+			expected, err := os.ReadFile(golden)
+			if errors.Is(err, fs.ErrNotExist) {
+				t.Fatalf("Test %q failed: golden file %v is missing, run the test with -update to create it", name, golden)
+			} else if err != nil {
+				t.Fatalf("Test %q failed: unable to read the golden file, due to error: %v", name, err)
+			}
+			if diff := cmp.Diff(string(expected), string(rendered)); diff != "" {
+				t.Fatalf("Test %q failed: the runner pod differs from %v, run the test with -update if the change is intended (-golden +rendered):\n%v", name, golden, diff)
+			}
+		})
+	}
+}
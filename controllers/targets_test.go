@@ -0,0 +1,233 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	chaosTypes "github.com/litmuschaos/chaos-operator/pkg/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// separators removes the separators of the TARGETS env from the kind and the namespace of a target,
+// and the separator of the entries from its filter, as none of them can contain those in a valid chaosengine
+func separators(target Target) Target {
+	return Target{
+		Kind:      strings.NewReplacer(":", "", ";", "").Replace(target.Kind),
+		Namespace: strings.NewReplacer(":", "", ";", "").Replace(target.Namespace),
+		Filter:    strings.ReplaceAll(target.Filter, ";", ""),
+	}
+}
+
+// engineOfTargets returns a chaosengine which selects the targets as workloads of their names
+func engineOfTargets(targets []Target) *chaosTypes.EngineInfo {
+	workloads := []v1alpha1.Workload{}
+	for _, target := range targets {
+		workloads = append(workloads, v1alpha1.Workload{Kind: v1alpha1.WorkloadKind(target.Kind), Namespace: target.Namespace, Names: target.Filter})
+	}
+	return &chaosTypes.EngineInfo{
+		Instance:  &v1alpha1.ChaosEngine{ObjectMeta: metav1.ObjectMeta{Name: "nginx-chaos", Namespace: "default"}},
+		Selectors: &v1alpha1.Selector{Workloads: workloads},
+	}
+}
+
+func TestTargetsRoundTrip(t *testing.T) {
+	property := func(targets []Target) bool {
+		for i := range targets {
+			targets[i] = separators(targets[i])
+		}
+		decoded := DecodeTargets(getTargets(engineOfTargets(targets)))
+		return len(decoded) == len(targets) && (len(targets) == 0 || reflect.DeepEqual(decoded, targets))
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Fatalf("expected the decoded targets to match the encoded targets: %v", err)
+	}
+}
+
+func TestDecodeTargetsIdempotent(t *testing.T) {
+	property := func(targets string) bool {
+		decoded := DecodeTargets(targets)
+		return reflect.DeepEqual(DecodeTargets(getTargets(engineOfTargets(decoded))), decoded)
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Fatalf("expected the re-encoded targets to decode to the same targets: %v", err)
+	}
+}
+
+func TestDecodeTargets(t *testing.T) {
+	tests := map[string]struct {
+		targets  string
+		expected []Target
+	}{
+		"Test Positive-1": {
+			targets:  "",
+			expected: []Target{},
+		},
+		"Test Positive-2": {
+			targets: "deployment:default:[nginx,busybox];pod:litmus:[app=nginx,tier=frontend]",
+			expected: []Target{
+				{Kind: "deployment", Namespace: "default", Filter: "nginx,busybox"},
+				{Kind: "pod", Namespace: "litmus", Filter: "app=nginx,tier=frontend"},
+			},
+		},
+		"Test Negative-1": {
+			targets:  "deployment",
+			expected: []Target{},
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			if decoded := DecodeTargets(mock.targets); !reflect.DeepEqual(decoded, mock.expected) {
+				t.Fatalf("Test %q failed: expected %+v, received %+v", name, mock.expected, decoded)
+			}
+		})
+	}
+}
+
+func TestGetChaosStatus(t *testing.T) {
+	tests := map[string]struct {
+		annotations map[string]string
+		history     *v1alpha1.HistoryDetails
+		targets     []v1alpha1.TargetDetails
+		remaining   map[string]string
+	}{
+		"Test Positive-1": {
+			annotations: map[string]string{"deployment/nginx": "injected", "litmuschaos.io/history-baseline": "1,0,0"},
+			history:     &v1alpha1.HistoryDetails{},
+			targets:     []v1alpha1.TargetDetails{{Name: "nginx", Kind: "deployment", ChaosStatus: "injected"}},
+			remaining:   map[string]string{"litmuschaos.io/history-baseline": "1,0,0"},
+		},
+		"Test Positive-2": {
+			annotations: map[string]string{"deployment/nginx": "reverted"},
+			history:     &v1alpha1.HistoryDetails{Targets: []v1alpha1.TargetDetails{{Name: "nginx", Kind: "deployment", ChaosStatus: "injected"}}},
+			targets:     []v1alpha1.TargetDetails{{Name: "nginx", Kind: "deployment", ChaosStatus: "reverted"}},
+			remaining:   map[string]string{},
+		},
+		"Test Positive-3": {
+			annotations: map[string]string{"pod/nginx-7d9f": "targeted"},
+			targets:     []v1alpha1.TargetDetails{{Name: "nginx-7d9f", Kind: "pod", ChaosStatus: "targeted"}},
+			remaining:   map[string]string{},
+		},
+		"Test Negative-1": {
+			annotations: map[string]string{"nginx": "injected"},
+			history:     &v1alpha1.HistoryDetails{},
+			remaining:   map[string]string{"nginx": "injected"},
+		},
+		"Test Negative-2": {
+			annotations: map[string]string{"deployment/": "injected", "/nginx": "reverted"},
+			remaining:   map[string]string{"deployment/": "injected", "/nginx": "reverted"},
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := v1alpha1.ChaosResult{
+				ObjectMeta: metav1.ObjectMeta{Annotations: mock.annotations},
+				Status:     v1alpha1.ChaosResultStatus{History: mock.history},
+			}
+			targets, annotations := getChaosStatus(result)
+			if len(targets) != len(mock.targets) || (len(targets) != 0 && !reflect.DeepEqual(targets, mock.targets)) {
+				t.Fatalf("Test %q failed: expected targets %+v, received %+v", name, mock.targets, targets)
+			}
+			if !reflect.DeepEqual(annotations, mock.remaining) {
+				t.Fatalf("Test %q failed: expected annotations %v, received %v", name, mock.remaining, annotations)
+			}
+		})
+	}
+}
+
+func FuzzGetTargets(f *testing.F) {
+	f.Add("deployment", "default", "nginx,busybox", "", "", "", false)
+	f.Add("statefulset", "database", "", "app=mysql", "", "", false)
+	f.Add("", "", "", "", "default", "app=nginx", false)
+	f.Add("", "", "nginx", "", "", "", true)
+	f.Fuzz(func(t *testing.T, kind, namespace, names, labels, appns, applabel string, isPod bool) {
+		engine := &chaosTypes.EngineInfo{
+			Instance: &v1alpha1.ChaosEngine{ObjectMeta: metav1.ObjectMeta{Name: "nginx-chaos", Namespace: "default"}},
+		}
+		switch {
+		case isPod:
+			engine.Selectors = &v1alpha1.Selector{Pods: []v1alpha1.Pod{{Namespace: namespace, Names: names}}}
+		case kind != "":
+			engine.Selectors = &v1alpha1.Selector{Workloads: []v1alpha1.Workload{{Kind: v1alpha1.WorkloadKind(kind), Namespace: namespace, Names: names, Labels: labels}}}
+		default:
+			engine.AppInfo = v1alpha1.ApplicationParams{Appns: appns, Applabel: applabel, AppKind: kind}
+		}
+
+		targets := getTargets(engine)
+		decoded := DecodeTargets(targets)
+		// the separators are not valid in any of the fields of a chaosengine, the targets are not decodable then
+		if targets == "" || strings.ContainsAny(kind+namespace+names+labels+appns+applabel, ":;") {
+			return
+		}
+		if len(decoded) != 1 {
+			t.Fatalf("expected a single target in %q, received %+v", targets, decoded)
+		}
+		if engine.Selectors != nil && decoded[0].Namespace != namespace {
+			t.Fatalf("expected the namespace %q, received %+v", namespace, decoded[0])
+		}
+	})
+}
+
+func FuzzGetChaosStatus(f *testing.F) {
+	f.Add("deployment/nginx", "injected", "nginx", true)
+	f.Add("nginx", "reverted", "", false)
+	f.Add("deployment/", "Targeted", "", true)
+	f.Add("a/b/c", "injected", "b/c", false)
+	f.Fuzz(func(t *testing.T, key, value, recorded string, hasHistory bool) {
+		result := v1alpha1.ChaosResult{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{key: value}}}
+		if hasHistory {
+			result.Status.History = &v1alpha1.HistoryDetails{Targets: []v1alpha1.TargetDetails{{Name: recorded, Kind: "deployment", ChaosStatus: "injected"}}}
+		}
+
+		targets, annotations := getChaosStatus(result)
+
+		// only the chaos status annotations keyed as <kind>/<name> are moved into the targets
+		kind, name, found := strings.Cut(key, "/")
+		kind, name = strings.TrimSpace(kind), strings.TrimSpace(name)
+		isStatus := strings.EqualFold(value, "injected") || strings.EqualFold(value, "reverted") || strings.EqualFold(value, "targeted")
+		isMoved := isStatus && found && kind != "" && name != ""
+
+		if _, isKept := annotations[key]; isKept == isMoved {
+			t.Fatalf("expected the annotation %q: %q to be moved %v, received annotations %v", key, value, isMoved, annotations)
+		}
+		isRecorded := false
+		for _, target := range targets {
+			if target.Name == name && target.ChaosStatus == value {
+				isRecorded = true
+			}
+		}
+		if isMoved && !isRecorded {
+			t.Fatalf("expected the annotation %q: %q to be recorded in the targets, received %+v", key, value, targets)
+		}
+	})
+}
+
+func FuzzDecodeTargets(f *testing.F) {
+	f.Add("deployment:default:[nginx,busybox];pod:litmus:[app=nginx]")
+	f.Add("KIND:default:[app=nginx]")
+	f.Add("deployment:default:[[a]]];;:")
+	f.Fuzz(func(t *testing.T, targets string) {
+		decoded := DecodeTargets(targets)
+		if reencoded := DecodeTargets(getTargets(engineOfTargets(decoded))); !reflect.DeepEqual(reencoded, decoded) {
+			t.Fatalf("expected the re-encoded targets of %q to decode to %+v, received %+v", targets, decoded, reencoded)
+		}
+	})
+}
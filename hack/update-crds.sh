@@ -0,0 +1,57 @@
+#!/bin/bash
+#
+#Copyright 2019 LitmusChaos Authors
+#
+#Licensed under the Apache License, Version 2.0 (the "License");
+#you may not use this file except in compliance with the License.
+#You may obtain a copy of the License at
+#
+#   http://www.apache.org/licenses/LICENSE-2.0
+#
+#Unless required by applicable law or agreed to in writing, software
+#distributed under the License is distributed on an "AS IS" BASIS,
+#WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+#See the License for the specific language governing permissions and
+#limitations under the License.
+#
+
+# Generates the CRDs from the api types into deploy/crds and bundles them into deploy/chaos_crds.yaml,
+# along with the webhook configurations at the end of deploy/webhook.yaml
+# usage: CONTROLLER_GEN=bin/controller-gen hack/update-crds.sh
+
+set -e
+
+CONTROLLER_GEN=${CONTROLLER_GEN:-controller-gen}
+CRD_DIR=deploy/crds
+
+rm -f ${CRD_DIR}/*.yaml
+${CONTROLLER_GEN} crd paths="./api/..." output:crd:dir=${CRD_DIR}
+
+cat ${CRD_DIR}/litmuschaos.io_chaosengines.yaml ${CRD_DIR}/litmuschaos.io_chaosexperiments.yaml \
+  ${CRD_DIR}/litmuschaos.io_chaosresults.yaml > deploy/chaos_crds.yaml
+
+# the mutating webhook of the audit trail is generated from its marker, with the CA injected by cert-manager
+WEBHOOK_MARKER="# the webhook configurations below are generated from the kubebuilder markers by the manifests make target"
+sed -i "/^${WEBHOOK_MARKER}\$/,\$d" deploy/webhook.yaml
+echo "${WEBHOOK_MARKER}" >> deploy/webhook.yaml
+${CONTROLLER_GEN} webhook paths="./pkg/audit/..." output:webhook:stdout | sed \
+  -e 's#^  name: mutating-webhook-configuration$#  name: chaos-operator-audit\n  annotations:\n    cert-manager.io/inject-ca-from: litmus/chaos-operator-serving-cert#' \
+  -e 's#^      name: webhook-service$#      name: chaos-operator-webhook-service#' \
+  -e 's#^      namespace: system$#      namespace: litmus#' >> deploy/webhook.yaml
+
+# v1beta1 of the chaosengines is not served by the default CRD, as it needs the conversion webhook of the
+# chaos-operator. The CRD serving it through the webhook is appended to the webhook configurations
+sed -e 's#^\(    controller-gen.kubebuilder.io/version: .*\)$#\1\n    cert-manager.io/inject-ca-from: litmus/chaos-operator-serving-cert#' \
+  -e 's#^    served: false$#    served: true#' ${CRD_DIR}/litmuschaos.io_chaosengines.yaml >> deploy/webhook.yaml
+cat >> deploy/webhook.yaml <<EOF
+  conversion:
+    strategy: Webhook
+    webhook:
+      conversionReviewVersions:
+      - v1
+      clientConfig:
+        service:
+          namespace: litmus
+          name: chaos-operator-webhook-service
+          path: /convert
+EOF
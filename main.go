@@ -17,13 +17,16 @@ limitations under the License.
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"github.com/litmuschaos/chaos-operator/pkg/analytics"
 	"github.com/operator-framework/operator-sdk/pkg/k8sutil"
 	"os"
+	"path/filepath"
 	"runtime"
-	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -33,14 +36,34 @@ import (
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	litmuschaosiov1alpha1 "github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	litmuschaosiov1beta1 "github.com/litmuschaos/chaos-operator/api/litmuschaos/v1beta1"
 	"github.com/litmuschaos/chaos-operator/controllers"
+	"github.com/litmuschaos/chaos-operator/pkg/audit"
+	"github.com/litmuschaos/chaos-operator/pkg/auth"
+	"github.com/litmuschaos/chaos-operator/pkg/cloudevents"
+	"github.com/litmuschaos/chaos-operator/pkg/config"
+	"github.com/litmuschaos/chaos-operator/pkg/health"
+	"github.com/litmuschaos/chaos-operator/pkg/namespaces"
+	"github.com/litmuschaos/chaos-operator/pkg/notifier"
+	"github.com/litmuschaos/chaos-operator/pkg/report"
+	"github.com/litmuschaos/chaos-operator/pkg/retention"
+	"github.com/litmuschaos/chaos-operator/pkg/sharding"
+	"github.com/litmuschaos/chaos-operator/pkg/timeout"
+	"github.com/litmuschaos/chaos-operator/pkg/tracing"
 	//+kubebuilder:scaffold:imports
 )
 
+// leaderElectionID is the name of the lease of the leader election
+const leaderElectionID = "chaos-operator.lock"
+
 var (
 	scheme   = schemeruntime.NewScheme()
 	setupLog = ctrl.Log.WithName("setup")
@@ -49,6 +72,7 @@ var (
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 	utilruntime.Must(litmuschaosiov1alpha1.AddToScheme(scheme))
+	utilruntime.Must(litmuschaosiov1beta1.AddToScheme(scheme))
 	//+kubebuilder:scaffold:scheme
 }
 
@@ -56,11 +80,92 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var resultRetention retention.Policy
+	var resultArchive, resultArchiveLocation string
+	var resultGCInterval time.Duration
+	var traceExporter, traceEndpoint string
+	var cloudEventsSink, cloudEventsMode string
+	var enableAuditWebhook, enableConversionWebhook bool
+	var auditOperatorUsername, auditLogPath string
+	var auditLogMaxSize int64
+	var auditLogMaxBackups int
+	var watchNamespaceSelector string
+	var watchNamespaceResync time.Duration
+	var shards int
+	var shardBy string
+	var shardLeaseDuration time.Duration
+	var configFile string
+	var maxConcurrentReconciles int
+	var webhookCertDir string
+	var analyticsSink, analyticsLocation string
+	var analyticsInterval time.Duration
+	var analyticsShareWithRunner bool
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.DurationVar(&resultRetention.MaxAge, "chaosresult-max-age", 0,
+		"The duration for which a finished chaosresult is retained since its last update. Zero retains them forever.")
+	flag.IntVar(&resultRetention.MaxCountPerExperiment, "chaosresult-max-count-per-experiment", 0,
+		"The maximum number of finished chaosresults retained per experiment in a namespace. Zero disables the limit.")
+	flag.IntVar(&resultRetention.MaxCountPerEngine, "chaosresult-max-count-per-engine", 0,
+		"The maximum number of finished chaosresults retained per chaosengine in a namespace. Zero disables the limit.")
+	flag.IntVar(&resultRetention.KeepLastFailures, "chaosresult-keep-last-failures", 0,
+		"The number of latest failed chaosresults per experiment which are always retained.")
+	flag.StringVar(&resultArchive, "chaosresult-archive", retention.ArchiveNone,
+		"The archive for the pruned chaosresults, supported values: none, configmap, file.")
+	flag.StringVar(&resultArchiveLocation, "chaosresult-archive-location", "",
+		"The name of the archive configmap, or the archive directory for the file archive.")
+	flag.DurationVar(&resultGCInterval, "chaosresult-gc-interval", 10*time.Minute,
+		"The interval between two chaosresult garbage collection runs.")
+	flag.StringVar(&traceExporter, "trace-exporter", tracing.ExporterNone,
+		"The exporter of the reconcile traces, supported values: none, otlp, stdout, file.")
+	flag.StringVar(&traceEndpoint, "trace-endpoint", "",
+		"The OTLP/HTTP endpoint for the otlp exporter, or the file path for the file exporter.")
+	flag.StringVar(&cloudEventsSink, "cloudevents-sink", "",
+		"The URL of the sink to which the chaos lifecycle cloudevents are published. Empty disables the cloudevents.")
+	flag.StringVar(&cloudEventsMode, "cloudevents-mode", cloudevents.ModeBinary,
+		"The content mode of the published cloudevents, supported values: binary, structured.")
+	flag.BoolVar(&enableConversionWebhook, "enable-conversion-webhook", false,
+		"Enable the conversion webhook of the chaosengines, required to serve the v1beta1 API.")
+	flag.BoolVar(&enableAuditWebhook, "enable-audit-webhook", false,
+		"Enable the mutating webhook, which stamps the user starting, stopping or restarting the chaosengine into its annotations.")
+	flag.StringVar(&auditOperatorUsername, "audit-operator-username", "system:serviceaccount:litmus:litmus",
+		"The username of the operator, whose own updates of the chaosengines are not audited.")
+	flag.StringVar(&auditLogPath, "audit-log-path", "",
+		"The path of the file to which the audit records of the chaosengine runs are appended. Empty disables the audit log.")
+	flag.Int64Var(&auditLogMaxSize, "audit-log-max-size", 100*1024*1024,
+		"The size in bytes after which the audit log is rotated.")
+	flag.IntVar(&auditLogMaxBackups, "audit-log-max-backups", 5,
+		"The number of rotated audit logs to retain.")
+	flag.StringVar(&watchNamespaceSelector, "watch-namespace-selector", "",
+		"The label selector of the namespaces watched along with the ones listed in WATCH_NAMESPACE. "+
+			"The operator restarts to watch the namespaces which start or stop matching it.")
+	flag.DurationVar(&watchNamespaceResync, "watch-namespace-resync-period", time.Minute,
+		"The interval after which the namespaces matching the -watch-namespace-selector are resolved again.")
+	flag.IntVar(&shards, "shards", 0,
+		"The number of shards of the watched namespaces, owned by the operator replicas holding their leases. "+
+			"Zero disables the sharding, it cannot be used along with the leader election.")
+	flag.StringVar(&shardBy, "shard-by", sharding.ModeHash,
+		"The assignment of the namespaces to the shards, supported values: hash, label. "+
+			"The label mode uses the "+sharding.ShardLabel+" label of the namespaces, falling back to the hash of their names.")
+	flag.DurationVar(&shardLeaseDuration, "shard-lease-duration", 15*time.Second,
+		"The duration after which the shards of a lost replica are acquired by the other replicas.")
+	flag.IntVar(&maxConcurrentReconciles, "max-concurrent-reconciles", 1,
+		"The number of chaosengines and chaosresults reconciled in parallel.")
+	flag.StringVar(&webhookCertDir, "webhook-cert-dir", filepath.Join(os.TempDir(), "k8s-webhook-server", "serving-certs"),
+		"The directory containing the tls.crt and tls.key of the webhook server.")
+	flag.StringVar(&analyticsSink, "analytics-sink", analytics.SinkNone,
+		"The sink of the anonymous usage analytics, supported values: none, file, http. The analytics are disabled by default.")
+	flag.StringVar(&analyticsLocation, "analytics-location", "",
+		"The collector endpoint for the http sink, or the file path for the file sink.")
+	flag.DurationVar(&analyticsInterval, "analytics-interval", analytics.DefaultInterval,
+		"The interval between two analytics reports.")
+	flag.BoolVar(&analyticsShareWithRunner, "analytics-share-with-runner", false,
+		"Pass the installation id of the analytics to the chaos-runner as CLIENT_UUID, requires an analytics sink.")
+	flag.StringVar(&configFile, "config", "",
+		"The path of the "+config.Kind+" file, which is reloaded on its changes. The explicitly set args take precedence over it.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -71,27 +176,105 @@ func main() {
 
 	printVersion()
 
-	namespace, err := k8sutil.GetWatchNamespace()
+	// the args which are set explicitly override the config file, on the startup and on every reload.
+	// all the args apply without a config file
+	setFlags := map[string]bool{}
+	flag.Visit(func(f *flag.Flag) { setFlags[f.Name] = true })
+	isSet := func(name string) bool { return configFile == "" || setFlags[name] }
+	overrideConfig := func(operatorConfig *config.OperatorConfig) {
+		if isSet("metrics-bind-address") {
+			operatorConfig.Metrics.BindAddress = metricsAddr
+		}
+		if isSet("health-probe-bind-address") {
+			operatorConfig.Metrics.HealthProbeBindAddress = probeAddr
+		}
+		if isSet("max-concurrent-reconciles") {
+			operatorConfig.Controller.MaxConcurrentReconciles = maxConcurrentReconciles
+		}
+		if isSet("chaosresult-max-age") {
+			operatorConfig.ChaosResultRetention.MaxAge.Duration = resultRetention.MaxAge
+		}
+		if isSet("chaosresult-max-count-per-experiment") {
+			operatorConfig.ChaosResultRetention.MaxCountPerExperiment = resultRetention.MaxCountPerExperiment
+		}
+		if isSet("chaosresult-max-count-per-engine") {
+			operatorConfig.ChaosResultRetention.MaxCountPerEngine = resultRetention.MaxCountPerEngine
+		}
+		if isSet("chaosresult-keep-last-failures") {
+			operatorConfig.ChaosResultRetention.KeepLastFailures = resultRetention.KeepLastFailures
+		}
+		if isSet("chaosresult-gc-interval") {
+			operatorConfig.ChaosResultRetention.GCInterval.Duration = resultGCInterval
+		}
+	}
+	operatorConfig := config.Default()
+	configWatcher := &config.Watcher{Path: configFile, Override: overrideConfig}
+	if configFile != "" {
+		loaded, err := configWatcher.Load()
+		if err != nil {
+			setupLog.Error(err, "unable to load the config file")
+			os.Exit(1)
+		}
+		operatorConfig = loaded
+	} else {
+		overrideConfig(operatorConfig)
+	}
+	configStore := config.NewStore(operatorConfig)
+	configWatcher.Store = configStore
+
+	shutdownTracing, err := tracing.Setup(context.Background(), traceExporter, traceEndpoint)
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+	defer func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			setupLog.Error(err, "unable to flush traces")
+		}
+	}()
+
+	watchNamespace, err := k8sutil.GetWatchNamespace()
 	if err != nil {
 		setupLog.Error(err, "failed to get watch namespace")
 		os.Exit(1)
 	}
 
-	// Trigger the Analytics if it's enabled
-	if isAnalytics := strings.ToUpper(os.Getenv("ANALYTICS")); isAnalytics != "FALSE" {
-		if err := analytics.TriggerAnalytics(); err != nil {
-			setupLog.Error(err, "failed to trigger analytics")
-		}
+	if shards > 0 && enableLeaderElection {
+		setupLog.Error(fmt.Errorf("the sharding cannot be used along with the leader election"), "invalid args")
+		os.Exit(1)
 	}
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	cfg := ctrl.GetConfigOrDie()
+	// the namespaces are resolved before the start of the manager, as its cache is restricted to them.
+	// the same uncached client holds the shard leases, in the namespace of the operator which may not be watched
+	apiClient, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		setupLog.Error(err, "unable to create client")
+		os.Exit(1)
+	}
+	watchNamespaces, err := namespaces.Resolve(context.Background(), apiClient, watchNamespace, watchNamespaceSelector)
+	if err != nil {
+		setupLog.Error(err, "failed to resolve the watch namespaces")
+		os.Exit(1)
+	}
+	if len(watchNamespaces) == 0 {
+		setupLog.Info("watching all the namespaces")
+	} else {
+		setupLog.Info("watching the namespaces", "namespaces", watchNamespaces)
+	}
+
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
 		Scheme:                 scheme,
-		MetricsBindAddress:     metricsAddr,
+		MetricsBindAddress:     operatorConfig.Metrics.BindAddress,
 		Port:                   9443,
-		HealthProbeBindAddress: probeAddr,
+		HealthProbeBindAddress: operatorConfig.Metrics.HealthProbeBindAddress,
+		CertDir:                webhookCertDir,
 		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "chaos-operator.lock",
-		Namespace:              namespace,
+		LeaderElectionID:       leaderElectionID,
+		// the lease is read by the readiness check from the namespace of the operator
+		LeaderElectionNamespace: os.Getenv("POD_NAMESPACE"),
+		// a multi-namespace cache is used if more than one namespace is watched
+		Cache: cache.Options{Namespaces: watchNamespaces},
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -109,28 +292,207 @@ func main() {
 		os.Exit(1)
 	}
 
+	if configFile != "" {
+		if err = mgr.Add(configWatcher); err != nil {
+			setupLog.Error(err, "unable to add config watcher")
+			os.Exit(1)
+		}
+	}
+
+	if watchNamespaceSelector != "" {
+		if err = mgr.Add(&namespaces.Watcher{
+			Reader:         apiClient,
+			WatchNamespace: watchNamespace,
+			Selector:       watchNamespaceSelector,
+			Namespaces:     watchNamespaces,
+			Interval:       watchNamespaceResync,
+		}); err != nil {
+			setupLog.Error(err, "unable to add namespace watcher")
+			os.Exit(1)
+		}
+	}
+
+	var sharder *sharding.Sharder
+	if shards > 0 {
+		if sharder, err = sharding.NewSharder(apiClient, mgr.GetClient(), os.Getenv("POD_NAMESPACE"), os.Getenv("POD_NAME"),
+			shards, shardBy, shardLeaseDuration); err != nil {
+			setupLog.Error(err, "unable to create sharder")
+			os.Exit(1)
+		}
+		if err = mgr.Add(sharder); err != nil {
+			setupLog.Error(err, "unable to add sharder")
+			os.Exit(1)
+		}
+		setupLog.Info("sharding the watched namespaces", "shards", shards, "mode", sharder.Mode)
+	}
+
+	var publisher *cloudevents.Publisher
+	if cloudEventsSink != "" {
+		if publisher, err = cloudevents.NewPublisher(cloudEventsSink, cloudEventsMode); err != nil {
+			setupLog.Error(err, "unable to create cloudevents publisher")
+			os.Exit(1)
+		}
+		if err = mgr.Add(publisher); err != nil {
+			setupLog.Error(err, "unable to add cloudevents publisher")
+			os.Exit(1)
+		}
+	}
+
+	var auditSink audit.Sink
+	if auditLogPath != "" {
+		auditSink = audit.NewFileSink(auditLogPath, auditLogMaxSize, auditLogMaxBackups)
+	}
+	if enableConversionWebhook {
+		if err = (&litmuschaosiov1beta1.ChaosEngine{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ChaosEngine")
+			os.Exit(1)
+		}
+	}
+	if enableAuditWebhook {
+		mgr.GetWebhookServer().Register(audit.WebhookPath, &webhook.Admission{Handler: &audit.Annotator{
+			Decoder:          admission.NewDecoder(mgr.GetScheme()),
+			OperatorUsername: auditOperatorUsername,
+		}})
+	}
+
+	// the analytics are opt-in, the counters are reported from the background
+	sink, err := analytics.NewSink(analyticsSink, analyticsLocation)
+	if err != nil {
+		setupLog.Error(err, "unable to set up analytics sink")
+		os.Exit(1)
+	}
+	collector := analytics.NewCollector(sink, analyticsInterval)
+	if analyticsShareWithRunner && collector == nil {
+		setupLog.Error(fmt.Errorf("the installation id cannot be shared while the analytics are disabled"), "invalid args")
+		os.Exit(1)
+	}
+	if collector != nil {
+		// the runner pods only receive the installation id on a separate opt-in, as it leaks into their env
+		if analyticsShareWithRunner {
+			analytics.ClientUUID = collector.InstallationID
+		}
+		if err = mgr.Add(collector); err != nil {
+			setupLog.Error(err, "unable to add analytics collector")
+			os.Exit(1)
+		}
+	}
+
+	dispatcher := notifier.NewDispatcher(mgr.GetAPIReader())
+	dispatcher.Config = configStore
+	if err = mgr.Add(dispatcher); err != nil {
+		setupLog.Error(err, "unable to add notifier dispatcher")
+		os.Exit(1)
+	}
+	// the api calls of the reconciles are bounded by the timeouts of the operator config
+	apiTimeouts := timeout.NewClient(mgr.GetClient(), configStore)
+	// a reconcile outliving twice its timeout has stalled, e.g. on a call ignoring the context
+	watchdog := health.NewWatchdog(func() time.Duration {
+		return 2 * configStore.Get().Controller.Timeouts.Reconcile.Duration
+	})
 	if err = (&controllers.ChaosEngineReconciler{
-		Client:   mgr.GetClient(),
-		Scheme:   mgr.GetScheme(),
-		Recorder: mgr.GetEventRecorderFor("chaos-operator"),
+		Client:      apiTimeouts,
+		Scheme:      mgr.GetScheme(),
+		Recorder:    notifier.NewRecorder(mgr.GetEventRecorderFor("chaos-operator"), dispatcher),
+		CloudEvents: publisher,
+		Audit:       auditSink,
+		Shards:      sharder,
+		Config:      configStore,
+		Watchdog:    watchdog,
+		APIReader:   mgr.GetAPIReader(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ChaosEngine")
 		os.Exit(1)
 	}
+	if err = (&controllers.ChaosResultReconciler{
+		Client:      apiTimeouts,
+		Scheme:      mgr.GetScheme(),
+		CloudEvents: publisher,
+		Shards:      sharder,
+		Config:      configStore,
+		Watchdog:    watchdog,
+		Analytics:   collector,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ChaosResult")
+		os.Exit(1)
+	}
+	// the garbage collector is always added with a config file, as the retention policy may be enabled on its reload
+	if operatorConfig.ChaosResultRetention.Policy().IsEnabled() || configFile != "" {
+		archiver, err := retention.NewArchiver(resultArchive, resultArchiveLocation, mgr.GetClient())
+		if err != nil {
+			setupLog.Error(err, "unable to create chaosresult archiver")
+			os.Exit(1)
+		}
+		if err = (&controllers.ChaosResultGarbageCollector{
+			Client:   mgr.GetClient(),
+			Archiver: archiver,
+			Interval: operatorConfig.ChaosResultRetention.GCInterval.Duration,
+			Shards:   sharder,
+			Config:   configStore,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create chaosresult garbage collector")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
+	if err := mgr.AddMetricsExtraHandler(report.HandlerPath, &report.Handler{
+		Reader:     mgr.GetAPIReader(),
+		Client:     apiClient,
+		Namespaces: watchNamespaces,
+	}); err != nil {
+		setupLog.Error(err, "unable to set up report handler")
+		os.Exit(1)
+	}
+
+	// the effective config is only served to the users allowed to get its path, as the metrics port is not protected
+	if err := mgr.AddMetricsExtraHandler(config.HandlerPath, &auth.Handler{
+		Reviewer: &auth.Reviewer{Client: apiClient},
+		Handler:  &config.Handler{Store: configStore},
+	}); err != nil {
+		setupLog.Error(err, "unable to set up config handler")
+		os.Exit(1)
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
 	}
-	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
-		setupLog.Error(err, "unable to set up ready check")
+	if err := mgr.AddHealthzCheck("reconcile", watchdog.Check); err != nil {
+		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
 	}
+	readyChecks := map[string]healthz.Checker{
+		"crds": health.CRDs(mgr.GetRESTMapper(),
+			litmuschaosiov1alpha1.SchemeGroupVersion.WithKind("ChaosEngine"),
+			litmuschaosiov1alpha1.SchemeGroupVersion.WithKind("ChaosExperiment"),
+			litmuschaosiov1alpha1.SchemeGroupVersion.WithKind("ChaosResult")),
+		"cache-sync": health.CacheSynced(mgr.GetCache()),
+	}
+	if enableConversionWebhook || enableAuditWebhook {
+		readyChecks["webhook"] = mgr.GetWebhookServer().StartedChecker()
+		readyChecks["webhook-certificate"] = health.Certificate(filepath.Join(webhookCertDir, "tls.crt"))
+	}
+	if enableLeaderElection && os.Getenv("POD_NAMESPACE") != "" {
+		readyChecks["leader-election"] = health.LeaderElectionLease(mgr.GetAPIReader(), os.Getenv("POD_NAMESPACE"), leaderElectionID)
+	}
+	for name, check := range readyChecks {
+		if err := mgr.AddReadyzCheck(name, check); err != nil {
+			setupLog.Error(err, "unable to set up ready check", "check", name)
+			os.Exit(1)
+		}
+	}
 
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+		if errors.Is(err, namespaces.ErrChanged) {
+			// the cache of the manager is restricted to the namespaces resolved at the startup, the operator
+			// exits successfully so that it is restarted to watch the changed namespaces
+			setupLog.Info("restarting to watch the changed namespaces")
+			return
+		}
 		setupLog.Error(err, "problem running manager")
+		// flush the traces of the failed run, as os.Exit skips the deferred calls
+		_ = shutdownTracing(context.Background())
 		os.Exit(1)
 	}
 
@@ -14,48 +14,141 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
+// Package analytics reports the aggregated, non-identifying usage counters of the chaos-operator to an
+// opted-in sink. The schema of the reports is documented in docs/analytics.md
 package analytics
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
-
-	ga "github.com/jpillora/go-ogle-analytics"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
 )
 
 const (
-	// ClientID contains TrackingID of the application
-	clientID = "UA-92076314-21"
+	// SinkNone disables the analytics, it is the default
+	SinkNone = "none"
+	// SinkFile appends the reports to a local file, e.g. for the air-gapped clusters
+	SinkFile = "file"
+	// SinkHTTP posts the reports to a collector endpoint
+	SinkHTTP = "http"
 
-	// supported event categories
+	// SchemaVersion is the version of the schema of the reports
+	SchemaVersion = "litmuschaos.io/analytics/v1"
+	// component denotes the Litmus component sending the reports
+	component = "chaos-operator"
 
-	// Category category notifies installation of a component of Litmus Infrastructure
-	category = "Litmus-Infra"
+	// httpTimeout bounds a post to the collector endpoint
+	httpTimeout = 10 * time.Second
+)
 
-	// supported event actions
+// ClientUUID contains the installation id of the analytics, passed on to the chaos-runner.
+// It is empty unless the analytics are enabled along with the -analytics-share-with-runner arg
+var ClientUUID string
 
-	// Action is sent when the installation is triggered
-	action = "Installation"
+// Report is the only payload sent to the sinks. It contains no names, namespaces, uids, images or
+// any other data of the cluster, only the counters aggregated over the reporting period
+type Report struct {
+	// Schema is the SchemaVersion of the report
+	Schema string `json:"schema"`
+	// Component is always chaos-operator
+	Component string `json:"component"`
+	// InstallationID is generated randomly on every start of the operator
+	InstallationID string `json:"installationID"`
+	// PeriodStart and PeriodEnd bound the period over which the counters are aggregated
+	PeriodStart time.Time `json:"periodStart"`
+	PeriodEnd   time.Time `json:"periodEnd"`
+	// ExperimentRuns is the number of the experiment runs completed within the period
+	ExperimentRuns int64 `json:"experimentRuns"`
+	// Verdicts contains the number of the completed runs by their verdict, e.g. Pass, Fail or Stopped
+	Verdicts map[string]int64 `json:"verdicts"`
+}
 
-	// supported event labels
+// Sink receives the analytics reports
+type Sink interface {
+	Send(ctx context.Context, report Report) error
+}
 
-	// Label denotes event is associated to which Litmus component
-	label = "Chaos-Operator"
-)
+// NewSink returns the sink for the given sink type, nil if the analytics are disabled
+// location is the endpoint url for the http sink and the file path for the file sink
+func NewSink(sinkType, location string) (Sink, error) {
+	switch sinkType {
+	case "", SinkNone:
+		return nil, nil
+	case SinkFile:
+		if location == "" {
+			return nil, fmt.Errorf("file path is required for the %v analytics sink", SinkFile)
+		}
+		return &FileSink{Path: location}, nil
+	case SinkHTTP:
+		if location == "" {
+			return nil, fmt.Errorf("endpoint is required for the %v analytics sink", SinkHTTP)
+		}
+		return &HTTPSink{Endpoint: location, Client: &http.Client{Timeout: httpTimeout}}, nil
+	}
+	return nil, fmt.Errorf("unsupported analytics sink %q, supported sinks: %v, %v, %v", sinkType, SinkNone, SinkFile, SinkHTTP)
+}
 
-// TriggerAnalytics is responsible for sending out events
-func TriggerAnalytics() error {
-	client, err := ga.NewClient(clientID)
+// FileSink appends the reports as json lines to a local file
+type FileSink struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+// Send appends the report to the file
+func (sink *FileSink) Send(_ context.Context, report Report) error {
+	data, err := json.Marshal(report)
 	if err != nil {
-		return fmt.Errorf("new client generation failed, error : %s", err)
+		return err
+	}
+
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(sink.Path), 0755); err != nil {
+		return fmt.Errorf("unable to create the analytics directory, due to error: %v", err)
 	}
-	// sets the clientUUID to operator uid
-	ClientUUID, err = getUID()
+	file, err := os.OpenFile(sink.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("unable to open the analytics file, due to error: %v", err)
+	}
+	defer file.Close()
+	_, err = file.Write(append(data, '\n'))
+	return err
+}
+
+// HTTPSink posts the reports as json to a collector endpoint
+type HTTPSink struct {
+	Endpoint string
+	Client   *http.Client
+}
+
+// Send posts the report to the endpoint, any non 2xx response is an error
+func (sink *HTTPSink) Send(ctx context.Context, report Report) error {
+	data, err := json.Marshal(report)
 	if err != nil {
 		return err
 	}
-	client.ClientID(ClientUUID)
-	if err := client.Send(ga.NewEvent(category, action).Label(label)); err != nil {
-		return fmt.Errorf("analytics event sending failed, error: %s", err)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sink.Endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := sink.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to post the analytics report, due to error: %v", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("analytics collector responded with status %v", resp.StatusCode)
 	}
 	return nil
 }
@@ -0,0 +1,179 @@
+/*
+Copyright 2019 LitmusChaos Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+   http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analytics
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestNewSink(t *testing.T) {
+	tests := map[string]struct {
+		sinkType, location string
+		isNil, isErr       bool
+	}{
+		"Test Positive-1": {
+			sinkType: SinkNone,
+			isNil:    true,
+		},
+		"Test Positive-2": {
+			sinkType: SinkFile,
+			location: "/var/log/chaos-operator/analytics.json",
+		},
+		"Test Positive-3": {
+			sinkType: SinkHTTP,
+			location: "https://collector.example.com/v1/reports",
+		},
+		"Test Negative-1": {
+			sinkType: SinkHTTP,
+			isErr:    true,
+		},
+		"Test Negative-2": {
+			sinkType: "ga",
+			isErr:    true,
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			sink, err := NewSink(mock.sinkType, mock.location)
+			if mock.isErr != (err != nil) {
+				t.Fatalf("Test %q failed: expected error %v, received %v", name, mock.isErr, err)
+			}
+			if !mock.isErr && mock.isNil != (sink == nil) {
+				t.Fatalf("Test %q failed: expected nil sink %v, received %v", name, mock.isNil, sink)
+			}
+		})
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "analytics", "reports.json")
+	sink := &FileSink{Path: path}
+	for i := int64(1); i <= 2; i++ {
+		if err := sink.Send(context.Background(), Report{Schema: SchemaVersion, ExperimentRuns: i}); err != nil {
+			t.Fatalf("unable to send the report, due to error: %v", err)
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("unable to open the reports, due to error: %v", err)
+	}
+	defer file.Close()
+	var reports []Report
+	for scanner := bufio.NewScanner(file); scanner.Scan(); {
+		report := Report{}
+		if err := json.Unmarshal(scanner.Bytes(), &report); err != nil {
+			t.Fatalf("unable to decode the report, due to error: %v", err)
+		}
+		reports = append(reports, report)
+	}
+	if len(reports) != 2 || reports[1].ExperimentRuns != 2 {
+		t.Fatalf("expected the two reports to be appended, received %+v", reports)
+	}
+}
+
+func TestHTTPSink(t *testing.T) {
+	tests := map[string]struct {
+		status int
+		isErr  bool
+	}{
+		"Test Positive-1": {
+			status: http.StatusAccepted,
+		},
+		"Test Negative-1": {
+			status: http.StatusInternalServerError,
+			isErr:  true,
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			received := Report{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+					t.Errorf("Test %q failed: unexpected request %v %v", name, r.Method, r.Header.Get("Content-Type"))
+				}
+				_ = json.NewDecoder(r.Body).Decode(&received)
+				w.WriteHeader(mock.status)
+			}))
+			defer server.Close()
+
+			sink := &HTTPSink{Endpoint: server.URL, Client: server.Client()}
+			err := sink.Send(context.Background(), Report{Schema: SchemaVersion, Component: component})
+			if mock.isErr != (err != nil) {
+				t.Fatalf("Test %q failed: expected error %v, received %v", name, mock.isErr, err)
+			}
+			if received.Schema != SchemaVersion {
+				t.Fatalf("Test %q failed: expected the report to be posted, received %+v", name, received)
+			}
+		})
+	}
+}
+
+// fakeSink records the sent reports, failing while err is set
+type fakeSink struct {
+	reports []Report
+	err     error
+}
+
+func (sink *fakeSink) Send(_ context.Context, report Report) error {
+	if sink.err != nil {
+		return sink.err
+	}
+	sink.reports = append(sink.reports, report)
+	return nil
+}
+
+func TestCollector(t *testing.T) {
+	sink := &fakeSink{err: fmt.Errorf("collector is unreachable")}
+	collector := NewCollector(sink, time.Hour)
+	collector.RecordRun("Pass")
+	collector.RecordRun("Fail")
+
+	// the counters of the failed report are carried over into the next one
+	collector.flush(context.Background())
+	collector.RecordRun("Pass")
+	sink.err = nil
+	collector.flush(context.Background())
+	if len(sink.reports) != 1 {
+		t.Fatalf("expected a single report, received %+v", sink.reports)
+	}
+	report := sink.reports[0]
+	if report.ExperimentRuns != 3 || report.Verdicts["Pass"] != 2 || report.Verdicts["Fail"] != 1 {
+		t.Fatalf("unexpected counters of the report %+v", report)
+	}
+	if report.Schema != SchemaVersion || report.InstallationID == "" {
+		t.Fatalf("unexpected schema of the report %+v", report)
+	}
+
+	// the counters are reset once they are reported
+	collector.flush(context.Background())
+	if report := sink.reports[1]; report.ExperimentRuns != 0 || len(report.Verdicts) != 0 || !report.PeriodStart.Equal(sink.reports[0].PeriodEnd) {
+		t.Fatalf("expected the counters of the next period to be reset, received %+v", report)
+	}
+
+	var disabled *Collector
+	disabled.RecordRun("Pass")
+	if NewCollector(nil, time.Hour) != nil {
+		t.Fatalf("expected no collector without a sink")
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package analytics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	chaosTypes "github.com/litmuschaos/chaos-operator/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+// DefaultInterval is the default reporting period of the collector
+const DefaultInterval = 24 * time.Hour
+
+// Collector aggregates the counters of the experiment runs and reports them to the sink once per interval.
+// The counters are recorded without blocking and the reports are sent from the collector's own goroutine,
+// so neither the startup nor the reconciles wait on the sink
+type Collector struct {
+	Sink Sink
+	// Interval between two reports
+	Interval time.Duration
+	// InstallationID identifies the reports of the same operator run
+	InstallationID string
+
+	now         func() time.Time
+	mu          sync.Mutex
+	periodStart time.Time
+	runs        int64
+	verdicts    map[string]int64
+}
+
+// NewCollector returns the collector reporting to the given sink, nil if the sink is nil
+func NewCollector(sink Sink, interval time.Duration) *Collector {
+	if sink == nil {
+		return nil
+	}
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Collector{
+		Sink:           sink,
+		Interval:       interval,
+		InstallationID: string(uuid.NewUUID()),
+		now:            time.Now,
+		periodStart:    time.Now(),
+		verdicts:       map[string]int64{},
+	}
+}
+
+// RecordRun counts a completed experiment run with the given verdict, it is a no-op on a nil collector
+func (collector *Collector) RecordRun(verdict string) {
+	if collector == nil {
+		return
+	}
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	collector.runs++
+	collector.verdicts[verdict]++
+}
+
+// Start reports the counters once per interval, and once more on the shutdown
+func (collector *Collector) Start(ctx context.Context) error {
+	ticker := time.NewTicker(collector.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			// the context of the final report outlives the one of the manager
+			flushCtx, cancel := context.WithTimeout(context.Background(), httpTimeout)
+			collector.flush(flushCtx)
+			cancel()
+			return nil
+		case <-ticker.C:
+			collector.flush(ctx)
+		}
+	}
+}
+
+// NeedLeaderElection returns false, every replica reports the runs it has reconciled
+func (collector *Collector) NeedLeaderElection() bool {
+	return false
+}
+
+// flush sends the report of the current period. The counters of a report which could not be sent
+// are retained and aggregated into the next one
+func (collector *Collector) flush(ctx context.Context) {
+	report := collector.report()
+	if err := collector.Sink.Send(ctx, report); err != nil {
+		chaosTypes.Log.V(1).Info("unable to send the analytics report", "error", err.Error())
+		return
+	}
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	collector.runs -= report.ExperimentRuns
+	for verdict, count := range report.Verdicts {
+		collector.verdicts[verdict] -= count
+		if collector.verdicts[verdict] == 0 {
+			delete(collector.verdicts, verdict)
+		}
+	}
+	collector.periodStart = report.PeriodEnd
+}
+
+// report returns the report of the counters recorded so far
+func (collector *Collector) report() Report {
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	verdicts := make(map[string]int64, len(collector.verdicts))
+	for verdict, count := range collector.verdicts {
+		verdicts[verdict] = count
+	}
+	return Report{
+		Schema:         SchemaVersion,
+		Component:      component,
+		InstallationID: collector.InstallationID,
+		PeriodStart:    collector.periodStart,
+		PeriodEnd:      collector.now(),
+		ExperimentRuns: collector.runs,
+		Verdicts:       verdicts,
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+)
+
+const (
+	// UserAnnotation contains the user who requested the last action on the chaosengine
+	UserAnnotation = "audit.litmuschaos.io/user"
+	// GroupsAnnotation contains the comma separated groups of the user
+	GroupsAnnotation = "audit.litmuschaos.io/groups"
+	// ActionAnnotation contains the last action requested on the chaosengine
+	ActionAnnotation = "audit.litmuschaos.io/action"
+	// TimeAnnotation contains the time at which the last action was requested
+	TimeAnnotation = "audit.litmuschaos.io/time"
+)
+
+// the actions requested on the chaosengines, which are audited
+const (
+	ActionStart    = "start"
+	ActionStop     = "stop"
+	ActionRestart  = "restart"
+	ActionComplete = "complete"
+)
+
+// the outcomes of the audited actions
+const (
+	OutcomeInitialized = "initialized"
+	OutcomeRejected    = "rejected"
+	OutcomeCompleted   = "completed"
+	OutcomeStopped     = "stopped"
+	OutcomeRestarted   = "restarted"
+)
+
+// Record is an audit record of an action on a chaosengine
+type Record struct {
+	Time        time.Time `json:"time"`
+	User        string    `json:"user"`
+	Groups      string    `json:"groups,omitempty"`
+	Action      string    `json:"action"`
+	RequestTime string    `json:"requestTime,omitempty"`
+	Engine      string    `json:"engine"`
+	Namespace   string    `json:"namespace"`
+	UID         string    `json:"uid"`
+	SpecHash    string    `json:"specHash"`
+	Targets     string    `json:"targets,omitempty"`
+	Outcome     string    `json:"outcome"`
+	Message     string    `json:"message,omitempty"`
+}
+
+// Sink persists the audit records
+type Sink interface {
+	Write(record Record) error
+}
+
+// NewRecord returns the audit record of the action on the chaosengine, attributed to the user
+// stamped in the chaosengine annotations by the audit webhook
+func NewRecord(engine *v1alpha1.ChaosEngine, action, targets, outcome, message string) Record {
+	return Record{
+		Time:        time.Now().UTC(),
+		User:        engine.Annotations[UserAnnotation],
+		Groups:      engine.Annotations[GroupsAnnotation],
+		Action:      action,
+		RequestTime: engine.Annotations[TimeAnnotation],
+		Engine:      engine.Name,
+		Namespace:   engine.Namespace,
+		UID:         string(engine.UID),
+		SpecHash:    SpecHash(engine),
+		Targets:     targets,
+		Outcome:     outcome,
+		Message:     message,
+	}
+}
+
+// SpecHash returns the sha256 of the chaosengine spec, excluding the engineState
+// so that the start, stop and restart of the same spec share the hash
+func SpecHash(engine *v1alpha1.ChaosEngine) string {
+	spec := engine.Spec.DeepCopy()
+	spec.EngineState = ""
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
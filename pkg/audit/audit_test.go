@@ -0,0 +1,185 @@
+/*
+Copyright 2019 LitmusChaos Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+   http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func newEngine(state v1alpha1.EngineState, annotations map[string]string) *v1alpha1.ChaosEngine {
+	return &v1alpha1.ChaosEngine{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "litmuschaos.io/v1alpha1", Kind: "ChaosEngine"},
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx-chaos", Namespace: "default", Annotations: annotations},
+		Spec: v1alpha1.ChaosEngineSpec{
+			EngineState: state,
+			Experiments: []v1alpha1.ExperimentList{{Name: "pod-delete"}},
+		},
+	}
+}
+
+func TestSpecHash(t *testing.T) {
+	active := newEngine(v1alpha1.EngineStateActive, nil)
+	stopped := newEngine(v1alpha1.EngineStateStop, nil)
+	changed := newEngine(v1alpha1.EngineStateActive, nil)
+	changed.Spec.Experiments[0].Name = "container-kill"
+
+	if SpecHash(active) != SpecHash(stopped) {
+		t.Fatalf("Test failed: expected the engineState to be excluded from the spec hash")
+	}
+	if SpecHash(active) == SpecHash(changed) {
+		t.Fatalf("Test failed: expected the spec hash to change with the experiments")
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	sink := NewFileSink(path, 400, 2)
+	engine := newEngine(v1alpha1.EngineStateActive, map[string]string{UserAnnotation: "alice"})
+
+	for i := 0; i < 10; i++ {
+		if err := sink.Write(NewRecord(engine, ActionStart, "", OutcomeInitialized, "")); err != nil {
+			t.Fatalf("Test failed: expected error to be nil, received %v", err)
+		}
+	}
+
+	for _, name := range []string{path, path + ".1", path + ".2"} {
+		file, err := os.Open(name)
+		if err != nil {
+			t.Fatalf("Test failed: expected %v to exist, received %v", name, err)
+		}
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			record := Record{}
+			if err := json.Unmarshal(scanner.Bytes(), &record); err != nil || record.User != "alice" || record.Engine != "nginx-chaos" {
+				t.Fatalf("Test failed: unexpected record %q in %v", scanner.Text(), name)
+			}
+		}
+		file.Close()
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Fatalf("Test failed: expected only 2 backups to be retained")
+	}
+	if info, _ := os.Stat(path + ".1"); info.Mode().Perm()&0222 != 0 {
+		t.Fatalf("Test failed: expected the rotated audit log to be read-only, received %v", info.Mode())
+	}
+}
+
+func TestAnnotator(t *testing.T) {
+	stamped := map[string]string{UserAnnotation: "alice", ActionAnnotation: ActionStart}
+	tests := map[string]struct {
+		operation   admissionv1.Operation
+		user        string
+		oldEngine   *v1alpha1.ChaosEngine
+		engine      *v1alpha1.ChaosEngine
+		action      string
+		auditedUser string
+	}{
+		"Test Positive-1": {
+			operation:   admissionv1.Create,
+			user:        "bob",
+			engine:      newEngine(v1alpha1.EngineStateActive, nil),
+			action:      ActionStart,
+			auditedUser: "bob",
+		},
+		"Test Positive-2": {
+			operation:   admissionv1.Update,
+			user:        "bob",
+			oldEngine:   newEngine(v1alpha1.EngineStateActive, stamped),
+			engine:      newEngine(v1alpha1.EngineStateStop, stamped),
+			action:      ActionStop,
+			auditedUser: "bob",
+		},
+		"Test Positive-3": {
+			operation:   admissionv1.Update,
+			user:        "bob",
+			oldEngine:   newEngine(v1alpha1.EngineStateStop, stamped),
+			engine:      newEngine(v1alpha1.EngineStateActive, stamped),
+			action:      ActionRestart,
+			auditedUser: "bob",
+		},
+		"Test Negative-1": {
+			// the operator stopping the completed engine is not audited
+			operation:   admissionv1.Update,
+			user:        "operator",
+			oldEngine:   newEngine(v1alpha1.EngineStateActive, stamped),
+			engine:      newEngine(v1alpha1.EngineStateStop, stamped),
+			action:      ActionStart,
+			auditedUser: "alice",
+		},
+		"Test Negative-2": {
+			// the audit annotations cannot be forged
+			operation:   admissionv1.Update,
+			user:        "bob",
+			oldEngine:   newEngine(v1alpha1.EngineStateActive, stamped),
+			engine:      newEngine(v1alpha1.EngineStateActive, map[string]string{UserAnnotation: "mallory", ActionAnnotation: ActionRestart}),
+			action:      ActionStart,
+			auditedUser: "alice",
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("Test failed: expected error to be nil, received %v", err)
+	}
+	annotator := &Annotator{Decoder: admission.NewDecoder(scheme), OperatorUsername: "operator"}
+
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+				Operation: mock.operation,
+				UserInfo:  authenticationv1.UserInfo{Username: mock.user},
+			}}
+			req.Object.Raw, _ = json.Marshal(mock.engine)
+			if mock.oldEngine != nil {
+				req.OldObject.Raw, _ = json.Marshal(mock.oldEngine)
+			}
+
+			resp := annotator.Handle(context.TODO(), req)
+			if !resp.Allowed {
+				t.Fatalf("Test %q failed: expected the request to be allowed, received %v", name, resp.Result)
+			}
+
+			engine := mock.engine.DeepCopy()
+			if len(resp.Patches) != 0 {
+				patch, _ := json.Marshal(resp.Patches)
+				decoded, err := jsonpatch.DecodePatch(patch)
+				if err != nil {
+					t.Fatalf("Test %q failed: invalid patch %s", name, patch)
+				}
+				patched, err := decoded.Apply(req.Object.Raw)
+				if err != nil {
+					t.Fatalf("Test %q failed: unable to apply patch %s, received %v", name, patch, err)
+				}
+				engine = &v1alpha1.ChaosEngine{}
+				_ = json.Unmarshal(patched, engine)
+			}
+			if engine.Annotations[UserAnnotation] != mock.auditedUser || engine.Annotations[ActionAnnotation] != mock.action {
+				t.Fatalf("Test %q failed: expected %v by %v, received annotations %v", name, mock.action, mock.auditedUser, engine.Annotations)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+const (
+	defaultMaxSize    = 100 * 1024 * 1024
+	defaultMaxBackups = 5
+)
+
+// FileSink appends the audit records as json lines to the local file.
+// The file is rotated to <path>.1 .. <path>.<MaxBackups> once it exceeds MaxSize bytes,
+// and the rotated files are made read-only so that the records stay immutable
+type FileSink struct {
+	Path       string
+	MaxSize    int64
+	MaxBackups int
+
+	mu sync.Mutex
+}
+
+// NewFileSink returns the file sink for the given path, with the default size and backups if not specified
+func NewFileSink(path string, maxSize int64, maxBackups int) *FileSink {
+	if maxSize <= 0 {
+		maxSize = defaultMaxSize
+	}
+	if maxBackups <= 0 {
+		maxBackups = defaultMaxBackups
+	}
+	return &FileSink{Path: path, MaxSize: maxSize, MaxBackups: maxBackups}
+}
+
+// Write appends the record to the file, rotating it if required
+func (sink *FileSink) Write(record Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+
+	if info, err := os.Stat(sink.Path); err == nil && info.Size()+int64(len(data)) > sink.MaxSize {
+		if err := sink.rotate(); err != nil {
+			return fmt.Errorf("unable to rotate the audit log %v, due to error: %v", sink.Path, err)
+		}
+	}
+
+	file, err := os.OpenFile(sink.Path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// rotate shifts <path>.N to <path>.N+1, dropping the oldest backup, and moves the current file to <path>.1
+func (sink *FileSink) rotate() error {
+	oldest := backupName(sink.Path, sink.MaxBackups)
+	if err := os.Remove(oldest); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	for i := sink.MaxBackups - 1; i >= 1; i-- {
+		if err := os.Rename(backupName(sink.Path, i), backupName(sink.Path, i+1)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if err := os.Rename(sink.Path, backupName(sink.Path, 1)); err != nil {
+		return err
+	}
+	return os.Chmod(backupName(sink.Path, 1), 0400)
+}
+
+func backupName(path string, index int) string {
+	return fmt.Sprintf("%s.%d", path, index)
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	admissionv1 "k8s.io/api/admission/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// WebhookPath is the path at which the audit webhook is served
+const WebhookPath = "/mutate-litmuschaos-io-v1alpha1-chaosengine"
+
+//+kubebuilder:webhook:path=/mutate-litmuschaos-io-v1alpha1-chaosengine,mutating=true,failurePolicy=fail,sideEffects=None,groups=litmuschaos.io,resources=chaosengines,verbs=create;update,versions=v1alpha1,name=mchaosengine.litmuschaos.io,admissionReviewVersions=v1
+
+// Annotator is the mutating webhook, which stamps the user requesting the start, stop
+// or restart of the chaosengine into its annotations. The audit annotations cannot be
+// set or modified by the users, they are restored from the old object on the other updates
+type Annotator struct {
+	Decoder *admission.Decoder
+	// OperatorUsername is the user of the operator, whose own updates of the chaosengine are not audited
+	OperatorUsername string
+}
+
+// Handle implements the admission.Handler
+func (annotator *Annotator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	engine := &v1alpha1.ChaosEngine{}
+	if err := annotator.Decoder.Decode(req, engine); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	var action string
+	oldEngine := &v1alpha1.ChaosEngine{}
+	switch req.Operation {
+	case admissionv1.Create:
+		action = ActionStart
+	case admissionv1.Update:
+		if err := annotator.Decoder.DecodeRaw(req.OldObject, oldEngine); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		action = stateChange(oldEngine.Spec.EngineState, engine.Spec.EngineState)
+	default:
+		return admission.Allowed("")
+	}
+
+	if action == "" || req.UserInfo.Username == annotator.OperatorUsername {
+		restoreAnnotations(engine, oldEngine)
+	} else {
+		if engine.Annotations == nil {
+			engine.Annotations = map[string]string{}
+		}
+		engine.Annotations[UserAnnotation] = req.UserInfo.Username
+		engine.Annotations[GroupsAnnotation] = strings.Join(req.UserInfo.Groups, ",")
+		engine.Annotations[ActionAnnotation] = action
+		engine.Annotations[TimeAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	marshaled, err := json.Marshal(engine)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
+
+// stateChange returns the audited action for the change of the engineState, if any
+func stateChange(oldState, newState v1alpha1.EngineState) string {
+	if oldState == newState {
+		return ""
+	}
+	switch newState {
+	case v1alpha1.EngineStateStop:
+		return ActionStop
+	case v1alpha1.EngineStateActive:
+		if oldState == v1alpha1.EngineStateStop {
+			return ActionRestart
+		}
+	}
+	return ""
+}
+
+// restoreAnnotations sets the audit annotations of the engine to the ones of the old engine
+func restoreAnnotations(engine, oldEngine *v1alpha1.ChaosEngine) {
+	for _, key := range []string{UserAnnotation, GroupsAnnotation, ActionAnnotation, TimeAnnotation} {
+		value, ok := oldEngine.Annotations[key]
+		switch {
+		case ok:
+			if engine.Annotations == nil {
+				engine.Annotations = map[string]string{}
+			}
+			engine.Annotations[key] = value
+		default:
+			delete(engine.Annotations, key)
+		}
+	}
+}
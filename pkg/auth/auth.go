@@ -0,0 +1,107 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"net/http"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+//+kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// Reviewer authenticates the requests by their bearer token and authorizes them with the apiserver,
+// for the endpoints served on the metrics port, which is not protected by the manager
+type Reviewer struct {
+	// Client creates the tokenreviews and the subjectaccessreviews of the requests
+	Client client.Client
+}
+
+// Authenticate reviews the bearer token of the request, the user is nil if it is not authenticated
+func (reviewer *Reviewer) Authenticate(req *http.Request) (*authenticationv1.UserInfo, error) {
+	token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if token == "" || token == req.Header.Get("Authorization") {
+		return nil, nil
+	}
+	review := &authenticationv1.TokenReview{Spec: authenticationv1.TokenReviewSpec{Token: token}}
+	if err := reviewer.Client.Create(req.Context(), review); err != nil {
+		return nil, err
+	}
+	if !review.Status.Authenticated {
+		return nil, nil
+	}
+	return &review.Status.User, nil
+}
+
+// Authorize checks if the user is allowed the resource attributes, or the non-resource attributes if they are set instead
+func (reviewer *Reviewer) Authorize(req *http.Request, user *authenticationv1.UserInfo, resource *authorizationv1.ResourceAttributes, nonResource *authorizationv1.NonResourceAttributes) (bool, error) {
+	extra := map[string]authorizationv1.ExtraValue{}
+	for k, v := range user.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:                  user.Username,
+			Groups:                user.Groups,
+			UID:                   user.UID,
+			Extra:                 extra,
+			ResourceAttributes:    resource,
+			NonResourceAttributes: nonResource,
+		},
+	}
+	if err := reviewer.Client.Create(req.Context(), review); err != nil {
+		return false, err
+	}
+	return review.Status.Allowed, nil
+}
+
+// Handler serves the requests of the users who are allowed the request path as a non-resource url,
+// e.g. the users bound to a clusterrole with the get verb on the /debug/config nonResourceURLs
+type Handler struct {
+	Reviewer *Reviewer
+	Handler  http.Handler
+}
+
+// ServeHTTP authenticates and authorizes the request, before serving it with the wrapped handler
+func (handler *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	user, err := handler.Reviewer.Authenticate(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if user == nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	isAllowed, err := handler.Reviewer.Authorize(req, user, nil, &authorizationv1.NonResourceAttributes{
+		Path: req.URL.Path,
+		Verb: strings.ToLower(req.Method),
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !isAllowed {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+	handler.Handler.ServeHTTP(w, req)
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestHandler(t *testing.T) {
+	// the admin token is allowed to get /debug/config, the viewer token is only authenticated
+	reviews := interceptor.NewClient(fake.NewClientBuilder().Build(), interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			switch review := obj.(type) {
+			case *authenticationv1.TokenReview:
+				review.Status.Authenticated = review.Spec.Token == "admin-token" || review.Spec.Token == "viewer-token"
+				review.Status.User.Username = review.Spec.Token
+			case *authorizationv1.SubjectAccessReview:
+				review.Status.Allowed = review.Spec.User == "admin-token" && review.Spec.NonResourceAttributes != nil &&
+					review.Spec.NonResourceAttributes.Path == "/debug/config" && review.Spec.NonResourceAttributes.Verb == "get"
+			}
+			return nil
+		},
+	})
+	handler := &Handler{
+		Reviewer: &Reviewer{Client: reviews},
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			_, _ = w.Write([]byte("{}"))
+		}),
+	}
+
+	tests := map[string]struct {
+		path         string
+		token        string
+		expectedCode int
+	}{
+		"Test Positive-1": {
+			path:         "/debug/config",
+			token:        "admin-token",
+			expectedCode: http.StatusOK,
+		},
+		"Test Negative-1": {
+			path:         "/debug/config",
+			expectedCode: http.StatusUnauthorized,
+		},
+		"Test Negative-2": {
+			path:         "/debug/config",
+			token:        "unknown-token",
+			expectedCode: http.StatusUnauthorized,
+		},
+		"Test Negative-3": {
+			path:         "/debug/config",
+			token:        "viewer-token",
+			expectedCode: http.StatusForbidden,
+		},
+		"Test Negative-4": {
+			path:         "/debug/pprof",
+			token:        "admin-token",
+			expectedCode: http.StatusForbidden,
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, mock.path, nil)
+			if mock.token != "" {
+				req.Header.Set("Authorization", "Bearer "+mock.token)
+			}
+			handler.ServeHTTP(recorder, req)
+			if recorder.Code != mock.expectedCode {
+				t.Fatalf("Test %q failed: expected status code %d, received %d", name, mock.expectedCode, recorder.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevents
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+const (
+	// SpecVersion is the version of the cloudevents specification
+	SpecVersion = "1.0"
+	// Source is the source of the cloudevents published by the operator
+	Source = "litmuschaos.io/chaos-operator"
+
+	// TypeEngineInitialized is published once the chaosengine is initialized
+	TypeEngineInitialized = "io.litmuschaos.chaosengine.initialized"
+	// TypeRunnerCreated is published once the chaos-runner pod is created
+	TypeRunnerCreated = "io.litmuschaos.chaosengine.runner.created"
+	// TypeExperimentStatusChanged is published when the status of an experiment of the chaosengine changes
+	TypeExperimentStatusChanged = "io.litmuschaos.chaosengine.experiment.statuschanged"
+	// TypeEngineCompleted is published once the chaosengine is completed
+	TypeEngineCompleted = "io.litmuschaos.chaosengine.completed"
+	// TypeEngineAborted is published once the chaosengine is aborted
+	TypeEngineAborted = "io.litmuschaos.chaosengine.aborted"
+	// TypePolicyViolated is published when the chaosengine is stopped because its spec is not allowed to run,
+	// say, the experiments are not found or the target application is not valid
+	TypePolicyViolated = "io.litmuschaos.chaosengine.policyviolated"
+)
+
+// Event is a cloudevent, along with its json data
+type Event struct {
+	SpecVersion     string          `json:"specversion"`
+	ID              string          `json:"id"`
+	Source          string          `json:"source"`
+	Type            string          `json:"type"`
+	Subject         string          `json:"subject,omitempty"`
+	Time            time.Time       `json:"time"`
+	DataContentType string          `json:"datacontenttype"`
+	Data            json.RawMessage `json:"data,omitempty"`
+}
+
+// EngineData is the data of the chaosengine events
+type EngineData struct {
+	Engine       string                        `json:"engine"`
+	Namespace    string                        `json:"namespace"`
+	UID          string                        `json:"uid"`
+	EngineStatus string                        `json:"engineStatus"`
+	Message      string                        `json:"message,omitempty"`
+	Experiments  []v1alpha1.ExperimentStatuses `json:"experiments,omitempty"`
+}
+
+// NewEngineEvent returns the event of the given type for the chaosengine
+// the subject of the event is <namespace>/<chaosengine>
+func NewEngineEvent(eventType string, engine *v1alpha1.ChaosEngine, message string) Event {
+	return newEvent(eventType, engine.Namespace+"/"+engine.Name, EngineData{
+		Engine:       engine.Name,
+		Namespace:    engine.Namespace,
+		UID:          string(engine.UID),
+		EngineStatus: string(engine.Status.EngineStatus),
+		Message:      message,
+		Experiments:  engine.Status.Experiments,
+	})
+}
+
+// ExperimentData is the data of the experiment status events
+type ExperimentData struct {
+	Engine                 string `json:"engine"`
+	Namespace              string `json:"namespace"`
+	UID                    string `json:"uid"`
+	Experiment             string `json:"experiment"`
+	Status                 string `json:"status,omitempty"`
+	Verdict                string `json:"verdict,omitempty"`
+	Phase                  string `json:"phase,omitempty"`
+	ProbeSuccessPercentage string `json:"probeSuccessPercentage,omitempty"`
+}
+
+// NewExperimentEvent returns the status changed event of the experiment of the chaosengine
+// the subject of the event is <namespace>/<chaosengine>/<experiment>
+func NewExperimentEvent(engine *v1alpha1.ChaosEngine, expStatus v1alpha1.ExperimentStatuses) Event {
+	return newEvent(TypeExperimentStatusChanged, engine.Namespace+"/"+engine.Name+"/"+expStatus.Name, ExperimentData{
+		Engine:                 engine.Name,
+		Namespace:              engine.Namespace,
+		UID:                    string(engine.UID),
+		Experiment:             expStatus.Name,
+		Status:                 string(expStatus.Status),
+		Verdict:                expStatus.Verdict,
+		Phase:                  string(expStatus.ResultPhase),
+		ProbeSuccessPercentage: expStatus.ProbeSuccessPercentage,
+	})
+}
+
+func newEvent(eventType, subject string, data interface{}) Event {
+	event := Event{
+		SpecVersion:     SpecVersion,
+		ID:              string(uuid.NewUUID()),
+		Source:          Source,
+		Type:            eventType,
+		Subject:         subject,
+		Time:            time.Now().UTC(),
+		DataContentType: "application/json",
+	}
+	// the data is marshalled right away, so that the event does not share any state with the reconciler
+	if raw, err := json.Marshal(data); err == nil {
+		event.Data = raw
+	}
+	return event
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevents
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/litmuschaos/chaos-operator/pkg/metrics"
+	chaosTypes "github.com/litmuschaos/chaos-operator/pkg/types"
+)
+
+const (
+	// ModeBinary sends the event attributes as ce-* headers and the data as the body
+	ModeBinary = "binary"
+	// ModeStructured sends the whole event as the application/cloudevents+json body
+	ModeStructured = "structured"
+
+	defaultQueueSize    = 1000
+	defaultMaxRetries   = 5
+	defaultInitialDelay = time.Second
+	defaultMaxDelay     = time.Minute
+	defaultTimeout      = 10 * time.Second
+)
+
+// drop reasons of the dropped events metric
+const (
+	dropReasonQueueFull       = "queue_full"
+	dropReasonRetriesExceeded = "retries_exceeded"
+	dropReasonShutdown        = "shutdown"
+)
+
+// delivery is an event queued for delivery, along with the failed attempts
+type delivery struct {
+	event    Event
+	attempts int
+}
+
+// Publisher delivers the events to the sink in background through a bounded in-memory queue.
+// The failed deliveries are retried with exponential backoff, and the events which cannot be
+// queued or delivered are dropped and counted in the dropped events metric
+type Publisher struct {
+	Sink         string
+	Mode         string
+	HTTPClient   *http.Client
+	MaxRetries   int
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+
+	queue chan delivery
+}
+
+// NewPublisher returns a publisher for the given sink, with the default queue size and backoff
+func NewPublisher(sink, mode string) (*Publisher, error) {
+	switch mode {
+	case "":
+		mode = ModeBinary
+	case ModeBinary, ModeStructured:
+	default:
+		return nil, fmt.Errorf("unsupported cloudevents mode %q, supported modes: %v, %v", mode, ModeBinary, ModeStructured)
+	}
+	return &Publisher{
+		Sink:         sink,
+		Mode:         mode,
+		HTTPClient:   &http.Client{Timeout: defaultTimeout},
+		MaxRetries:   defaultMaxRetries,
+		InitialDelay: defaultInitialDelay,
+		MaxDelay:     defaultMaxDelay,
+		queue:        make(chan delivery, defaultQueueSize),
+	}, nil
+}
+
+// Publish queues the event for delivery without blocking, it is a no-op on a nil publisher
+func (publisher *Publisher) Publish(event Event) {
+	if publisher == nil {
+		return
+	}
+	publisher.enqueue(delivery{event: event})
+}
+
+func (publisher *Publisher) enqueue(d delivery) {
+	select {
+	case publisher.queue <- d:
+	default:
+		metrics.CloudEventsDropped.WithLabelValues(d.event.Type, dropReasonQueueFull).Inc()
+		chaosTypes.Log.Info("dropping cloudevent as the queue is full", "type", d.event.Type, "subject", d.event.Subject)
+	}
+}
+
+// Start delivers the queued events till the context is cancelled
+func (publisher *Publisher) Start(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			for {
+				select {
+				case d := <-publisher.queue:
+					metrics.CloudEventsDropped.WithLabelValues(d.event.Type, dropReasonShutdown).Inc()
+				default:
+					return nil
+				}
+			}
+		case d := <-publisher.queue:
+			publisher.deliver(ctx, d)
+		}
+	}
+}
+
+// NeedLeaderElection makes the publisher run on all the replicas, the events are only produced by the leader
+func (publisher *Publisher) NeedLeaderElection() bool {
+	return false
+}
+
+// deliver sends the event, queueing it again after the backoff delay if the delivery fails
+func (publisher *Publisher) deliver(ctx context.Context, d delivery) {
+	err := publisher.Send(ctx, d.event)
+	if err == nil {
+		return
+	}
+
+	d.attempts++
+	if d.attempts > publisher.MaxRetries {
+		metrics.CloudEventsDropped.WithLabelValues(d.event.Type, dropReasonRetriesExceeded).Inc()
+		chaosTypes.Log.Error(err, "dropping cloudevent as the retries are exceeded", "type", d.event.Type, "subject", d.event.Subject)
+		return
+	}
+	time.AfterFunc(publisher.backoff(d.attempts), func() { publisher.enqueue(d) })
+}
+
+// backoff returns the delay before the given retry
+func (publisher *Publisher) backoff(attempt int) time.Duration {
+	delay := publisher.InitialDelay
+	for i := 1; i < attempt && delay < publisher.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > publisher.MaxDelay {
+		return publisher.MaxDelay
+	}
+	return delay
+}
+
+// Send delivers the event to the sink in the configured mode
+func (publisher *Publisher) Send(ctx context.Context, event Event) error {
+	var body []byte
+	var err error
+	if publisher.Mode == ModeStructured {
+		if body, err = json.Marshal(event); err != nil {
+			return err
+		}
+	} else {
+		body = event.Data
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, publisher.Sink, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if publisher.Mode == ModeStructured {
+		req.Header.Set("Content-Type", "application/cloudevents+json")
+	} else {
+		req.Header.Set("Content-Type", event.DataContentType)
+		req.Header.Set("ce-specversion", event.SpecVersion)
+		req.Header.Set("ce-id", event.ID)
+		req.Header.Set("ce-source", event.Source)
+		req.Header.Set("ce-type", event.Type)
+		req.Header.Set("ce-time", event.Time.Format(time.RFC3339Nano))
+		if event.Subject != "" {
+			req.Header.Set("ce-subject", event.Subject)
+		}
+	}
+
+	resp, err := publisher.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("cloudevents sink responded with status code %d", resp.StatusCode)
+	}
+	return nil
+}
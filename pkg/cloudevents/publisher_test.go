@@ -0,0 +1,137 @@
+/*
+Copyright 2019 LitmusChaos Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+   http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cloudevents
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/chaos-operator/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var engine = &v1alpha1.ChaosEngine{
+	ObjectMeta: metav1.ObjectMeta{Name: "nginx-chaos", Namespace: "default", UID: "engine-uid"},
+	Status:     v1alpha1.ChaosEngineStatus{EngineStatus: v1alpha1.EngineStatusCompleted},
+}
+
+func TestSend(t *testing.T) {
+	tests := map[string]struct {
+		mode string
+	}{
+		"Test Positive-1": {mode: ModeBinary},
+		"Test Positive-2": {mode: ModeStructured},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			var header http.Header
+			var body []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				header = req.Header
+				body, _ = io.ReadAll(req.Body)
+			}))
+			defer server.Close()
+
+			publisher, err := NewPublisher(server.URL, mock.mode)
+			if err != nil {
+				t.Fatalf("Test %q failed: expected error to be nil, received %v", name, err)
+			}
+			event := NewEngineEvent(TypeEngineCompleted, engine, "")
+			if err := publisher.Send(context.TODO(), event); err != nil {
+				t.Fatalf("Test %q failed: expected error to be nil, received %v", name, err)
+			}
+
+			data := EngineData{}
+			if mock.mode == ModeBinary {
+				if header.Get("ce-type") != TypeEngineCompleted || header.Get("ce-id") != event.ID || header.Get("ce-subject") != "default/nginx-chaos" {
+					t.Fatalf("Test %q failed: unexpected cloudevent headers %v", name, header)
+				}
+				if err := json.Unmarshal(body, &data); err != nil {
+					t.Fatalf("Test %q failed: invalid data %q", name, body)
+				}
+			} else {
+				received := Event{}
+				if err := json.Unmarshal(body, &received); err != nil || received.Type != TypeEngineCompleted || received.SpecVersion != SpecVersion {
+					t.Fatalf("Test %q failed: unexpected structured cloudevent %q", name, body)
+				}
+				if err := json.Unmarshal(received.Data, &data); err != nil {
+					t.Fatalf("Test %q failed: invalid data %q", name, received.Data)
+				}
+			}
+			if data.Engine != "nginx-chaos" || data.EngineStatus != "completed" {
+				t.Fatalf("Test %q failed: unexpected event data %+v", name, data)
+			}
+		})
+	}
+}
+
+func TestPublish(t *testing.T) {
+	received := make(chan string, 10)
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		received <- req.Header.Get("ce-type")
+	}))
+	defer server.Close()
+
+	publisher, err := NewPublisher(server.URL, ModeBinary)
+	if err != nil {
+		t.Fatalf("Test failed: expected error to be nil, received %v", err)
+	}
+	publisher.InitialDelay = time.Millisecond
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	go func() { _ = publisher.Start(ctx) }()
+
+	publisher.Publish(NewEngineEvent(TypeEngineInitialized, engine, ""))
+	select {
+	case eventType := <-received:
+		if eventType != TypeEngineInitialized {
+			t.Fatalf("Test failed: unexpected event type %q", eventType)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Test failed: expected the failed delivery to be retried")
+	}
+
+	// nil publisher, when the cloudevents are disabled
+	var disabled *Publisher
+	disabled.Publish(NewEngineEvent(TypeEngineInitialized, engine, ""))
+}
+
+func TestPublishDropped(t *testing.T) {
+	publisher, err := NewPublisher("http://127.0.0.1:0", ModeBinary)
+	if err != nil {
+		t.Fatalf("Test failed: expected error to be nil, received %v", err)
+	}
+	publisher.queue = make(chan delivery, 1)
+
+	before := testutil.ToFloat64(metrics.CloudEventsDropped.WithLabelValues(TypeEngineAborted, dropReasonQueueFull))
+	publisher.Publish(NewEngineEvent(TypeEngineAborted, engine, ""))
+	publisher.Publish(NewEngineEvent(TypeEngineAborted, engine, ""))
+	after := testutil.ToFloat64(metrics.CloudEventsDropped.WithLabelValues(TypeEngineAborted, dropReasonQueueFull))
+	if after-before != 1 {
+		t.Fatalf("Test failed: expected 1 dropped event, received %v", after-before)
+	}
+}
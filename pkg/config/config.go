@@ -0,0 +1,299 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package config loads the versioned configuration file of the chaos-operator
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/litmuschaos/chaos-operator/pkg/retention"
+	chaosTypes "github.com/litmuschaos/chaos-operator/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// APIVersion is the supported apiVersion of the configuration file
+	APIVersion = "litmuschaos.io/v1alpha1"
+	// Kind is the supported kind of the configuration file
+	Kind = "OperatorConfig"
+
+	defaultMaxConcurrentReconciles    = 1
+	defaultChaosPodTerminationTimeout = 3 * time.Minute
+	defaultReconcileTimeout           = 5 * time.Minute
+	defaultReadTimeout                = 30 * time.Second
+	defaultWriteTimeout               = 30 * time.Second
+	defaultRateLimiterBaseDelay       = 5 * time.Millisecond
+	defaultRateLimiterMaxDelay        = 1000 * time.Second
+	defaultRateLimiterQPS             = 10
+	defaultRateLimiterBurst           = 100
+	defaultGCInterval                 = 10 * time.Minute
+	defaultNotifierTimeout            = 10 * time.Second
+	defaultNotifierInitialDelay       = time.Second
+	defaultNotifierMaxDelay           = 30 * time.Second
+	defaultNotifierMaxRetries         = 5
+	defaultMetricsBindAddress         = ":8080"
+	defaultHealthProbeBindAddress     = ":8081"
+)
+
+// OperatorConfig is the configuration of the chaos-operator.
+// The runner, timeout, retention and notifier settings are applied on the reload of the file,
+// the concurrency and the metrics settings are only applied at the startup
+type OperatorConfig struct {
+	metav1.TypeMeta `json:",inline"`
+	// Runner contains the defaults of the chaos-runner pods
+	Runner RunnerConfig `json:"runner,omitempty"`
+	// Controller contains the concurrency and the timeouts of the reconciles
+	Controller ControllerConfig `json:"controller,omitempty"`
+	// ChaosResultRetention contains the retention policy of the chaosresults
+	ChaosResultRetention RetentionConfig `json:"chaosResultRetention,omitempty"`
+	// Notifier contains the delivery settings of the chaosengine notifiers
+	Notifier NotifierConfig `json:"notifier,omitempty"`
+	// Metrics contains the addresses of the metrics and the health probe endpoints
+	Metrics MetricsConfig `json:"metrics,omitempty"`
+}
+
+// RunnerConfig contains the defaults of the chaos-runner pods, used if the chaosengine does not specify them
+type RunnerConfig struct {
+	// Image of the runner, it defaults to the CHAOS_RUNNER_IMAGE env
+	Image string `json:"image,omitempty"`
+	// ImagePullPolicy of the runner, it defaults to IfNotPresent
+	ImagePullPolicy corev1.PullPolicy `json:"imagePullPolicy,omitempty"`
+	// Resources of the runner container
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+}
+
+// ControllerConfig contains the concurrency and the timeouts of the reconciles
+type ControllerConfig struct {
+	// MaxConcurrentReconciles is the number of chaosengines reconciled in parallel
+	MaxConcurrentReconciles int `json:"maxConcurrentReconciles,omitempty"`
+	// ChaosPodTerminationTimeout is the duration for which the termination of the chaos pods is awaited on an abort
+	ChaosPodTerminationTimeout metav1.Duration `json:"chaosPodTerminationTimeout,omitempty"`
+	// Timeouts bound the reconciles and the api calls made by them
+	Timeouts TimeoutsConfig `json:"timeouts,omitempty"`
+	// RateLimiter limits the requeues of the reconciles
+	RateLimiter RateLimiterConfig `json:"rateLimiter,omitempty"`
+}
+
+// RateLimiterConfig contains the limits of the requeues, the larger of the per-item and the overall delay is applied.
+// The defaults are the ones of the controller-runtime
+type RateLimiterConfig struct {
+	// BaseDelay is the delay of the first requeue of a failed item, it is doubled on every consecutive failure
+	BaseDelay metav1.Duration `json:"baseDelay,omitempty"`
+	// MaxDelay caps the per-item delay
+	MaxDelay metav1.Duration `json:"maxDelay,omitempty"`
+	// QPS is the overall rate of the requeues across all the items
+	QPS float64 `json:"qps,omitempty"`
+	// Burst is the number of requeues allowed beyond the overall rate
+	Burst int `json:"burst,omitempty"`
+}
+
+// TimeoutsConfig contains the deadlines of the reconciles and of the api calls, a timed out call fails the reconcile
+// which is then requeued
+type TimeoutsConfig struct {
+	// Reconcile bounds a complete reconcile of a chaosengine or a chaosresult
+	Reconcile metav1.Duration `json:"reconcile,omitempty"`
+	// Read bounds each get and list call
+	Read metav1.Duration `json:"read,omitempty"`
+	// Write bounds each create, update, patch and delete call
+	Write metav1.Duration `json:"write,omitempty"`
+}
+
+// RetentionConfig contains the retention policy of the chaosresults, a zero value of any of the limits disables that limit
+type RetentionConfig struct {
+	MaxAge                metav1.Duration `json:"maxAge,omitempty"`
+	MaxCountPerExperiment int             `json:"maxCountPerExperiment,omitempty"`
+	MaxCountPerEngine     int             `json:"maxCountPerEngine,omitempty"`
+	KeepLastFailures      int             `json:"keepLastFailures,omitempty"`
+	// GCInterval is the interval between two chaosresult garbage collection runs
+	GCInterval metav1.Duration `json:"gcInterval,omitempty"`
+}
+
+// NotifierConfig contains the delivery settings of the chaosengine notifiers
+type NotifierConfig struct {
+	// Timeout of a webhook request
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+	// InitialDelay is the delay before the first retry, it is doubled on every retry till MaxDelay
+	InitialDelay metav1.Duration `json:"initialDelay,omitempty"`
+	MaxDelay     metav1.Duration `json:"maxDelay,omitempty"`
+	// MaxRetries bounds the retries of the notifiers of the chaosengines
+	MaxRetries int `json:"maxRetries,omitempty"`
+	// AllowedHosts are the hosts of the webhooks which can be notified, a host prefixed with "*." also allows
+	// its subdomains. All the hosts are allowed if it is empty
+	AllowedHosts []string `json:"allowedHosts,omitempty"`
+	// AllowPrivateNetworks allows the webhooks on the loopback, private and link-local addresses,
+	// which are denied by default
+	AllowPrivateNetworks bool `json:"allowPrivateNetworks,omitempty"`
+}
+
+// MetricsConfig contains the addresses of the metrics and the health probe endpoints
+type MetricsConfig struct {
+	BindAddress            string `json:"bindAddress,omitempty"`
+	HealthProbeBindAddress string `json:"healthProbeBindAddress,omitempty"`
+}
+
+// Default returns the configuration used without a configuration file
+func Default() *OperatorConfig {
+	config := &OperatorConfig{TypeMeta: metav1.TypeMeta{APIVersion: APIVersion, Kind: Kind}}
+	config.SetDefaults()
+	return config
+}
+
+// Load reads, defaults and validates the configuration file
+func Load(path string) (*OperatorConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the config file %v, due to error: %v", path, err)
+	}
+	return Parse(data)
+}
+
+// Parse decodes, defaults and validates the configuration, rejecting the unknown fields
+func Parse(data []byte) (*OperatorConfig, error) {
+	config := &OperatorConfig{}
+	if err := yaml.UnmarshalStrict(data, config); err != nil {
+		return nil, fmt.Errorf("unable to parse the config, due to error: %v", err)
+	}
+	config.SetDefaults()
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// SetDefaults sets the defaults of the unset fields
+func (config *OperatorConfig) SetDefaults() {
+	if config.Runner.Image == "" {
+		config.Runner.Image = os.Getenv("CHAOS_RUNNER_IMAGE")
+	}
+	if config.Runner.Image == "" {
+		config.Runner.Image = chaosTypes.DefaultChaosRunnerImage
+	}
+	if config.Runner.ImagePullPolicy == "" {
+		config.Runner.ImagePullPolicy = corev1.PullIfNotPresent
+	}
+	if config.Controller.MaxConcurrentReconciles == 0 {
+		config.Controller.MaxConcurrentReconciles = defaultMaxConcurrentReconciles
+	}
+	setDefaultDuration(&config.Controller.ChaosPodTerminationTimeout, defaultChaosPodTerminationTimeout)
+	setDefaultDuration(&config.Controller.Timeouts.Reconcile, defaultReconcileTimeout)
+	setDefaultDuration(&config.Controller.Timeouts.Read, defaultReadTimeout)
+	setDefaultDuration(&config.Controller.Timeouts.Write, defaultWriteTimeout)
+	setDefaultDuration(&config.Controller.RateLimiter.BaseDelay, defaultRateLimiterBaseDelay)
+	setDefaultDuration(&config.Controller.RateLimiter.MaxDelay, defaultRateLimiterMaxDelay)
+	if config.Controller.RateLimiter.QPS == 0 {
+		config.Controller.RateLimiter.QPS = defaultRateLimiterQPS
+	}
+	if config.Controller.RateLimiter.Burst == 0 {
+		config.Controller.RateLimiter.Burst = defaultRateLimiterBurst
+	}
+	setDefaultDuration(&config.ChaosResultRetention.GCInterval, defaultGCInterval)
+	setDefaultDuration(&config.Notifier.Timeout, defaultNotifierTimeout)
+	setDefaultDuration(&config.Notifier.InitialDelay, defaultNotifierInitialDelay)
+	setDefaultDuration(&config.Notifier.MaxDelay, defaultNotifierMaxDelay)
+	if config.Notifier.MaxRetries == 0 {
+		config.Notifier.MaxRetries = defaultNotifierMaxRetries
+	}
+	if config.Metrics.BindAddress == "" {
+		config.Metrics.BindAddress = defaultMetricsBindAddress
+	}
+	if config.Metrics.HealthProbeBindAddress == "" {
+		config.Metrics.HealthProbeBindAddress = defaultHealthProbeBindAddress
+	}
+}
+
+func setDefaultDuration(duration *metav1.Duration, value time.Duration) {
+	if duration.Duration == 0 {
+		duration.Duration = value
+	}
+}
+
+// Validate returns an error if the configuration is invalid
+func (config *OperatorConfig) Validate() error {
+	if config.APIVersion != APIVersion || config.Kind != Kind {
+		return fmt.Errorf("unsupported config %v/%v, expected apiVersion %v and kind %v", config.APIVersion, config.Kind, APIVersion, Kind)
+	}
+	switch config.Runner.ImagePullPolicy {
+	case corev1.PullAlways, corev1.PullIfNotPresent, corev1.PullNever:
+	default:
+		return fmt.Errorf("unsupported runner.imagePullPolicy %q", config.Runner.ImagePullPolicy)
+	}
+	if config.Controller.MaxConcurrentReconciles < 0 {
+		return fmt.Errorf("controller.maxConcurrentReconciles should not be negative")
+	}
+	for name, count := range map[string]int{
+		"chaosResultRetention.maxCountPerExperiment": config.ChaosResultRetention.MaxCountPerExperiment,
+		"chaosResultRetention.maxCountPerEngine":     config.ChaosResultRetention.MaxCountPerEngine,
+		"chaosResultRetention.keepLastFailures":      config.ChaosResultRetention.KeepLastFailures,
+		"controller.rateLimiter.burst":               config.Controller.RateLimiter.Burst,
+		"notifier.maxRetries":                        config.Notifier.MaxRetries,
+	} {
+		if count < 0 {
+			return fmt.Errorf("%v should not be negative", name)
+		}
+	}
+	for name, duration := range map[string]metav1.Duration{
+		"controller.chaosPodTerminationTimeout": config.Controller.ChaosPodTerminationTimeout,
+		"controller.timeouts.reconcile":         config.Controller.Timeouts.Reconcile,
+		"controller.timeouts.read":              config.Controller.Timeouts.Read,
+		"controller.timeouts.write":             config.Controller.Timeouts.Write,
+		"controller.rateLimiter.baseDelay":      config.Controller.RateLimiter.BaseDelay,
+		"controller.rateLimiter.maxDelay":       config.Controller.RateLimiter.MaxDelay,
+		"chaosResultRetention.maxAge":           config.ChaosResultRetention.MaxAge,
+		"chaosResultRetention.gcInterval":       config.ChaosResultRetention.GCInterval,
+		"notifier.timeout":                      config.Notifier.Timeout,
+		"notifier.initialDelay":                 config.Notifier.InitialDelay,
+		"notifier.maxDelay":                     config.Notifier.MaxDelay,
+	} {
+		if duration.Duration < 0 {
+			return fmt.Errorf("%v should not be negative", name)
+		}
+	}
+	if config.Controller.RateLimiter.QPS < 0 {
+		return fmt.Errorf("controller.rateLimiter.qps should not be negative")
+	}
+	if config.Controller.RateLimiter.BaseDelay.Duration > config.Controller.RateLimiter.MaxDelay.Duration {
+		return fmt.Errorf("controller.rateLimiter.baseDelay should not be greater than controller.rateLimiter.maxDelay")
+	}
+	// the termination of the chaos pods is awaited within a reconcile
+	if config.Controller.ChaosPodTerminationTimeout.Duration >= config.Controller.Timeouts.Reconcile.Duration {
+		return fmt.Errorf("controller.chaosPodTerminationTimeout should be less than controller.timeouts.reconcile")
+	}
+	if config.Notifier.InitialDelay.Duration > config.Notifier.MaxDelay.Duration {
+		return fmt.Errorf("notifier.initialDelay should not be greater than notifier.maxDelay")
+	}
+	for _, host := range config.Notifier.AllowedHosts {
+		if host == "" || strings.ContainsAny(host, "/:") {
+			return fmt.Errorf("notifier.allowedHosts should contain host names, received %q", host)
+		}
+	}
+	return nil
+}
+
+// Policy returns the retention policy of the chaosresults
+func (retentionConfig RetentionConfig) Policy() retention.Policy {
+	return retention.Policy{
+		MaxAge:                retentionConfig.MaxAge.Duration,
+		MaxCountPerExperiment: retentionConfig.MaxCountPerExperiment,
+		MaxCountPerEngine:     retentionConfig.MaxCountPerEngine,
+		KeepLastFailures:      retentionConfig.KeepLastFailures,
+	}
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2019 LitmusChaos Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+   http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const validConfig = `
+apiVersion: litmuschaos.io/v1alpha1
+kind: OperatorConfig
+runner:
+  image: litmuschaos/chaos-runner:2.0.0
+  resources:
+    limits:
+      cpu: 100m
+controller:
+  maxConcurrentReconciles: 4
+  chaosPodTerminationTimeout: 1m
+  timeouts:
+    read: 10s
+chaosResultRetention:
+  maxAge: 24h
+  maxCountPerExperiment: 10
+notifier:
+  initialDelay: 2s
+  allowedHosts:
+  - hooks.slack.com
+  - "*.pagerduty.com"
+metrics:
+  bindAddress: ":9090"
+`
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		data  string
+		isErr bool
+	}{
+		"Test Positive-1": {
+			data: validConfig,
+		},
+		"Test Positive-2": {
+			data: "apiVersion: litmuschaos.io/v1alpha1\nkind: OperatorConfig\n",
+		},
+		"Test Negative-1": {
+			data:  "apiVersion: litmuschaos.io/v1beta1\nkind: OperatorConfig\n",
+			isErr: true,
+		},
+		"Test Negative-2": {
+			data:  "apiVersion: litmuschaos.io/v1alpha1\nkind: OperatorConfig\nrunner:\n  imag: litmuschaos/chaos-runner:2.0.0\n",
+			isErr: true,
+		},
+		"Test Negative-3": {
+			data:  "apiVersion: litmuschaos.io/v1alpha1\nkind: OperatorConfig\nrunner:\n  imagePullPolicy: Sometimes\n",
+			isErr: true,
+		},
+		"Test Negative-4": {
+			data:  "apiVersion: litmuschaos.io/v1alpha1\nkind: OperatorConfig\nchaosResultRetention:\n  maxCountPerEngine: -1\n",
+			isErr: true,
+		},
+		"Test Negative-5": {
+			data:  "apiVersion: litmuschaos.io/v1alpha1\nkind: OperatorConfig\nnotifier:\n  initialDelay: 1m\n  maxDelay: 1s\n",
+			isErr: true,
+		},
+		"Test Negative-6": {
+			data:  "apiVersion: litmuschaos.io/v1alpha1\nkind: OperatorConfig\ncontroller:\n  chaosPodTerminationTimeout: 10m\n",
+			isErr: true,
+		},
+		"Test Negative-7": {
+			data:  "apiVersion: litmuschaos.io/v1alpha1\nkind: OperatorConfig\ncontroller:\n  timeouts:\n    write: -1s\n",
+			isErr: true,
+		},
+		"Test Negative-8": {
+			data:  "apiVersion: litmuschaos.io/v1alpha1\nkind: OperatorConfig\nnotifier:\n  maxRetries: -1\n",
+			isErr: true,
+		},
+		"Test Negative-9": {
+			data:  "apiVersion: litmuschaos.io/v1alpha1\nkind: OperatorConfig\nnotifier:\n  allowedHosts:\n  - https://hooks.slack.com\n",
+			isErr: true,
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse([]byte(mock.data))
+			if mock.isErr && err == nil {
+				t.Fatalf("Test %q failed: expected error not to be nil", name)
+			}
+			if !mock.isErr && err != nil {
+				t.Fatalf("Test %q failed: expected error to be nil, received %v", name, err)
+			}
+		})
+	}
+}
+
+func TestParseDefaults(t *testing.T) {
+	config, err := Parse([]byte(validConfig))
+	if err != nil {
+		t.Fatalf("unable to parse the config, due to error: %v", err)
+	}
+	if config.Runner.Image != "litmuschaos/chaos-runner:2.0.0" || config.Runner.ImagePullPolicy != corev1.PullIfNotPresent {
+		t.Fatalf("unexpected runner config %+v", config.Runner)
+	}
+	if config.Runner.Resources.Limits.Cpu().String() != "100m" {
+		t.Fatalf("expected the runner cpu limit 100m, received %v", config.Runner.Resources.Limits.Cpu())
+	}
+	if config.Controller.ChaosPodTerminationTimeout.Duration != time.Minute {
+		t.Fatalf("expected the chaos pod termination timeout 1m, received %v", config.Controller.ChaosPodTerminationTimeout.Duration)
+	}
+	if timeouts := config.Controller.Timeouts; timeouts.Read.Duration != 10*time.Second || timeouts.Write.Duration != defaultWriteTimeout || timeouts.Reconcile.Duration != defaultReconcileTimeout {
+		t.Fatalf("unexpected controller timeouts %+v", timeouts)
+	}
+	if policy := config.ChaosResultRetention.Policy(); policy.MaxAge != 24*time.Hour || policy.MaxCountPerExperiment != 10 {
+		t.Fatalf("unexpected retention policy %+v", policy)
+	}
+	if config.Notifier.InitialDelay.Duration != 2*time.Second || config.Notifier.MaxDelay.Duration != defaultNotifierMaxDelay {
+		t.Fatalf("unexpected notifier config %+v", config.Notifier)
+	}
+	if config.Metrics.BindAddress != ":9090" || config.Metrics.HealthProbeBindAddress != defaultHealthProbeBindAddress {
+		t.Fatalf("unexpected metrics config %+v", config.Metrics)
+	}
+}
+
+func TestWatcherReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig := func(data string) {
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatalf("unable to write the config file, due to error: %v", err)
+		}
+	}
+	writeConfig(validConfig)
+
+	watcher := &Watcher{Path: path, Override: func(config *OperatorConfig) {
+		config.ChaosResultRetention.KeepLastFailures = 3
+	}}
+	config, err := watcher.Load()
+	if err != nil {
+		t.Fatalf("unable to load the config file, due to error: %v", err)
+	}
+	watcher.Store = NewStore(config)
+
+	// the runner image is reloaded, the concurrency and metrics settings are retained
+	writeConfig("apiVersion: litmuschaos.io/v1alpha1\nkind: OperatorConfig\nrunner:\n  image: litmuschaos/chaos-runner:2.1.0\ncontroller:\n  maxConcurrentReconciles: 8\n")
+	if err := watcher.Reload(); err != nil {
+		t.Fatalf("unable to reload the config file, due to error: %v", err)
+	}
+	reloaded := watcher.Store.Get()
+	if reloaded.Runner.Image != "litmuschaos/chaos-runner:2.1.0" {
+		t.Fatalf("expected the reloaded runner image, received %v", reloaded.Runner.Image)
+	}
+	if reloaded.Controller.MaxConcurrentReconciles != 4 || reloaded.Metrics.BindAddress != ":9090" {
+		t.Fatalf("expected the startup settings to be retained, received %v and %v", reloaded.Controller.MaxConcurrentReconciles, reloaded.Metrics.BindAddress)
+	}
+	if reloaded.ChaosResultRetention.KeepLastFailures != 3 {
+		t.Fatalf("expected the override to be applied on the reload, received %v", reloaded.ChaosResultRetention.KeepLastFailures)
+	}
+
+	// an invalid config is rejected, retaining the current one
+	writeConfig("apiVersion: litmuschaos.io/v1alpha1\nkind: OperatorConfig\nrunner:\n  imagePullPolicy: Sometimes\n")
+	if err := watcher.Reload(); err == nil {
+		t.Fatalf("expected the reload of the invalid config to fail")
+	}
+	if watcher.Store.Get() != reloaded {
+		t.Fatalf("expected the current config to be retained")
+	}
+}
+
+func TestNilStore(t *testing.T) {
+	var store *Store
+	if config := store.Get(); config.Controller.MaxConcurrentReconciles != defaultMaxConcurrentReconciles {
+		t.Fatalf("expected the default config from a nil store, received %+v", config.Controller)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	config, err := Parse([]byte(validConfig))
+	if err != nil {
+		t.Fatalf("unable to parse the config, due to error: %v", err)
+	}
+	handler := &Handler{Store: NewStore(config)}
+
+	tests := map[string]struct {
+		method string
+		status int
+	}{
+		"Test Positive-1": {
+			method: http.MethodGet,
+			status: http.StatusOK,
+		},
+		"Test Negative-1": {
+			method: http.MethodPost,
+			status: http.StatusMethodNotAllowed,
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(mock.method, HandlerPath, nil))
+			if recorder.Code != mock.status {
+				t.Fatalf("Test %q failed: expected status %v, received %v", name, mock.status, recorder.Code)
+			}
+			if mock.status != http.StatusOK {
+				return
+			}
+			served := &OperatorConfig{}
+			if err := json.Unmarshal(recorder.Body.Bytes(), served); err != nil {
+				t.Fatalf("Test %q failed: unable to decode the served config, due to error: %v", name, err)
+			}
+			if served.Runner.Image != config.Runner.Image || served.Kind != Kind {
+				t.Fatalf("Test %q failed: unexpected served config %+v", name, served)
+			}
+		})
+	}
+}
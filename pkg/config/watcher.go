@@ -0,0 +1,181 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/litmuschaos/chaos-operator/pkg/metrics"
+	chaosTypes "github.com/litmuschaos/chaos-operator/pkg/types"
+)
+
+// HandlerPath is the path on which the effective configuration is served
+const HandlerPath = "/debug/config"
+
+// reload results of the config reloads metric
+const (
+	reloadSucceeded = "success"
+	reloadFailed    = "failure"
+)
+
+// Store holds the effective configuration, which is replaced on the reload of the file
+type Store struct {
+	value atomic.Value
+}
+
+// NewStore returns a store holding the given configuration
+func NewStore(config *OperatorConfig) *Store {
+	store := &Store{}
+	store.value.Store(config)
+	return store
+}
+
+// Get returns the effective configuration, or the defaults on a nil store.
+// The returned configuration is shared, it must not be modified
+func (store *Store) Get() *OperatorConfig {
+	if store == nil {
+		return Default()
+	}
+	return store.value.Load().(*OperatorConfig)
+}
+
+// Watcher reloads the configuration file into the store on its changes.
+// An invalid configuration is rejected, retaining the previous one
+type Watcher struct {
+	// Path of the configuration file
+	Path string
+	// Store receives the reloaded configuration
+	Store *Store
+	// Override is applied on every loaded configuration, e.g. to retain the explicitly set flags
+	Override func(*OperatorConfig)
+}
+
+// Load reads the configuration file and applies the overrides
+func (watcher *Watcher) Load() (*OperatorConfig, error) {
+	config, err := Load(watcher.Path)
+	if err != nil {
+		return nil, err
+	}
+	if watcher.Override != nil {
+		watcher.Override(config)
+		if err := config.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return config, nil
+}
+
+// Reload loads the configuration file into the store, the changes of the
+// settings which are only applied at the startup are logged and retained
+func (watcher *Watcher) Reload() error {
+	config, err := watcher.Load()
+	if err != nil {
+		metrics.ConfigReloads.WithLabelValues(reloadFailed).Inc()
+		return err
+	}
+
+	current := watcher.Store.Get()
+	if config.Controller.MaxConcurrentReconciles != current.Controller.MaxConcurrentReconciles ||
+		config.Controller.RateLimiter != current.Controller.RateLimiter || config.Metrics != current.Metrics {
+		chaosTypes.Log.Info("the concurrency, the rate limiter and the metrics settings are applied after a restart of the operator")
+		config.Controller.MaxConcurrentReconciles = current.Controller.MaxConcurrentReconciles
+		config.Controller.RateLimiter = current.Controller.RateLimiter
+		config.Metrics = current.Metrics
+	}
+	watcher.Store.value.Store(config)
+	metrics.ConfigReloads.WithLabelValues(reloadSucceeded).Inc()
+	return nil
+}
+
+// Start reloads the configuration on the changes of the file till the context is cancelled.
+// The directory of the file is watched, as a mounted configmap is updated by swapping a symlink
+func (watcher *Watcher) Start(ctx context.Context) error {
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("unable to watch the config file, due to error: %v", err)
+	}
+	defer fsWatcher.Close()
+
+	dir := filepath.Dir(watcher.Path)
+	if err := fsWatcher.Add(dir); err != nil {
+		return fmt.Errorf("unable to watch the config directory %v, due to error: %v", dir, err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-fsWatcher.Events:
+			if !ok {
+				return nil
+			}
+			if !watcher.isRelevant(event) {
+				continue
+			}
+			if err := watcher.Reload(); err != nil {
+				chaosTypes.Log.Error(err, "unable to reload the config file, retaining the current config", "path", watcher.Path)
+				continue
+			}
+			chaosTypes.Log.Info("reloaded the config file", "path", watcher.Path)
+		case err, ok := <-fsWatcher.Errors:
+			if !ok {
+				return nil
+			}
+			chaosTypes.Log.Error(err, "unable to watch the config file", "path", watcher.Path)
+		}
+	}
+}
+
+// NeedLeaderElection makes the config reload on all the replicas
+func (watcher *Watcher) NeedLeaderElection() bool {
+	return false
+}
+
+// isRelevant returns true for the changes of the file, or of the ..data symlink of a mounted configmap
+func (watcher *Watcher) isRelevant(event fsnotify.Event) bool {
+	if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename) == 0 {
+		return false
+	}
+	name := filepath.Base(event.Name)
+	return name == filepath.Base(watcher.Path) || name == "..data"
+}
+
+// Handler serves the effective configuration as json
+type Handler struct {
+	Store *Store
+}
+
+// ServeHTTP serves the effective configuration
+func (handler *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := json.MarshalIndent(handler.Store.Get(), "", "  ")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
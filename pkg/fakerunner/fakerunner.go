@@ -0,0 +1,314 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fakerunner simulates the chaos-runner without injecting any chaos. It reads the env
+// which the operator sets on the runner pod, reports the experiments of the chaosengine as running,
+// and then writes the chaosresults and the experiment statuses of a scripted scenario
+package fakerunner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/chaos-operator/controllers"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientretry "k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ErrCrashed is returned by Run if the scenario crashes the runner once the experiments are running
+var ErrCrashed = errors.New("the fake-runner has crashed as per the scenario")
+
+// Env contains the env of the runner pod, as set by the operator
+type Env struct {
+	// EngineName is the name of the chaosengine, from CHAOSENGINE
+	EngineName string
+	// Namespace is the namespace of the chaosengine, from CHAOS_NAMESPACE
+	Namespace string
+	// Experiments are the experiments of the chaosengine, from EXPERIMENT_LIST
+	Experiments []string
+	// Targets are the encoded targets of the chaosengine, from TARGETS
+	Targets string
+}
+
+// EnvFrom reads the env of the runner pod through the lookup func, e.g. os.Getenv
+func EnvFrom(lookup func(string) string) (Env, error) {
+	env := Env{
+		EngineName: lookup("CHAOSENGINE"),
+		Namespace:  lookup("CHAOS_NAMESPACE"),
+		Targets:    lookup("TARGETS"),
+	}
+	if env.EngineName == "" || env.Namespace == "" {
+		return Env{}, fmt.Errorf("CHAOSENGINE and CHAOS_NAMESPACE env are required")
+	}
+	for _, exp := range strings.Split(lookup("EXPERIMENT_LIST"), ",") {
+		if exp = strings.TrimSpace(exp); exp != "" {
+			env.Experiments = append(env.Experiments, exp)
+		}
+	}
+	if len(env.Experiments) == 0 {
+		return Env{}, fmt.Errorf("EXPERIMENT_LIST env is empty")
+	}
+	return env, nil
+}
+
+// Scenario scripts the outcome of the simulated run
+type Scenario struct {
+	// Verdict is the verdict of the experiments which are not listed in ExperimentVerdicts
+	Verdict v1alpha1.ResultVerdict
+	// ExperimentVerdicts overrides the verdict of the individual experiments
+	ExperimentVerdicts map[string]v1alpha1.ResultVerdict
+	// Probes are the probe statuses reported for every experiment
+	Probes []v1alpha1.ProbeStatuses
+	// Targets are the <kind>/<name> targets annotated on the chaosresults,
+	// the named targets of the TARGETS env are used if it is empty
+	Targets []string
+	// TargetStatus is the chaos status annotated for the targets, i.e. injected, reverted or targeted,
+	// the targets are not annotated if it is empty
+	TargetStatus string
+	// Duration is the time for which the experiments stay running
+	Duration time.Duration
+	// Hang keeps the experiments running until the context is cancelled
+	Hang bool
+	// Crash returns ErrCrashed once the experiments are running, without completing them
+	Crash bool
+}
+
+// verdictOf returns the verdict of the experiment
+func (scenario Scenario) verdictOf(exp string) v1alpha1.ResultVerdict {
+	if verdict, found := scenario.ExperimentVerdicts[exp]; found {
+		return verdict
+	}
+	if scenario.Verdict == "" {
+		return v1alpha1.ResultVerdictPassed
+	}
+	return scenario.Verdict
+}
+
+// targetsOf returns the <kind>/<name> targets of the scenario, or else the named targets of the env
+// the label selectors of the env are skipped, as they don't name any target
+func (scenario Scenario) targetsOf(env Env) []string {
+	if len(scenario.Targets) != 0 {
+		return scenario.Targets
+	}
+	var targets []string
+	for _, target := range controllers.DecodeTargets(env.Targets) {
+		for _, name := range strings.Split(target.Filter, ",") {
+			if name = strings.TrimSpace(name); name != "" && !strings.Contains(name, "=") {
+				targets = append(targets, target.Kind+"/"+name)
+			}
+		}
+	}
+	return targets
+}
+
+// Run simulates the run of the experiments of the chaosengine as per the scenario
+func Run(ctx context.Context, c client.Client, env Env, scenario Scenario) error {
+	engine := &v1alpha1.ChaosEngine{}
+	if err := c.Get(ctx, types.NamespacedName{Name: env.EngineName, Namespace: env.Namespace}, engine); err != nil {
+		return fmt.Errorf("unable to get the chaosengine, due to error: %v", err)
+	}
+
+	for _, exp := range env.Experiments {
+		if err := writeResult(ctx, c, engine, exp, v1alpha1.TestStatus{Phase: v1alpha1.ResultPhaseRunning, Verdict: v1alpha1.ResultVerdictAwaited}, nil, nil); err != nil {
+			return err
+		}
+		if err := updateExperimentStatus(ctx, c, engine, exp, v1alpha1.ExperimentStatusRunning, v1alpha1.ResultVerdictAwaited); err != nil {
+			return err
+		}
+	}
+
+	if scenario.Crash {
+		return ErrCrashed
+	}
+	if scenario.Hang {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(scenario.Duration):
+	}
+
+	annotations := map[string]string{}
+	if scenario.TargetStatus != "" {
+		for _, target := range scenario.targetsOf(env) {
+			annotations[target] = scenario.TargetStatus
+		}
+	}
+	for _, exp := range env.Experiments {
+		verdict := scenario.verdictOf(exp)
+		if err := writeResult(ctx, c, engine, exp, testStatus(verdict, scenario.Probes), scenario.Probes, annotations); err != nil {
+			return err
+		}
+		if err := updateExperimentStatus(ctx, c, engine, exp, v1alpha1.ExperimentStatusCompleted, verdict); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// testStatus returns the experiment status of the chaosresult for the verdict and the probe statuses
+func testStatus(verdict v1alpha1.ResultVerdict, probes []v1alpha1.ProbeStatuses) v1alpha1.TestStatus {
+	status := v1alpha1.TestStatus{Phase: v1alpha1.ResultPhaseCompleted, Verdict: verdict}
+
+	passed, probeFailed := 0, false
+	for _, probe := range probes {
+		switch probe.Status.Verdict {
+		case v1alpha1.ProbeVerdictPassed:
+			passed++
+		case v1alpha1.ProbeVerdictFailed:
+			probeFailed = true
+		}
+	}
+	switch {
+	case len(probes) != 0:
+		status.ProbeSuccessPercentage = strconv.Itoa(passed * 100 / len(probes))
+	case verdict == v1alpha1.ResultVerdictPassed:
+		status.ProbeSuccessPercentage = "100"
+	default:
+		status.ProbeSuccessPercentage = "0"
+	}
+
+	switch verdict {
+	case v1alpha1.ResultVerdictFailed:
+		if probeFailed {
+			status.Phase = v1alpha1.ResultPhaseCompletedWithProbeFailure
+		}
+	case v1alpha1.ResultVerdictStopped:
+		status.Phase = v1alpha1.ResultPhaseStopped
+	case v1alpha1.ResultVerdictError:
+		status.Phase = v1alpha1.ResultPhaseError
+		status.ErrorOutput = &v1alpha1.ErrorOutput{ErrorCode: "GENERIC_ERROR", Reason: "simulated by the fake-runner"}
+	}
+	return status
+}
+
+// writeResult creates or updates the chaosresult of the experiment, named <engine>-<experiment> as by the experiments
+func writeResult(ctx context.Context, c client.Client, engine *v1alpha1.ChaosEngine, exp string, status v1alpha1.TestStatus, probes []v1alpha1.ProbeStatuses, annotations map[string]string) error {
+	name := engine.Name + "-" + exp
+	err := clientretry.RetryOnConflict(clientretry.DefaultRetry, func() error {
+		result := &v1alpha1.ChaosResult{}
+		err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: engine.Namespace}, result)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+		isFound := err == nil
+
+		result.Name, result.Namespace = name, engine.Namespace
+		if result.Labels == nil {
+			result.Labels = map[string]string{}
+		}
+		result.Labels["name"] = name
+		result.Labels["chaosUID"] = string(engine.UID)
+		result.Labels["app.kubernetes.io/component"] = "chaosresult"
+		result.Labels["app.kubernetes.io/part-of"] = "litmus"
+		for k, v := range annotations {
+			if result.Annotations == nil {
+				result.Annotations = map[string]string{}
+			}
+			result.Annotations[k] = v
+		}
+		result.Spec = v1alpha1.ChaosResultSpec{EngineName: engine.Name, ExperimentName: exp}
+		result.Status.ExperimentStatus = status
+		result.Status.ProbeStatuses = probes
+
+		if !isFound {
+			return c.Create(ctx, result)
+		}
+		return c.Update(ctx, result)
+	})
+	if err != nil {
+		return fmt.Errorf("unable to write the chaosresult %v, due to error: %v", name, err)
+	}
+	return nil
+}
+
+// updateExperimentStatus sets the status and the verdict of the experiment in the chaosengine status.
+// It updates the chaosengine along with its spec, as the chaos-runner does, so the status subresource drops
+// the change and the operator derives the experiment statuses from the chaosresults
+func updateExperimentStatus(ctx context.Context, c client.Client, engine *v1alpha1.ChaosEngine, exp string, status v1alpha1.ExperimentStatus, verdict v1alpha1.ResultVerdict) error {
+	err := clientretry.RetryOnConflict(clientretry.DefaultRetry, func() error {
+		if err := c.Get(ctx, client.ObjectKeyFromObject(engine), engine); err != nil {
+			return err
+		}
+		expStatus := v1alpha1.ExperimentStatuses{
+			Name:           exp,
+			Runner:         engine.Name + "-runner",
+			ExpPod:         engine.Name + "-" + exp,
+			Status:         status,
+			Verdict:        string(verdict),
+			LastUpdateTime: v1.Now(),
+		}
+		isFound := false
+		for i := range engine.Status.Experiments {
+			if engine.Status.Experiments[i].Name == exp {
+				// the result phase and the probe success percentage are mirrored by the operator
+				expStatus.ResultPhase = engine.Status.Experiments[i].ResultPhase
+				expStatus.ProbeSuccessPercentage = engine.Status.Experiments[i].ProbeSuccessPercentage
+				engine.Status.Experiments[i] = expStatus
+				isFound = true
+			}
+		}
+		if !isFound {
+			engine.Status.Experiments = append(engine.Status.Experiments, expStatus)
+		}
+		return c.Update(ctx, engine)
+	})
+	if err != nil {
+		return fmt.Errorf("unable to update the status of experiment %v, due to error: %v", exp, err)
+	}
+	return nil
+}
+
+// ParseVerdict parses the verdict of an experiment
+func ParseVerdict(value string) (v1alpha1.ResultVerdict, error) {
+	switch verdict := v1alpha1.ResultVerdict(value); verdict {
+	case v1alpha1.ResultVerdictPassed, v1alpha1.ResultVerdictFailed, v1alpha1.ResultVerdictStopped, v1alpha1.ResultVerdictError:
+		return verdict, nil
+	}
+	return "", fmt.Errorf("unsupported verdict %q, supported verdicts: %v, %v, %v, %v", value,
+		v1alpha1.ResultVerdictPassed, v1alpha1.ResultVerdictFailed, v1alpha1.ResultVerdictStopped, v1alpha1.ResultVerdictError)
+}
+
+// ParseProbe parses a probe status of the form <name>:<type>:<mode>:<verdict>
+func ParseProbe(value string) (v1alpha1.ProbeStatuses, error) {
+	fields := strings.Split(value, ":")
+	if len(fields) != 4 || fields[0] == "" {
+		return v1alpha1.ProbeStatuses{}, fmt.Errorf("invalid probe %q, expected <name>:<type>:<mode>:<verdict>", value)
+	}
+	verdict := v1alpha1.ProbeVerdict(fields[3])
+	switch verdict {
+	case v1alpha1.ProbeVerdictPassed, v1alpha1.ProbeVerdictFailed, v1alpha1.ProbeVerdictNA, v1alpha1.ProbeVerdictAwaited:
+	default:
+		return v1alpha1.ProbeStatuses{}, fmt.Errorf("unsupported probe verdict %q, supported verdicts: %v, %v, %v, %v", fields[3],
+			v1alpha1.ProbeVerdictPassed, v1alpha1.ProbeVerdictFailed, v1alpha1.ProbeVerdictNA, v1alpha1.ProbeVerdictAwaited)
+	}
+	return v1alpha1.ProbeStatuses{
+		Name:   fields[0],
+		Type:   fields[1],
+		Mode:   fields[2],
+		Status: v1alpha1.ProbeStatus{Verdict: verdict, Description: "simulated by the fake-runner"},
+	}, nil
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fakerunner
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// newFakeClient returns a fake client with an initialized chaosengine of the env
+func newFakeClient(t *testing.T, env Env) client.Client {
+	scheme := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("unable to build the scheme, due to error: %v", err)
+	}
+	engine := &v1alpha1.ChaosEngine{
+		ObjectMeta: v1.ObjectMeta{Name: env.EngineName, Namespace: env.Namespace, UID: "fake-uid"},
+		Status:     v1alpha1.ChaosEngineStatus{EngineStatus: v1alpha1.EngineStatusInitialized},
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&v1alpha1.ChaosEngine{}).WithObjects(engine).Build()
+}
+
+func TestEnvFrom(t *testing.T) {
+	tests := map[string]struct {
+		env      map[string]string
+		isErr    bool
+		expected []string
+	}{
+		"Test Positive-1": {
+			env:      map[string]string{"CHAOSENGINE": "nginx-chaos", "CHAOS_NAMESPACE": "default", "EXPERIMENT_LIST": "pod-delete, container-kill"},
+			expected: []string{"pod-delete", "container-kill"},
+		},
+		"Test Negative-1": {
+			env:   map[string]string{"CHAOS_NAMESPACE": "default", "EXPERIMENT_LIST": "pod-delete"},
+			isErr: true,
+		},
+		"Test Negative-2": {
+			env:   map[string]string{"CHAOSENGINE": "nginx-chaos", "CHAOS_NAMESPACE": "default", "EXPERIMENT_LIST": ""},
+			isErr: true,
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			env, err := EnvFrom(func(key string) string { return mock.env[key] })
+			if mock.isErr {
+				if err == nil {
+					t.Fatalf("Test %q failed: expected an error", name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Test %q failed: expected no error, received %v", name, err)
+			}
+			if len(env.Experiments) != len(mock.expected) {
+				t.Fatalf("Test %q failed: expected experiments %v, received %v", name, mock.expected, env.Experiments)
+			}
+			for i := range mock.expected {
+				if env.Experiments[i] != mock.expected[i] {
+					t.Fatalf("Test %q failed: expected experiments %v, received %v", name, mock.expected, env.Experiments)
+				}
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	env := Env{
+		EngineName:  "nginx-chaos",
+		Namespace:   "default",
+		Experiments: []string{"pod-delete", "container-kill"},
+		Targets:     "deployment:default:[nginx,app=nginx]",
+	}
+	tests := map[string]struct {
+		scenario        Scenario
+		verdicts        map[string]v1alpha1.ResultVerdict
+		phase           v1alpha1.ResultPhase
+		probePercentage string
+		annotations     map[string]string
+	}{
+		"Test Positive-1": {
+			scenario:        Scenario{TargetStatus: "reverted"},
+			verdicts:        map[string]v1alpha1.ResultVerdict{"pod-delete": v1alpha1.ResultVerdictPassed, "container-kill": v1alpha1.ResultVerdictPassed},
+			phase:           v1alpha1.ResultPhaseCompleted,
+			probePercentage: "100",
+			annotations:     map[string]string{"deployment/nginx": "reverted"},
+		},
+		"Test Positive-2": {
+			scenario: Scenario{
+				Verdict:            v1alpha1.ResultVerdictFailed,
+				ExperimentVerdicts: map[string]v1alpha1.ResultVerdict{"container-kill": v1alpha1.ResultVerdictPassed},
+				Probes: []v1alpha1.ProbeStatuses{
+					{Name: "check-frontend", Status: v1alpha1.ProbeStatus{Verdict: v1alpha1.ProbeVerdictPassed}},
+					{Name: "check-backend", Status: v1alpha1.ProbeStatus{Verdict: v1alpha1.ProbeVerdictFailed}},
+				},
+				Targets:      []string{"statefulset/mysql"},
+				TargetStatus: "injected",
+			},
+			verdicts:        map[string]v1alpha1.ResultVerdict{"pod-delete": v1alpha1.ResultVerdictFailed, "container-kill": v1alpha1.ResultVerdictPassed},
+			probePercentage: "50",
+			annotations:     map[string]string{"statefulset/mysql": "injected"},
+		},
+		"Test Positive-3": {
+			scenario:        Scenario{Verdict: v1alpha1.ResultVerdictError},
+			verdicts:        map[string]v1alpha1.ResultVerdict{"pod-delete": v1alpha1.ResultVerdictError, "container-kill": v1alpha1.ResultVerdictError},
+			phase:           v1alpha1.ResultPhaseError,
+			probePercentage: "0",
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := newFakeClient(t, env)
+			if err := Run(context.Background(), c, env, mock.scenario); err != nil {
+				t.Fatalf("Test %q failed: expected no error, received %v", name, err)
+			}
+
+			// the experiment statuses are dropped by the status subresource, as for the chaos-runner
+			engine := &v1alpha1.ChaosEngine{}
+			if err := c.Get(context.Background(), types.NamespacedName{Name: env.EngineName, Namespace: env.Namespace}, engine); err != nil {
+				t.Fatalf("Test %q failed: unable to get the chaosengine, due to error: %v", name, err)
+			}
+			if len(engine.Status.Experiments) != 0 {
+				t.Fatalf("Test %q failed: expected no experiment statuses, received %+v", name, engine.Status.Experiments)
+			}
+
+			for _, exp := range env.Experiments {
+				result := &v1alpha1.ChaosResult{}
+				if err := c.Get(context.Background(), types.NamespacedName{Name: env.EngineName + "-" + exp, Namespace: env.Namespace}, result); err != nil {
+					t.Fatalf("Test %q failed: unable to get the chaosresult, due to error: %v", name, err)
+				}
+				if result.Labels["chaosUID"] != "fake-uid" || result.Spec.EngineName != env.EngineName || result.Spec.ExperimentName != exp {
+					t.Fatalf("Test %q failed: unexpected chaosresult %+v", name, result.ObjectMeta)
+				}
+				if result.Status.ExperimentStatus.Verdict != mock.verdicts[exp] || result.Status.ExperimentStatus.ProbeSuccessPercentage != mock.probePercentage {
+					t.Fatalf("Test %q failed: unexpected experiment status %+v", name, result.Status.ExperimentStatus)
+				}
+				if mock.phase != "" && result.Status.ExperimentStatus.Phase != mock.phase {
+					t.Fatalf("Test %q failed: expected phase %v, received %v", name, mock.phase, result.Status.ExperimentStatus.Phase)
+				}
+				for k, v := range mock.annotations {
+					if result.Annotations[k] != v {
+						t.Fatalf("Test %q failed: expected annotation %v=%v, received %v", name, k, v, result.Annotations)
+					}
+				}
+				if len(result.Annotations) != len(mock.annotations) {
+					t.Fatalf("Test %q failed: expected annotations %v, received %v", name, mock.annotations, result.Annotations)
+				}
+			}
+		})
+	}
+}
+
+func TestRunCrash(t *testing.T) {
+	env := Env{EngineName: "nginx-chaos", Namespace: "default", Experiments: []string{"pod-delete"}}
+	c := newFakeClient(t, env)
+	if err := Run(context.Background(), c, env, Scenario{Crash: true}); !errors.Is(err, ErrCrashed) {
+		t.Fatalf("expected the crash error, received %v", err)
+	}
+
+	result := &v1alpha1.ChaosResult{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "nginx-chaos-pod-delete", Namespace: env.Namespace}, result); err != nil {
+		t.Fatalf("unable to get the chaosresult, due to error: %v", err)
+	}
+	if result.Status.ExperimentStatus.Phase != v1alpha1.ResultPhaseRunning || result.Status.ExperimentStatus.Verdict != v1alpha1.ResultVerdictAwaited {
+		t.Fatalf("expected the crashed experiment to stay running, received %+v", result.Status.ExperimentStatus)
+	}
+}
+
+func TestRunHang(t *testing.T) {
+	env := Env{EngineName: "nginx-chaos", Namespace: "default", Experiments: []string{"pod-delete"}}
+	c := newFakeClient(t, env)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := Run(ctx, c, env, Scenario{Hang: true}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the hang to last until the deadline, received %v", err)
+	}
+
+	result := &v1alpha1.ChaosResult{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "nginx-chaos-pod-delete", Namespace: env.Namespace}, result); err != nil {
+		t.Fatalf("unable to get the chaosresult, due to error: %v", err)
+	}
+	if result.Status.ExperimentStatus.Phase != v1alpha1.ResultPhaseRunning {
+		t.Fatalf("expected the experiment to stay running, received %+v", result.Status.ExperimentStatus)
+	}
+}
+
+func TestParseProbe(t *testing.T) {
+	tests := map[string]struct {
+		probe string
+		isErr bool
+	}{
+		"Test Positive-1": {probe: "check-frontend:httpProbe:Continuous:Passed"},
+		"Test Positive-2": {probe: "check-backend:cmdProbe:EOT:N/A"},
+		"Test Negative-1": {probe: "check-frontend:httpProbe:Continuous", isErr: true},
+		"Test Negative-2": {probe: "check-frontend:httpProbe:Continuous:Pass", isErr: true},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseProbe(mock.probe); (err != nil) != mock.isErr {
+				t.Fatalf("Test %q failed: expected error %v, received %v", name, mock.isErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package health contains the readiness and the liveness checks of the chaos-operator
+package health
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+)
+
+// syncCheckTimeout bounds the wait for the cache sync within a readiness check
+const syncCheckTimeout = time.Second
+
+// CRDs returns a readiness check which fails till all the given kinds are served by the apiserver
+func CRDs(mapper meta.RESTMapper, kinds ...schema.GroupVersionKind) healthz.Checker {
+	return func(_ *http.Request) error {
+		for _, gvk := range kinds {
+			if _, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+				if meta.IsNoMatchError(err) {
+					return fmt.Errorf("the %v CRD is not installed", gvk.GroupKind())
+				}
+				return fmt.Errorf("unable to discover the %v CRD, due to error: %v", gvk.GroupKind(), err)
+			}
+		}
+		return nil
+	}
+}
+
+// Syncer is implemented by the manager cache
+type Syncer interface {
+	WaitForCacheSync(ctx context.Context) bool
+}
+
+// CacheSynced returns a readiness check which fails till the informers of the cache have synced,
+// e.g. while the operator lacks the rbac to list the watched resources
+func CacheSynced(cache Syncer) healthz.Checker {
+	return func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), syncCheckTimeout)
+		defer cancel()
+		if !cache.WaitForCacheSync(ctx) {
+			return fmt.Errorf("the informer caches have not synced")
+		}
+		return nil
+	}
+}
+
+// Certificate returns a readiness check which fails if the PEM certificate at the given path is missing, or not valid at the moment
+func Certificate(path string) healthz.Checker {
+	return func(_ *http.Request) error {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("unable to read the certificate %v, due to error: %v", path, err)
+		}
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return fmt.Errorf("unable to decode the certificate %v", path)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return fmt.Errorf("unable to parse the certificate %v, due to error: %v", path, err)
+		}
+		if now := time.Now(); now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+			return fmt.Errorf("the certificate %v is valid only from %v to %v", path, cert.NotBefore, cert.NotAfter)
+		}
+		return nil
+	}
+}
+
+// LeaderElectionLease returns a readiness check which fails if the lease of the leader election cannot be read.
+// The standby replicas are ready as well, as long as they are able to take over the lease
+func LeaderElectionLease(reader client.Reader, namespace, name string) healthz.Checker {
+	return func(req *http.Request) error {
+		lease := &coordinationv1.Lease{}
+		if err := reader.Get(req.Context(), client.ObjectKey{Namespace: namespace, Name: name}, lease); err != nil && !k8serrors.IsNotFound(err) {
+			return fmt.Errorf("unable to get the leader election lease %v/%v, due to error: %v", namespace, name, err)
+		}
+		return nil
+	}
+}
+
+// Watchdog tracks the running reconciles, its liveness check fails if any of them has stalled
+type Watchdog struct {
+	// StallAfter returns the duration after which a running reconcile is considered as stalled, zero disables the check
+	StallAfter func() time.Duration
+
+	now     func() time.Time
+	mu      sync.Mutex
+	next    uint64
+	running map[uint64]time.Time
+}
+
+// NewWatchdog returns the watchdog of the reconciles
+func NewWatchdog(stallAfter func() time.Duration) *Watchdog {
+	return &Watchdog{
+		StallAfter: stallAfter,
+		now:        time.Now,
+		running:    map[uint64]time.Time{},
+	}
+}
+
+// Track records the start of a reconcile, the returned function records its end
+func (watchdog *Watchdog) Track() (done func()) {
+	if watchdog == nil {
+		return func() {}
+	}
+	watchdog.mu.Lock()
+	defer watchdog.mu.Unlock()
+	id := watchdog.next
+	watchdog.next++
+	watchdog.running[id] = watchdog.now()
+	return func() {
+		watchdog.mu.Lock()
+		defer watchdog.mu.Unlock()
+		delete(watchdog.running, id)
+	}
+}
+
+// Check is the liveness check of the watchdog
+func (watchdog *Watchdog) Check(_ *http.Request) error {
+	stallAfter := watchdog.StallAfter()
+	if stallAfter <= 0 {
+		return nil
+	}
+	watchdog.mu.Lock()
+	defer watchdog.mu.Unlock()
+	now := watchdog.now()
+	for _, start := range watchdog.running {
+		if elapsed := now.Sub(start); elapsed > stallAfter {
+			return fmt.Errorf("a reconcile has been running for %v, longer than %v", elapsed.Round(time.Second), stallAfter)
+		}
+	}
+	return nil
+}
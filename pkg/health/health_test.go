@@ -0,0 +1,222 @@
+/*
+Copyright 2019 LitmusChaos Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+   http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var (
+	engineGVK = schema.GroupVersionKind{Group: "litmuschaos.io", Version: "v1alpha1", Kind: "ChaosEngine"}
+	resultGVK = schema.GroupVersionKind{Group: "litmuschaos.io", Version: "v1alpha1", Kind: "ChaosResult"}
+)
+
+func TestCRDs(t *testing.T) {
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{engineGVK.GroupVersion()})
+	mapper.Add(engineGVK, meta.RESTScopeNamespace)
+
+	tests := map[string]struct {
+		kinds []schema.GroupVersionKind
+		isErr bool
+	}{
+		"Test Positive-1": {
+			kinds: []schema.GroupVersionKind{engineGVK},
+		},
+		"Test Negative-1": {
+			kinds: []schema.GroupVersionKind{engineGVK, resultGVK},
+			isErr: true,
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := CRDs(mapper, mock.kinds...)(httptest.NewRequest("GET", "/readyz", nil))
+			if mock.isErr && err == nil {
+				t.Fatalf("Test %q failed: expected error not to be nil", name)
+			}
+			if !mock.isErr && err != nil {
+				t.Fatalf("Test %q failed: expected error to be nil, received %v", name, err)
+			}
+		})
+	}
+}
+
+func TestCacheSynced(t *testing.T) {
+	tests := map[string]struct {
+		synced bool
+	}{
+		"Test Positive-1": {
+			synced: true,
+		},
+		"Test Negative-1": {
+			synced: false,
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			synced := mock.synced
+			err := CacheSynced(&informertest.FakeInformers{Synced: &synced})(httptest.NewRequest("GET", "/readyz", nil))
+			if (err == nil) != mock.synced {
+				t.Fatalf("Test %q failed: expected the synced cache to be ready, received %v", name, err)
+			}
+		})
+	}
+}
+
+// writeCertificate writes a self-signed certificate valid between the given times
+func writeCertificate(t *testing.T, path string, notBefore, notAfter time.Time) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unable to generate the key, due to error: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "chaos-operator-webhook"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("unable to create the certificate, due to error: %v", err)
+	}
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		t.Fatalf("unable to write the certificate, due to error: %v", err)
+	}
+}
+
+func TestCertificate(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	writeCertificate(t, filepath.Join(dir, "valid.crt"), now.Add(-time.Hour), now.Add(time.Hour))
+	writeCertificate(t, filepath.Join(dir, "expired.crt"), now.Add(-2*time.Hour), now.Add(-time.Hour))
+	if err := os.WriteFile(filepath.Join(dir, "invalid.crt"), []byte("not a certificate"), 0600); err != nil {
+		t.Fatalf("unable to write the certificate, due to error: %v", err)
+	}
+
+	tests := map[string]struct {
+		file  string
+		isErr bool
+	}{
+		"Test Positive-1": {
+			file: "valid.crt",
+		},
+		"Test Negative-1": {
+			file:  "expired.crt",
+			isErr: true,
+		},
+		"Test Negative-2": {
+			file:  "invalid.crt",
+			isErr: true,
+		},
+		"Test Negative-3": {
+			file:  "missing.crt",
+			isErr: true,
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := Certificate(filepath.Join(dir, mock.file))(httptest.NewRequest("GET", "/readyz", nil))
+			if mock.isErr && err == nil {
+				t.Fatalf("Test %q failed: expected error not to be nil", name)
+			}
+			if !mock.isErr && err != nil {
+				t.Fatalf("Test %q failed: expected error to be nil, received %v", name, err)
+			}
+		})
+	}
+}
+
+func TestLeaderElectionLease(t *testing.T) {
+	lease := &coordinationv1.Lease{ObjectMeta: metav1.ObjectMeta{Name: "chaos-operator.lock", Namespace: "litmus"}}
+	tests := map[string]struct {
+		reader client.Reader
+		isErr  bool
+	}{
+		"Test Positive-1": {
+			reader: fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(lease).Build(),
+		},
+		"Test Positive-2": {
+			// the lease is created by the first replica elected as the leader
+			reader: fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).Build(),
+		},
+		"Test Negative-1": {
+			reader: fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithInterceptorFuncs(interceptor.Funcs{
+				Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					return k8serrors.NewForbidden(coordinationv1.Resource("leases"), key.Name, nil)
+				},
+			}).Build(),
+			isErr: true,
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := LeaderElectionLease(mock.reader, lease.Namespace, lease.Name)(httptest.NewRequest("GET", "/readyz", nil))
+			if mock.isErr && err == nil {
+				t.Fatalf("Test %q failed: expected error not to be nil", name)
+			}
+			if !mock.isErr && err != nil {
+				t.Fatalf("Test %q failed: expected error to be nil, received %v", name, err)
+			}
+		})
+	}
+}
+
+func TestWatchdog(t *testing.T) {
+	now := time.Now()
+	watchdog := NewWatchdog(func() time.Duration { return time.Minute })
+	watchdog.now = func() time.Time { return now }
+	req := httptest.NewRequest("GET", "/healthz", nil)
+
+	done := watchdog.Track()
+	finished := watchdog.Track()
+	finished()
+	if err := watchdog.Check(req); err != nil {
+		t.Fatalf("expected the running reconcile to be live, received %v", err)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if err := watchdog.Check(req); err == nil {
+		t.Fatalf("expected the stalled reconcile to fail the liveness check")
+	}
+
+	done()
+	if err := watchdog.Check(req); err != nil {
+		t.Fatalf("expected the liveness check to pass after the reconcile, received %v", err)
+	}
+
+	var nilWatchdog *Watchdog
+	nilWatchdog.Track()()
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	// ResilienceScore contains the resilience score of the chaosengines
+	ResilienceScore = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "litmuschaos",
+		Name:      "engine_resilience_score",
+		Help:      "Weighted resilience score of the finished experiments of the chaosengine",
+	}, []string{"chaosengine_namespace", "chaosengine_name"})
+
+	// CloudEventsDropped contains the number of cloudevents dropped without being delivered to the sink
+	CloudEventsDropped = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "litmuschaos",
+		Name:      "cloudevents_dropped_total",
+		Help:      "Number of cloudevents dropped without being delivered to the sink",
+	}, []string{"type", "reason"})
+
+	// NotificationsDropped contains the number of chaosengine notifications dropped without being delivered to the notifier
+	NotificationsDropped = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "litmuschaos",
+		Name:      "notifications_dropped_total",
+		Help:      "Number of chaosengine notifications dropped without being delivered to the notifier",
+	}, []string{"event", "reason"})
+
+	// OwnedShards contains the number of shards owned by the operator replica, if the sharding is enabled
+	OwnedShards = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "litmuschaos",
+		Name:      "operator_owned_shards",
+		Help:      "Number of shards owned by the operator replica",
+	})
+
+	// ConfigReloads contains the number of reloads of the config file, by their result
+	ConfigReloads = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "litmuschaos",
+		Name:      "operator_config_reloads_total",
+		Help:      "Number of reloads of the config file of the operator, by their result",
+	}, []string{"result"})
+
+	// Timeouts contains the number of api calls and reconciles of the operator which have timed out
+	Timeouts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "litmuschaos",
+		Name:      "operator_timeouts_total",
+		Help:      "Number of api calls and reconciles of the operator which have timed out, by operation and kind",
+	}, []string{"operation", "kind"})
+)
+
+func init() {
+	// the metrics are served along with the controller-runtime metrics
+	crmetrics.Registry.MustRegister(ResilienceScore, CloudEventsDropped, NotificationsDropped, OwnedShards, ConfigReloads, Timeouts)
+}
+
+// SetResilienceScore updates the resilience score of the given chaosengine
+func SetResilienceScore(namespace, name string, score float64) {
+	ResilienceScore.WithLabelValues(namespace, name).Set(score)
+}
+
+// DeleteResilienceScore removes the resilience score of the given chaosengine
+func DeleteResilienceScore(namespace, name string) {
+	ResilienceScore.DeleteLabelValues(namespace, name)
+}
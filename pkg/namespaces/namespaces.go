@@ -0,0 +1,143 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package namespaces resolves the namespaces watched by the chaos-operator
+package namespaces
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	chaosTypes "github.com/litmuschaos/chaos-operator/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Parse returns the namespaces of the comma separated WATCH_NAMESPACE, without the duplicates.
+// No namespaces are returned, i.e. all the namespaces are watched, if it is empty
+func Parse(watchNamespace string) []string {
+	var namespaces []string
+	for _, namespace := range strings.Split(watchNamespace, ",") {
+		namespaces = appendUnique(namespaces, strings.TrimSpace(namespace))
+	}
+	return namespaces
+}
+
+// ErrChanged is returned by the Watcher once the namespaces matching the label selector have changed
+var ErrChanged = errors.New("the watched namespaces have changed, restarting the operator")
+
+// Resolve returns the namespaces of the comma separated WATCH_NAMESPACE, along with the namespaces
+// matching the label selector. The cache of the manager is restricted to the resolved namespaces,
+// which the Watcher keeps up to date by restarting the operator. No namespaces are returned, i.e.
+// all the namespaces are watched, only if both of them are empty
+func Resolve(ctx context.Context, reader client.Reader, watchNamespace, selector string) ([]string, error) {
+	namespaces := Parse(watchNamespace)
+	if selector == "" {
+		return namespaces, nil
+	}
+
+	labelSelector, err := labels.Parse(selector)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the namespace selector %q, due to error: %v", selector, err)
+	}
+	namespaceList := &corev1.NamespaceList{}
+	if err := reader.List(ctx, namespaceList, client.MatchingLabelsSelector{Selector: labelSelector}); err != nil {
+		return nil, fmt.Errorf("unable to list the namespaces, due to error: %v", err)
+	}
+	for _, namespace := range namespaceList.Items {
+		namespaces = appendUnique(namespaces, namespace.Name)
+	}
+
+	// an empty list would watch all the namespaces, rather than none of them
+	if len(namespaces) == 0 {
+		return nil, fmt.Errorf("no namespaces match the namespace selector %q", selector)
+	}
+	return namespaces, nil
+}
+
+// Watcher re-resolves the watched namespaces at every interval, and stops the manager with
+// ErrChanged once they differ from the namespaces of its cache, i.e. once a namespace is labelled
+// or unlabelled, created or deleted. The operator resolves the namespaces again on its restart
+type Watcher struct {
+	Reader         client.Reader
+	WatchNamespace string
+	Selector       string
+	// Namespaces are the namespaces of the cache of the manager
+	Namespaces []string
+	Interval   time.Duration
+}
+
+// Start implements the manager.Runnable interface
+func (watcher *Watcher) Start(ctx context.Context) error {
+	ticker := time.NewTicker(watcher.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		namespaces, err := Resolve(ctx, watcher.Reader, watcher.WatchNamespace, watcher.Selector)
+		if err != nil {
+			// the namespaces of the cache are kept, rather than watching all of them or none
+			chaosTypes.Log.Error(err, "unable to resolve the watched namespaces")
+			continue
+		}
+		if !equal(namespaces, watcher.Namespaces) {
+			chaosTypes.Log.Info("the watched namespaces have changed", "namespaces", namespaces, "previous", watcher.Namespaces)
+			return ErrChanged
+		}
+	}
+}
+
+// NeedLeaderElection makes the watcher run on all the replicas, as each of them has its own cache
+func (watcher *Watcher) NeedLeaderElection() bool {
+	return false
+}
+
+// equal returns true if both the lists contain the same namespaces, in any order
+func equal(namespaces, other []string) bool {
+	if len(namespaces) != len(other) {
+		return false
+	}
+	sorted, sortedOther := append([]string{}, namespaces...), append([]string{}, other...)
+	sort.Strings(sorted)
+	sort.Strings(sortedOther)
+	for i := range sorted {
+		if sorted[i] != sortedOther[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func appendUnique(namespaces []string, namespace string) []string {
+	if namespace == "" {
+		return namespaces
+	}
+	for _, ns := range namespaces {
+		if ns == namespace {
+			return namespaces
+		}
+	}
+	return append(namespaces, namespace)
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2019 LitmusChaos Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+   http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namespaces
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		watchNamespace string
+		namespaces     []string
+	}{
+		"Test Positive-1": {
+			watchNamespace: "",
+		},
+		"Test Positive-2": {
+			watchNamespace: "litmus",
+			namespaces:     []string{"litmus"},
+		},
+		"Test Positive-3": {
+			watchNamespace: " litmus, default,,litmus ",
+			namespaces:     []string{"litmus", "default"},
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			if namespaces := Parse(mock.watchNamespace); !reflect.DeepEqual(namespaces, mock.namespaces) {
+				t.Fatalf("Test %q failed: expected %v, received %v", name, mock.namespaces, namespaces)
+			}
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	reader := fake.NewClientBuilder().WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "litmus"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{"litmuschaos.io/chaos": "enabled"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b", Labels: map[string]string{"litmuschaos.io/chaos": "enabled"}}},
+	).Build()
+
+	tests := map[string]struct {
+		watchNamespace string
+		selector       string
+		namespaces     []string
+		isErr          bool
+	}{
+		"Test Positive-1": {
+			watchNamespace: "litmus,default",
+			namespaces:     []string{"litmus", "default"},
+		},
+		"Test Positive-2": {
+			selector:   "litmuschaos.io/chaos=enabled",
+			namespaces: []string{"team-a", "team-b"},
+		},
+		"Test Positive-3": {
+			watchNamespace: "litmus,team-a",
+			selector:       "litmuschaos.io/chaos=enabled",
+			namespaces:     []string{"litmus", "team-a", "team-b"},
+		},
+		"Test Negative-1": {
+			selector: "litmuschaos.io/chaos=disabled",
+			isErr:    true,
+		},
+		"Test Negative-2": {
+			selector: "litmuschaos.io/chaos in enabled",
+			isErr:    true,
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			namespaces, err := Resolve(context.Background(), reader, mock.watchNamespace, mock.selector)
+			if mock.isErr {
+				if err == nil {
+					t.Fatalf("Test %q failed: expected error not to be nil", name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Test %q failed: expected error to be nil, received %v", name, err)
+			}
+			if !reflect.DeepEqual(namespaces, mock.namespaces) {
+				t.Fatalf("Test %q failed: expected %v, received %v", name, mock.namespaces, namespaces)
+			}
+		})
+	}
+}
+
+func TestWatcher(t *testing.T) {
+	labelled := map[string]string{"litmuschaos.io/chaos": "enabled"}
+	tests := map[string]struct {
+		namespaces []string
+		isChanged  bool
+	}{
+		"Test Positive-1": {
+			namespaces: []string{"team-b", "litmus", "team-a"},
+		},
+		"Test Positive-2": {
+			namespaces: []string{"litmus", "team-a"},
+			isChanged:  true,
+		},
+		"Test Positive-3": {
+			namespaces: []string{"litmus", "team-a", "team-b", "team-c"},
+			isChanged:  true,
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			watcher := &Watcher{
+				Reader: fake.NewClientBuilder().WithObjects(
+					&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: labelled}},
+					&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b", Labels: labelled}},
+				).Build(),
+				WatchNamespace: "litmus",
+				Selector:       "litmuschaos.io/chaos=enabled",
+				Namespaces:     mock.namespaces,
+				Interval:       10 * time.Millisecond,
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			if err := watcher.Start(ctx); errors.Is(err, ErrChanged) != mock.isChanged {
+				t.Fatalf("Test %q failed: expected the change %v, received %v", name, mock.isChanged, err)
+			}
+		})
+	}
+}
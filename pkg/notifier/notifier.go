@@ -0,0 +1,401 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"syscall"
+	"text/template"
+	"time"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/chaos-operator/pkg/config"
+	"github.com/litmuschaos/chaos-operator/pkg/metrics"
+	chaosTypes "github.com/litmuschaos/chaos-operator/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// SignatureHeader contains the HMAC-SHA256 signature of the payload
+	SignatureHeader = "X-Litmus-Signature"
+
+	defaultRetries      = 3
+	defaultTimeout      = 10 * time.Second
+	defaultDialTimeout  = 30 * time.Second
+	defaultQueueSize    = 1000
+	defaultWorkers      = 4
+	defaultMaxRedirects = 10
+)
+
+// drop reasons of the dropped notifications metric
+const (
+	dropReasonQueueFull       = "queue_full"
+	dropReasonRetriesExceeded = "retries_exceeded"
+	dropReasonShutdown        = "shutdown"
+)
+
+// sharedAddressSpace is the carrier-grade NAT range, which is not public either
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// LifecycleEvents contains the reasons of the events notified by default
+var LifecycleEvents = []string{
+	"ChaosEngineInitialized",
+	"ChaosEngineCompleted",
+	"ChaosEngineStopped",
+	"RestartInProgress",
+	"ChaosResourcesOperationFailed",
+}
+
+// Event is the payload of the notification, it is also the data of the payload templates
+type Event struct {
+	Reason       string                        `json:"reason"`
+	Type         string                        `json:"type"`
+	Message      string                        `json:"message"`
+	Engine       string                        `json:"engine"`
+	Namespace    string                        `json:"namespace"`
+	UID          string                        `json:"uid"`
+	EngineStatus string                        `json:"engineStatus"`
+	Experiments  []v1alpha1.ExperimentStatuses `json:"experiments,omitempty"`
+	Timestamp    time.Time                     `json:"timestamp"`
+}
+
+// NewEvent returns the notification event of the given chaosengine
+func NewEvent(engine *v1alpha1.ChaosEngine, eventType, reason, message string) Event {
+	return Event{
+		Reason:       reason,
+		Type:         eventType,
+		Message:      message,
+		Engine:       engine.Name,
+		Namespace:    engine.Namespace,
+		UID:          string(engine.UID),
+		EngineStatus: string(engine.Status.EngineStatus),
+		Experiments:  engine.Status.Experiments,
+		Timestamp:    time.Now().UTC(),
+	}
+}
+
+// delivery is a notification queued for delivery, along with the failed attempts
+type delivery struct {
+	namespace string
+	notifier  v1alpha1.NotifierSpec
+	event     Event
+	attempts  int
+}
+
+// Dispatcher delivers the events to the notifiers of the chaosengines in background through a bounded in-memory queue.
+// The failed deliveries are retried with exponential backoff, and the notifications which cannot be queued or
+// delivered are dropped and counted in the dropped notifications metric
+type Dispatcher struct {
+	// Reader reads the secrets referred by the notifiers
+	Reader client.Reader
+	// HTTPClient sends the webhook requests, it only dials the addresses allowed by the notifier config
+	HTTPClient *http.Client
+	// Config holds the effective operator config, whose notifier settings apply to the deliveries.
+	// The default config applies if it is not set
+	Config *config.Store
+
+	queue chan delivery
+}
+
+// NewDispatcher returns a dispatcher with the default queue size and http client
+func NewDispatcher(reader client.Reader) *Dispatcher {
+	dispatcher := &Dispatcher{
+		Reader: reader,
+		queue:  make(chan delivery, defaultQueueSize),
+	}
+	dialer := &net.Dialer{Timeout: defaultDialTimeout, KeepAlive: defaultDialTimeout, Control: dispatcher.checkAddress}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	dispatcher.HTTPClient = &http.Client{
+		Timeout:   defaultTimeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= defaultMaxRedirects {
+				return fmt.Errorf("stopped after %d redirects", defaultMaxRedirects)
+			}
+			return dispatcher.checkURL(req.URL)
+		},
+	}
+	return dispatcher
+}
+
+// Dispatch queues the event for the subscribed notifiers of the chaosengine without blocking
+func (dispatcher *Dispatcher) Dispatch(engine *v1alpha1.ChaosEngine, event Event) {
+	for _, notifier := range engine.Spec.Notifiers {
+		if !isSubscribed(notifier, event.Reason) {
+			continue
+		}
+		dispatcher.enqueue(delivery{namespace: event.Namespace, notifier: *notifier.DeepCopy(), event: event})
+	}
+}
+
+func (dispatcher *Dispatcher) enqueue(d delivery) {
+	select {
+	case dispatcher.queue <- d:
+	default:
+		metrics.NotificationsDropped.WithLabelValues(d.event.Reason, dropReasonQueueFull).Inc()
+		chaosTypes.Log.Info("dropping chaosengine notification as the queue is full", "notifier", d.notifier.Name, "chaosengine", d.event.Engine, "reason", d.event.Reason)
+	}
+}
+
+// Start delivers the queued notifications till the context is cancelled
+func (dispatcher *Dispatcher) Start(ctx context.Context) error {
+	var workers sync.WaitGroup
+	for i := 0; i < defaultWorkers; i++ {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case d := <-dispatcher.queue:
+					dispatcher.deliver(ctx, d)
+				}
+			}
+		}()
+	}
+	workers.Wait()
+
+	for {
+		select {
+		case d := <-dispatcher.queue:
+			metrics.NotificationsDropped.WithLabelValues(d.event.Reason, dropReasonShutdown).Inc()
+		default:
+			return nil
+		}
+	}
+}
+
+// NeedLeaderElection makes the dispatcher run on all the replicas, the events are only recorded by the leader
+func (dispatcher *Dispatcher) NeedLeaderElection() bool {
+	return false
+}
+
+// deliver notifies the event, queueing it again after the backoff delay if the delivery fails
+func (dispatcher *Dispatcher) deliver(ctx context.Context, d delivery) {
+	err := dispatcher.Notify(ctx, d.namespace, d.notifier, d.event)
+	if err == nil {
+		return
+	}
+
+	d.attempts++
+	if d.attempts > dispatcher.retries(d.notifier) {
+		metrics.NotificationsDropped.WithLabelValues(d.event.Reason, dropReasonRetriesExceeded).Inc()
+		chaosTypes.Log.Error(err, "dropping chaosengine notification as the retries are exceeded", "notifier", d.notifier.Name, "chaosengine", d.event.Engine, "reason", d.event.Reason)
+		return
+	}
+	time.AfterFunc(dispatcher.backoff(d.attempts), func() { dispatcher.enqueue(d) })
+}
+
+// retries returns the retries of the notifier, bounded by the notifier.maxRetries of the operator config
+func (dispatcher *Dispatcher) retries(notifier v1alpha1.NotifierSpec) int {
+	retries := defaultRetries
+	if notifier.Retries != nil {
+		retries = int(*notifier.Retries)
+	}
+	if maxRetries := dispatcher.Config.Get().Notifier.MaxRetries; retries > maxRetries {
+		return maxRetries
+	}
+	return retries
+}
+
+// backoff returns the delay before the given retry
+func (dispatcher *Dispatcher) backoff(attempt int) time.Duration {
+	notifierConfig := dispatcher.Config.Get().Notifier
+	delay, maxDelay := notifierConfig.InitialDelay.Duration, notifierConfig.MaxDelay.Duration
+	for i := 1; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		return maxDelay
+	}
+	return delay
+}
+
+// Notify delivers the event to the notifier once, the failed deliveries are retried by the dispatcher
+func (dispatcher *Dispatcher) Notify(ctx context.Context, namespace string, notifier v1alpha1.NotifierSpec, event Event) error {
+	endpoint := notifier.URL
+	if notifier.URLSecretRef != nil {
+		secretURL, err := dispatcher.secretValue(ctx, namespace, notifier.URLSecretRef)
+		if err != nil {
+			return err
+		}
+		endpoint = secretURL
+	}
+	if endpoint == "" {
+		return fmt.Errorf("url of notifier %v is not specified", notifier.Name)
+	}
+	parsedURL, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("unable to parse the url of notifier %v, err: %v", notifier.Name, err)
+	}
+	if err := dispatcher.checkURL(parsedURL); err != nil {
+		return err
+	}
+
+	payload, err := Render(notifier.Template, event)
+	if err != nil {
+		return err
+	}
+
+	var signature string
+	if notifier.HMACSecretRef != nil {
+		key, err := dispatcher.secretValue(ctx, namespace, notifier.HMACSecretRef)
+		if err != nil {
+			return err
+		}
+		signature = Sign(payload, []byte(key))
+	}
+
+	return dispatcher.send(ctx, endpoint, notifier.Headers, payload, signature, dispatcher.Config.Get().Notifier.Timeout.Duration)
+}
+
+// checkURL returns an error if the url is not an http(s) url of the hosts allowed by the notifier config
+func (dispatcher *Dispatcher) checkURL(endpoint *url.URL) error {
+	if endpoint.Scheme != "http" && endpoint.Scheme != "https" {
+		return fmt.Errorf("unsupported scheme %q of the notifier url", endpoint.Scheme)
+	}
+	allowedHosts := dispatcher.Config.Get().Notifier.AllowedHosts
+	if len(allowedHosts) == 0 {
+		return nil
+	}
+	host := strings.ToLower(endpoint.Hostname())
+	for _, allowed := range allowedHosts {
+		allowed = strings.ToLower(allowed)
+		if host == allowed || (strings.HasPrefix(allowed, "*.") && strings.HasSuffix(host, allowed[1:])) {
+			return nil
+		}
+	}
+	return fmt.Errorf("host %v of the notifier url is not allowed by the operator config", host)
+}
+
+// checkAddress denies the dials to the loopback, private and link-local addresses, unless the notifier config allows them.
+// It checks the resolved addresses, so the hosts cannot be pointed to a private address after the url is checked
+func (dispatcher *Dispatcher) checkAddress(_, address string, _ syscall.RawConn) error {
+	if dispatcher.Config.Get().Notifier.AllowPrivateNetworks {
+		return nil
+	}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || isPrivate(ip) {
+		return fmt.Errorf("address %v of the notifier is in a private network, which is not allowed by the operator config", host)
+	}
+	return nil
+}
+
+// send posts the payload to the webhook endpoint, within the timeout if it is set
+func (dispatcher *Dispatcher) send(ctx context.Context, endpoint string, headers map[string]string, payload []byte, signature string, timeout time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	if signature != "" {
+		req.Header.Set(SignatureHeader, signature)
+	}
+
+	resp, err := dispatcher.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// secretValue returns the value of the selected secret key
+func (dispatcher *Dispatcher) secretValue(ctx context.Context, namespace string, selector *corev1.SecretKeySelector) (string, error) {
+	secret := &corev1.Secret{}
+	if err := dispatcher.Reader.Get(ctx, types.NamespacedName{Name: selector.Name, Namespace: namespace}, secret); err != nil {
+		return "", fmt.Errorf("unable to get secret %v, err: %v", selector.Name, err)
+	}
+	value, found := secret.Data[selector.Key]
+	if !found {
+		return "", fmt.Errorf("key %v not found in secret %v", selector.Key, selector.Name)
+	}
+	return string(value), nil
+}
+
+// Render returns the payload of the event, rendered with the given go template
+// The event is rendered as json if the template is empty
+func Render(payloadTemplate string, event Event) ([]byte, error) {
+	if payloadTemplate == "" {
+		return json.Marshal(event)
+	}
+	tmpl, err := template.New("payload").Option("missingkey=error").Parse(payloadTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse payload template, err: %v", err)
+	}
+	var payload bytes.Buffer
+	if err := tmpl.Execute(&payload, event); err != nil {
+		return nil, fmt.Errorf("unable to render payload template, err: %v", err)
+	}
+	return payload.Bytes(), nil
+}
+
+// Sign returns the HMAC-SHA256 signature of the payload, formatted as sha256=<hex>
+func Sign(payload, key []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// isPrivate returns true if the ip is not a public unicast address
+func isPrivate(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsUnspecified() || sharedAddressSpace.Contains(ip)
+}
+
+// isSubscribed returns true if the notifier is subscribed to the events with the given reason
+func isSubscribed(notifier v1alpha1.NotifierSpec, reason string) bool {
+	events := notifier.Events
+	if len(events) == 0 {
+		events = LifecycleEvents
+	}
+	for _, event := range events {
+		if event == reason {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2019 LitmusChaos Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+   http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/chaos-operator/pkg/config"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// webhook records the requests received by the test server, failing the first failures requests
+type webhook struct {
+	sync.Mutex
+	failures  int
+	requests  int
+	body      string
+	signature string
+}
+
+func (hook *webhook) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	hook.Lock()
+	defer hook.Unlock()
+	hook.requests++
+	if hook.requests <= hook.failures {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	body, _ := io.ReadAll(req.Body)
+	hook.body = string(body)
+	hook.signature = req.Header.Get(SignatureHeader)
+}
+
+func (hook *webhook) received() int {
+	hook.Lock()
+	defer hook.Unlock()
+	return hook.requests
+}
+
+// testConfig returns the operator config for the test webhooks, which listen on the loopback address
+func testConfig() *config.OperatorConfig {
+	operatorConfig := config.Default()
+	operatorConfig.Notifier.InitialDelay.Duration = time.Millisecond
+	operatorConfig.Notifier.AllowPrivateNetworks = true
+	return operatorConfig
+}
+
+func TestNotify(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "webhook-secret", Namespace: "default"},
+		Data:       map[string][]byte{"hmac": []byte("s3cr3t")},
+	}
+	tests := map[string]struct {
+		failures         int
+		notifier         v1alpha1.NotifierSpec
+		allowedHosts     []string
+		isPrivateDenied  bool
+		expectedBody     string
+		expectedRequests int
+		isSigned         bool
+		isErr            bool
+	}{
+		"Test Positive-1": {
+			notifier: v1alpha1.NotifierSpec{
+				Name:     "chat",
+				Template: `{"text": "{{ .Engine }} {{ .Reason }}"}`,
+			},
+			expectedBody:     `{"text": "nginx-chaos ChaosEngineCompleted"}`,
+			expectedRequests: 1,
+		},
+		"Test Positive-2": {
+			notifier: v1alpha1.NotifierSpec{
+				Name:          "incident",
+				Template:      `{{ .Message }}`,
+				HMACSecretRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "webhook-secret"}, Key: "hmac"},
+			},
+			allowedHosts:     []string{"hooks.slack.com", "127.0.0.1"},
+			expectedBody:     "chaos completed",
+			expectedRequests: 1,
+			isSigned:         true,
+		},
+		"Test Negative-1": {
+			failures:         1,
+			notifier:         v1alpha1.NotifierSpec{Name: "incident"},
+			expectedRequests: 1,
+			isErr:            true,
+		},
+		"Test Negative-2": {
+			notifier:     v1alpha1.NotifierSpec{Name: "chat"},
+			allowedHosts: []string{"hooks.slack.com", "*.pagerduty.com"},
+			isErr:        true,
+		},
+		"Test Negative-3": {
+			notifier:        v1alpha1.NotifierSpec{Name: "chat"},
+			isPrivateDenied: true,
+			isErr:           true,
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			hook := &webhook{failures: mock.failures}
+			server := httptest.NewServer(hook)
+			defer server.Close()
+
+			operatorConfig := testConfig()
+			operatorConfig.Notifier.AllowedHosts = mock.allowedHosts
+			operatorConfig.Notifier.AllowPrivateNetworks = !mock.isPrivateDenied
+			dispatcher := NewDispatcher(fake.NewClientBuilder().WithObjects(secret).Build())
+			dispatcher.Config = config.NewStore(operatorConfig)
+			mock.notifier.URL = server.URL
+			event := Event{Reason: "ChaosEngineCompleted", Message: "chaos completed", Engine: "nginx-chaos", Namespace: "default"}
+
+			err := dispatcher.Notify(context.TODO(), "default", mock.notifier, event)
+			if mock.isErr != (err != nil) {
+				t.Fatalf("Test %q failed: expected error to be %v, received %v", name, mock.isErr, err)
+			}
+			if hook.requests != mock.expectedRequests {
+				t.Fatalf("Test %q failed: expected %d requests, received %d", name, mock.expectedRequests, hook.requests)
+			}
+			if mock.expectedBody != "" && hook.body != mock.expectedBody {
+				t.Fatalf("Test %q failed: expected body %q, received %q", name, mock.expectedBody, hook.body)
+			}
+			if mock.isSigned && hook.signature != Sign([]byte(mock.expectedBody), []byte("s3cr3t")) {
+				t.Fatalf("Test %q failed: unexpected signature %q", name, hook.signature)
+			}
+		})
+	}
+}
+
+func TestDispatch(t *testing.T) {
+	tests := map[string]struct {
+		failures         int
+		retries          int32
+		maxRetries       int
+		expectedRequests int
+	}{
+		"Test Positive-1": {
+			failures:         2,
+			retries:          2,
+			maxRetries:       5,
+			expectedRequests: 3,
+		},
+		"Test Negative-1": {
+			failures:         10,
+			retries:          4,
+			maxRetries:       1,
+			expectedRequests: 2,
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			hook := &webhook{failures: mock.failures}
+			server := httptest.NewServer(hook)
+			defer server.Close()
+
+			operatorConfig := testConfig()
+			operatorConfig.Notifier.MaxRetries = mock.maxRetries
+			dispatcher := NewDispatcher(fake.NewClientBuilder().Build())
+			dispatcher.Config = config.NewStore(operatorConfig)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go dispatcher.Start(ctx)
+
+			engine := &v1alpha1.ChaosEngine{
+				ObjectMeta: metav1.ObjectMeta{Name: "nginx-chaos", Namespace: "default"},
+				Spec: v1alpha1.ChaosEngineSpec{
+					Notifiers: []v1alpha1.NotifierSpec{{Name: "incident", URL: server.URL, Retries: &mock.retries}},
+				},
+			}
+			dispatcher.Dispatch(engine, NewEvent(engine, corev1.EventTypeNormal, "ChaosEngineCompleted", "chaos completed"))
+
+			deadline := time.Now().Add(5 * time.Second)
+			for hook.received() < mock.expectedRequests && time.Now().Before(deadline) {
+				time.Sleep(10 * time.Millisecond)
+			}
+			time.Sleep(100 * time.Millisecond)
+			if received := hook.received(); received != mock.expectedRequests {
+				t.Fatalf("Test %q failed: expected %d requests, received %d", name, mock.expectedRequests, received)
+			}
+		})
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	received := make(chan string, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		received <- string(body)
+	}))
+	defer server.Close()
+
+	engine := &v1alpha1.ChaosEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx-chaos", Namespace: "default"},
+		Spec: v1alpha1.ChaosEngineSpec{
+			Notifiers: []v1alpha1.NotifierSpec{
+				{Name: "chat", URL: server.URL, Template: "{{ .Reason }}", Events: []string{"ChaosEngineStopped"}},
+			},
+		},
+	}
+	fakeRecorder := record.NewFakeRecorder(10)
+	dispatcher := NewDispatcher(fake.NewClientBuilder().Build())
+	dispatcher.Config = config.NewStore(testConfig())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go dispatcher.Start(ctx)
+	recorder := NewRecorder(fakeRecorder, dispatcher)
+
+	recorder.Eventf(engine, corev1.EventTypeNormal, "ChaosEngineInitialized", "launching %s", "nginx-chaos-runner")
+	recorder.Eventf(engine, corev1.EventTypeNormal, "ChaosEngineStopped", "Chaos resources deleted successfully")
+
+	if len(fakeRecorder.Events) != 2 {
+		t.Fatalf("Test failed: expected 2 recorded events, received %d", len(fakeRecorder.Events))
+	}
+	select {
+	case body := <-received:
+		if body != "ChaosEngineStopped" {
+			t.Fatalf("Test failed: expected only the subscribed event to be notified, received %q", body)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Test failed: expected the subscribed event to be notified")
+	}
+	select {
+	case body := <-received:
+		t.Fatalf("Test failed: unexpected notification %q", body)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notifier
+
+import (
+	"fmt"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+// Recorder records the events like the wrapped EventRecorder and also dispatches
+// the events of the chaosengines to their notifiers
+type Recorder struct {
+	record.EventRecorder
+	Dispatcher *Dispatcher
+}
+
+// NewRecorder returns a Recorder which notifies the events recorded on the chaosengines
+func NewRecorder(recorder record.EventRecorder, dispatcher *Dispatcher) *Recorder {
+	return &Recorder{EventRecorder: recorder, Dispatcher: dispatcher}
+}
+
+// Event records the event and notifies it
+func (recorder *Recorder) Event(object runtime.Object, eventtype, reason, message string) {
+	recorder.EventRecorder.Event(object, eventtype, reason, message)
+	recorder.notify(object, eventtype, reason, message)
+}
+
+// Eventf records the event and notifies it
+func (recorder *Recorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	recorder.EventRecorder.Eventf(object, eventtype, reason, messageFmt, args...)
+	recorder.notify(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+// AnnotatedEventf records the event and notifies it
+func (recorder *Recorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	recorder.EventRecorder.AnnotatedEventf(object, annotations, eventtype, reason, messageFmt, args...)
+	recorder.notify(object, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+// notify dispatches the event if it is recorded on a chaosengine with notifiers
+func (recorder *Recorder) notify(object runtime.Object, eventtype, reason, message string) {
+	engine, ok := object.(*v1alpha1.ChaosEngine)
+	if !ok || len(engine.Spec.Notifiers) == 0 || recorder.Dispatcher == nil {
+		return
+	}
+	// the notifications are delivered in background, so the chaosengine must not be shared with the reconciler
+	engine = engine.DeepCopy()
+	recorder.Dispatcher.Dispatch(engine, NewEvent(engine, eventtype, reason, message))
+}
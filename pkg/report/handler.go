@@ -0,0 +1,131 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/litmuschaos/chaos-operator/pkg/auth"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// HandlerPath is the path on which the reports are served, as <HandlerPath><namespace>/<engine>
+const HandlerPath = "/reports/"
+
+// Handler serves the reports stored in the report configmaps of the watched namespaces
+// The json report is served by default, the junit report is served with ?format=junit
+// The requests are authenticated by their bearer token, and a report is only served to the
+// users who can get its configmap, as the metrics port is not protected by the manager
+type Handler struct {
+	// Reader reads the report configmaps, preferably directly from the apiserver
+	Reader client.Reader
+	// Client creates the tokenreviews and the subjectaccessreviews of the requests
+	Client client.Client
+	// Namespaces are the watched namespaces, all the namespaces are served if it is empty
+	Namespaces []string
+}
+
+// ServeHTTP serves the report of the chaosengine in the request path
+func (handler *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	fields := strings.Split(strings.Trim(strings.TrimPrefix(req.URL.Path, HandlerPath), "/"), "/")
+	if len(fields) != 2 || fields[0] == "" || fields[1] == "" {
+		http.Error(w, "expected path "+HandlerPath+"<namespace>/<chaosengine>", http.StatusBadRequest)
+		return
+	}
+	namespace, name := fields[0], ConfigMapName(fields[1])
+
+	reviewer := &auth.Reviewer{Client: handler.Client}
+	user, err := reviewer.Authenticate(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if user == nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	// the reports of the namespaces which are not watched are not served, even to the cluster admins
+	if !handler.isWatched(namespace) {
+		http.Error(w, "report not found", http.StatusNotFound)
+		return
+	}
+	isAllowed, err := reviewer.Authorize(req, user, &authorizationv1.ResourceAttributes{
+		Namespace: namespace,
+		Verb:      "get",
+		Resource:  "configmaps",
+		Name:      name,
+	}, nil)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if !isAllowed {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+
+	key, contentType := JSONKey, "application/json"
+	switch req.URL.Query().Get("format") {
+	case "", "json":
+	case "junit":
+		key, contentType = JUnitKey, "application/xml"
+	default:
+		http.Error(w, "unsupported format, supported formats: json, junit", http.StatusBadRequest)
+		return
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := handler.Reader.Get(req.Context(), types.NamespacedName{Name: name, Namespace: namespace}, configMap); err != nil {
+		if k8serrors.IsNotFound(err) {
+			http.Error(w, "report not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data, found := configMap.Data[key]
+	if !found {
+		http.Error(w, "report not found", http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	_, _ = w.Write([]byte(data))
+}
+
+// isWatched checks if the namespace is watched by the operator
+func (handler *Handler) isWatched(namespace string) bool {
+	if len(handler.Namespaces) == 0 {
+		return true
+	}
+	for _, ns := range handler.Namespaces {
+		if ns == namespace {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// JSONKey is the key of the json report inside the report configmap
+	JSONKey = "report.json"
+	// JUnitKey is the key of the junit xml report inside the report configmap
+	JUnitKey = "junit.xml"
+)
+
+// ConfigMapName returns the name of the report configmap of the given chaosengine
+func ConfigMapName(engineName string) string {
+	return engineName + "-report"
+}
+
+// Report contains the outcome of a chaosengine run
+type Report struct {
+	Engine          string             `json:"engine"`
+	Namespace       string             `json:"namespace"`
+	UID             string             `json:"uid"`
+	EngineStatus    string             `json:"engineStatus"`
+	ResilienceScore string             `json:"resilienceScore,omitempty"`
+	StartTime       *metav1.Time       `json:"startTime,omitempty"`
+	CompletionTime  *metav1.Time       `json:"completionTime,omitempty"`
+	DurationSeconds float64            `json:"durationSeconds"`
+	Experiments     []ExperimentReport `json:"experiments"`
+}
+
+// ExperimentReport contains the outcome of an experiment of the chaosengine run
+type ExperimentReport struct {
+	Name                   string                   `json:"name"`
+	Verdict                string                   `json:"verdict"`
+	Phase                  string                   `json:"phase,omitempty"`
+	ProbeSuccessPercentage string                   `json:"probeSuccessPercentage,omitempty"`
+	ErrorOutput            *v1alpha1.ErrorOutput    `json:"errorOutput,omitempty"`
+	ProbeStatuses          []v1alpha1.ProbeStatuses `json:"probeStatuses,omitempty"`
+	StartTime              *metav1.Time             `json:"startTime,omitempty"`
+	CompletionTime         *metav1.Time             `json:"completionTime,omitempty"`
+	DurationSeconds        float64                  `json:"durationSeconds"`
+}
+
+// Build aggregates the experiment statuses of the chaosengine and their chaosresults into a report
+// The experiments are run one after the other by the runner, so the start time of an experiment
+// is derived from the completion time of the previous one
+func Build(engine *v1alpha1.ChaosEngine, results []v1alpha1.ChaosResult) Report {
+	report := Report{
+		Engine:          engine.Name,
+		Namespace:       engine.Namespace,
+		UID:             string(engine.UID),
+		EngineStatus:    string(engine.Status.EngineStatus),
+		ResilienceScore: engine.Status.ResilienceScore,
+		StartTime:       engine.Status.StartTime,
+		CompletionTime:  engine.Status.CompletionTime,
+		DurationSeconds: duration(engine.Status.StartTime, engine.Status.CompletionTime),
+		Experiments:     []ExperimentReport{},
+	}
+
+	resultsByExperiment := make(map[string]v1alpha1.ChaosResult, len(results))
+	for _, result := range results {
+		resultsByExperiment[result.Spec.ExperimentName] = result
+	}
+
+	startTime := engine.Status.StartTime
+	for _, expStatus := range engine.Status.Experiments {
+		expReport := ExperimentReport{
+			Name:                   expStatus.Name,
+			Verdict:                expStatus.Verdict,
+			Phase:                  string(expStatus.ResultPhase),
+			ProbeSuccessPercentage: expStatus.ProbeSuccessPercentage,
+			StartTime:              startTime,
+		}
+		if !expStatus.LastUpdateTime.IsZero() {
+			completionTime := expStatus.LastUpdateTime
+			expReport.CompletionTime = &completionTime
+			expReport.DurationSeconds = duration(startTime, expReport.CompletionTime)
+			startTime = expReport.CompletionTime
+		}
+		if result, found := resultsByExperiment[expStatus.Name]; found {
+			if result.Status.ExperimentStatus.Verdict != "" {
+				expReport.Verdict = string(result.Status.ExperimentStatus.Verdict)
+			}
+			if result.Status.ExperimentStatus.Phase != "" {
+				expReport.Phase = string(result.Status.ExperimentStatus.Phase)
+			}
+			if result.Status.ExperimentStatus.ProbeSuccessPercentage != "" {
+				expReport.ProbeSuccessPercentage = result.Status.ExperimentStatus.ProbeSuccessPercentage
+			}
+			expReport.ErrorOutput = result.Status.ExperimentStatus.ErrorOutput
+			expReport.ProbeStatuses = result.Status.ProbeStatuses
+		}
+		report.Experiments = append(report.Experiments, expReport)
+	}
+	return report
+}
+
+// JSON returns the report in json format
+func (report Report) JSON() ([]byte, error) {
+	return json.MarshalIndent(report, "", "  ")
+}
+
+// JUnit returns the report in junit xml format, with a testcase per experiment
+func (report Report) JUnit() ([]byte, error) {
+	suite := junitTestSuite{
+		Name:  report.Namespace + "/" + report.Engine,
+		Tests: len(report.Experiments),
+		Time:  formatSeconds(report.DurationSeconds),
+		Properties: []junitProperty{
+			{Name: "engineStatus", Value: report.EngineStatus},
+			{Name: "resilienceScore", Value: report.ResilienceScore},
+		},
+	}
+	if report.StartTime != nil {
+		suite.Timestamp = report.StartTime.UTC().Format(time.RFC3339)
+	}
+
+	for _, exp := range report.Experiments {
+		testCase := junitTestCase{
+			Name:      exp.Name,
+			ClassName: report.Namespace + "." + report.Engine,
+			Time:      formatSeconds(exp.DurationSeconds),
+			SystemOut: probeSummary(exp),
+		}
+		switch v1alpha1.ResultVerdict(exp.Verdict) {
+		case v1alpha1.ResultVerdictPassed:
+		case v1alpha1.ResultVerdictFailed:
+			suite.Failures++
+			testCase.Failure = &junitMessage{Type: exp.Phase, Message: failureMessage(exp), Text: errorDetails(exp)}
+		case v1alpha1.ResultVerdictError:
+			suite.Errors++
+			testCase.Error = &junitMessage{Type: exp.Phase, Message: failureMessage(exp), Text: errorDetails(exp)}
+		default:
+			suite.Skipped++
+			testCase.Skipped = &junitMessage{Message: fmt.Sprintf("experiment verdict is %v", exp.Verdict)}
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	data, err := xml.MarshalIndent(junitTestSuites{Suites: []junitTestSuite{suite}}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Errors     int             `xml:"errors,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Time       string          `xml:"time,attr"`
+	Timestamp  string          `xml:"timestamp,attr,omitempty"`
+	Properties []junitProperty `xml:"properties>property"`
+	TestCases  []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Type    string `xml:"type,attr,omitempty"`
+	Message string `xml:"message,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// failureMessage returns the one line summary of the failed experiment
+func failureMessage(exp ExperimentReport) string {
+	if exp.ErrorOutput != nil && exp.ErrorOutput.Reason != "" {
+		return exp.ErrorOutput.Reason
+	}
+	return fmt.Sprintf("experiment verdict is %v, probe success percentage: %v", exp.Verdict, exp.ProbeSuccessPercentage)
+}
+
+// errorDetails returns the error code and the failed probes of the experiment
+func errorDetails(exp ExperimentReport) string {
+	var details []string
+	if exp.ErrorOutput != nil {
+		details = append(details, fmt.Sprintf("errorCode: %v, reason: %v", exp.ErrorOutput.ErrorCode, exp.ErrorOutput.Reason))
+	}
+	for _, probe := range exp.ProbeStatuses {
+		if probe.Status.Verdict == v1alpha1.ProbeVerdictFailed {
+			details = append(details, fmt.Sprintf("probe %v failed: %v", probe.Name, probe.Status.Description))
+		}
+	}
+	return strings.Join(details, "\n")
+}
+
+// probeSummary returns the status of all the probes of the experiment
+func probeSummary(exp ExperimentReport) string {
+	var summary []string
+	for _, probe := range exp.ProbeStatuses {
+		summary = append(summary, fmt.Sprintf("%v (%v, %v): %v %v", probe.Name, probe.Type, probe.Mode, probe.Status.Verdict, probe.Status.Description))
+	}
+	return strings.Join(summary, "\n")
+}
+
+// duration returns the seconds elapsed between the given times, zero if either of them is not known
+func duration(start, end *metav1.Time) float64 {
+	if start == nil || end == nil || end.Before(start) {
+		return 0
+	}
+	return end.Sub(start.Time).Seconds()
+}
+
+func formatSeconds(seconds float64) string {
+	return fmt.Sprintf("%.3f", seconds)
+}
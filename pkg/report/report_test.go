@@ -0,0 +1,185 @@
+/*
+Copyright 2019 LitmusChaos Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+   http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package report
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func newEngine() *v1alpha1.ChaosEngine {
+	start := metav1.NewTime(time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC))
+	completion := metav1.NewTime(start.Add(5 * time.Minute))
+	return &v1alpha1.ChaosEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: "nginx-chaos", Namespace: "default", UID: "engine-uid"},
+		Status: v1alpha1.ChaosEngineStatus{
+			EngineStatus:    v1alpha1.EngineStatusCompleted,
+			StartTime:       &start,
+			CompletionTime:  &completion,
+			ResilienceScore: "50.00",
+			Experiments: []v1alpha1.ExperimentStatuses{
+				{Name: "pod-delete", Verdict: "Pass", LastUpdateTime: metav1.NewTime(start.Add(2 * time.Minute))},
+				{Name: "pod-cpu-hog", Verdict: "Awaited", LastUpdateTime: metav1.NewTime(start.Add(5 * time.Minute))},
+			},
+		},
+	}
+}
+
+func TestBuild(t *testing.T) {
+	results := []v1alpha1.ChaosResult{
+		{
+			Spec: v1alpha1.ChaosResultSpec{ExperimentName: "pod-cpu-hog"},
+			Status: v1alpha1.ChaosResultStatus{
+				ExperimentStatus: v1alpha1.TestStatus{
+					Phase:                  v1alpha1.ResultPhaseCompleted,
+					Verdict:                v1alpha1.ResultVerdictFailed,
+					ProbeSuccessPercentage: "0",
+					ErrorOutput:            &v1alpha1.ErrorOutput{ErrorCode: "CHAOS_INJECT_ERROR", Reason: "unable to inject chaos"},
+				},
+				ProbeStatuses: []v1alpha1.ProbeStatuses{
+					{Name: "check-frontend", Type: "httpProbe", Mode: "Continuous", Status: v1alpha1.ProbeStatus{Verdict: v1alpha1.ProbeVerdictFailed}},
+				},
+			},
+		},
+	}
+
+	report := Build(newEngine(), results)
+	if report.DurationSeconds != 300 {
+		t.Fatalf("Test failed: expected run duration 300s, received %v", report.DurationSeconds)
+	}
+	if len(report.Experiments) != 2 {
+		t.Fatalf("Test failed: expected 2 experiments, received %d", len(report.Experiments))
+	}
+	if exp := report.Experiments[0]; exp.Verdict != "Pass" || exp.DurationSeconds != 120 {
+		t.Fatalf("Test failed: unexpected report for pod-delete: %+v", exp)
+	}
+	if exp := report.Experiments[1]; exp.Verdict != "Fail" || exp.DurationSeconds != 180 || len(exp.ProbeStatuses) != 1 || exp.ErrorOutput == nil {
+		t.Fatalf("Test failed: unexpected report for pod-cpu-hog: %+v", exp)
+	}
+
+	data, err := report.JUnit()
+	if err != nil {
+		t.Fatalf("Test failed: expected error to be nil, received %v", err)
+	}
+	suites := junitTestSuites{}
+	if err := xml.Unmarshal(data, &suites); err != nil {
+		t.Fatalf("Test failed: invalid junit report: %v", err)
+	}
+	if suite := suites.Suites[0]; suite.Tests != 2 || suite.Failures != 1 || suite.TestCases[1].Failure == nil {
+		t.Fatalf("Test failed: unexpected junit testsuite: %+v", suite)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: ConfigMapName("nginx-chaos"), Namespace: "default"},
+		Data:       map[string]string{JSONKey: "{}", JUnitKey: "<testsuites></testsuites>"},
+	}
+	// the admin token is allowed to get the configmaps, the viewer token is only authenticated
+	reviews := interceptor.NewClient(fake.NewClientBuilder().Build(), interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			switch review := obj.(type) {
+			case *authenticationv1.TokenReview:
+				review.Status.Authenticated = review.Spec.Token == "admin-token" || review.Spec.Token == "viewer-token"
+				review.Status.User.Username = review.Spec.Token
+			case *authorizationv1.SubjectAccessReview:
+				review.Status.Allowed = review.Spec.User == "admin-token" && review.Spec.ResourceAttributes.Resource == "configmaps"
+			}
+			return nil
+		},
+	})
+	handler := &Handler{
+		Reader:     fake.NewClientBuilder().WithObjects(configMap).Build(),
+		Client:     reviews,
+		Namespaces: []string{"default", "litmus"},
+	}
+
+	tests := map[string]struct {
+		path         string
+		token        string
+		expectedCode int
+		expectedBody string
+	}{
+		"Test Positive-1": {
+			path:         "/reports/default/nginx-chaos",
+			token:        "admin-token",
+			expectedCode: http.StatusOK,
+			expectedBody: "{}",
+		},
+		"Test Positive-2": {
+			path:         "/reports/default/nginx-chaos?format=junit",
+			token:        "admin-token",
+			expectedCode: http.StatusOK,
+			expectedBody: "<testsuites></testsuites>",
+		},
+		"Test Negative-1": {
+			path:         "/reports/default/unknown-chaos",
+			token:        "admin-token",
+			expectedCode: http.StatusNotFound,
+		},
+		"Test Negative-2": {
+			path:         "/reports/default",
+			token:        "admin-token",
+			expectedCode: http.StatusBadRequest,
+		},
+		"Test Negative-3": {
+			path:         "/reports/default/nginx-chaos",
+			expectedCode: http.StatusUnauthorized,
+		},
+		"Test Negative-4": {
+			path:         "/reports/default/nginx-chaos",
+			token:        "unknown-token",
+			expectedCode: http.StatusUnauthorized,
+		},
+		"Test Negative-5": {
+			path:         "/reports/default/nginx-chaos",
+			token:        "viewer-token",
+			expectedCode: http.StatusForbidden,
+		},
+		"Test Negative-6": {
+			path:         "/reports/kube-system/nginx-chaos",
+			token:        "admin-token",
+			expectedCode: http.StatusNotFound,
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, mock.path, nil)
+			if mock.token != "" {
+				req.Header.Set("Authorization", "Bearer "+mock.token)
+			}
+			handler.ServeHTTP(recorder, req)
+			if recorder.Code != mock.expectedCode {
+				t.Fatalf("Test %q failed: expected status code %d, received %d", name, mock.expectedCode, recorder.Code)
+			}
+			if mock.expectedBody != "" && recorder.Body.String() != mock.expectedBody {
+				t.Fatalf("Test %q failed: expected body %q, received %q", name, mock.expectedBody, recorder.Body.String())
+			}
+		})
+	}
+}
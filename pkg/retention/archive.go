@@ -0,0 +1,168 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retention
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientretry "k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ArchiveNone disables the archiving of the pruned chaosresults
+	ArchiveNone = "none"
+	// ArchiveConfigMap archives the pruned chaosresults into a configmap in the namespace of the chaosresult
+	ArchiveConfigMap = "configmap"
+	// ArchiveFile archives the pruned chaosresults into a local directory
+	ArchiveFile = "file"
+
+	// DefaultArchiveConfigMapName contains the default name of the archive configmap
+	DefaultArchiveConfigMapName = "chaosresult-archive"
+
+	// maxConfigMapArchiveSize keeps the archive configmap well within the 1MiB object size limit
+	maxConfigMapArchiveSize = 768 * 1024
+)
+
+// Archiver archives a chaosresult before it is pruned
+type Archiver interface {
+	Archive(ctx context.Context, result *v1alpha1.ChaosResult) error
+}
+
+// NewArchiver returns the archiver for the given archive type
+// location is the configmap name for the configmap archive and the directory for the file archive
+func NewArchiver(archiveType, location string, c client.Client) (Archiver, error) {
+	switch archiveType {
+	case "", ArchiveNone:
+		return nil, nil
+	case ArchiveConfigMap:
+		if location == "" {
+			location = DefaultArchiveConfigMapName
+		}
+		return &ConfigMapArchiver{Client: c, Name: location}, nil
+	case ArchiveFile:
+		if location == "" {
+			return nil, fmt.Errorf("archive directory is required for the %v archive", ArchiveFile)
+		}
+		return &FileArchiver{Dir: location}, nil
+	}
+	return nil, fmt.Errorf("unsupported archive type %q, supported types: %v, %v, %v", archiveType, ArchiveNone, ArchiveConfigMap, ArchiveFile)
+}
+
+// ConfigMapArchiver archives the chaosresults as json entries of a configmap in the namespace of the chaosresult
+// The oldest entries are dropped once the configmap grows beyond maxConfigMapArchiveSize
+type ConfigMapArchiver struct {
+	Client client.Client
+	Name   string
+}
+
+// Archive adds the chaosresult to the archive configmap
+func (archiver *ConfigMapArchiver) Archive(ctx context.Context, result *v1alpha1.ChaosResult) error {
+	data, err := marshalResult(result)
+	if err != nil {
+		return err
+	}
+	key := archiveKey(result, time.Now())
+
+	return clientretry.RetryOnConflict(clientretry.DefaultRetry, func() error {
+		configMap := &corev1.ConfigMap{}
+		err := archiver.Client.Get(ctx, types.NamespacedName{Name: archiver.Name, Namespace: result.Namespace}, configMap)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+
+		if k8serrors.IsNotFound(err) {
+			configMap = &corev1.ConfigMap{
+				ObjectMeta: v1.ObjectMeta{
+					Name:      archiver.Name,
+					Namespace: result.Namespace,
+					Labels: map[string]string{
+						"app.kubernetes.io/component": "chaosresult-archive",
+						"app.kubernetes.io/part-of":   "litmus",
+					},
+				},
+				Data: map[string]string{key: string(data)},
+			}
+			return archiver.Client.Create(ctx, configMap)
+		}
+
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		configMap.Data[key] = string(data)
+		trimArchive(configMap.Data, maxConfigMapArchiveSize)
+		return archiver.Client.Update(ctx, configMap)
+	})
+}
+
+// FileArchiver archives the chaosresults as json files inside <Dir>/<namespace>/
+type FileArchiver struct {
+	Dir string
+}
+
+// Archive writes the chaosresult into the archive directory
+func (archiver *FileArchiver) Archive(ctx context.Context, result *v1alpha1.ChaosResult) error {
+	data, err := marshalResult(result)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(archiver.Dir, result.Namespace)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("unable to create archive directory, err: %v", err)
+	}
+	return os.WriteFile(filepath.Join(dir, archiveKey(result, time.Now())), data, 0644)
+}
+
+// archiveKey returns the archive entry name, prefixed with the archive time so that the entries sort by age
+func archiveKey(result *v1alpha1.ChaosResult, now time.Time) string {
+	return fmt.Sprintf("%d.%s.json", now.Unix(), result.Name)
+}
+
+// marshalResult returns the json of the chaosresult without the managed fields
+func marshalResult(result *v1alpha1.ChaosResult) ([]byte, error) {
+	archived := result.DeepCopy()
+	archived.ManagedFields = nil
+	archived.APIVersion = v1alpha1.SchemeGroupVersion.String()
+	archived.Kind = "ChaosResult"
+	return json.Marshal(archived)
+}
+
+// trimArchive drops the oldest entries till the archive fits in the given size
+func trimArchive(data map[string]string, maxSize int) {
+	keys := make([]string, 0, len(data))
+	size := 0
+	for k, v := range data {
+		keys = append(keys, k)
+		size += len(k) + len(v)
+	}
+	sort.Strings(keys)
+	for i := 0; size > maxSize && i < len(keys)-1; i++ {
+		size -= len(keys[i]) + len(data[keys[i]])
+		delete(data, keys[i])
+	}
+}
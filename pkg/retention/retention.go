@@ -0,0 +1,154 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retention
+
+import (
+	"sort"
+	"time"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+)
+
+// Policy defines the retention policy of the chaosresults
+// A zero value of any of the limits disables that limit
+type Policy struct {
+	// MaxAge is the maximum duration for which a finished chaosresult is retained since its last update
+	MaxAge time.Duration
+	// MaxCountPerExperiment is the maximum number of finished chaosresults retained per experiment in a namespace
+	MaxCountPerExperiment int
+	// MaxCountPerEngine is the maximum number of finished chaosresults retained per engine in a namespace
+	MaxCountPerEngine int
+	// KeepLastFailures is the number of latest failed chaosresults per experiment in a namespace,
+	// which are retained irrespective of MaxAge and the max counts
+	KeepLastFailures int
+}
+
+// IsEnabled returns true if any of the limits of the policy is set
+func (policy Policy) IsEnabled() bool {
+	return policy.MaxAge > 0 || policy.MaxCountPerExperiment > 0 || policy.MaxCountPerEngine > 0
+}
+
+// Prunable returns the chaosresults which are to be pruned as per the policy
+// The chaosresults of the runs which are yet to finish are never pruned
+func (policy Policy) Prunable(results []v1alpha1.ChaosResult, now time.Time) []v1alpha1.ChaosResult {
+	if !policy.IsEnabled() {
+		return nil
+	}
+
+	var finished []v1alpha1.ChaosResult
+	for _, result := range results {
+		if IsFinished(result) {
+			finished = append(finished, result)
+		}
+	}
+
+	// newest chaosresults first, so that the latest ones are retained
+	sort.SliceStable(finished, func(i, j int) bool {
+		ti, tj := LastUpdateTime(finished[i]), LastUpdateTime(finished[j])
+		if !ti.Equal(tj) {
+			return ti.After(tj)
+		}
+		return finished[i].Name < finished[j].Name
+	})
+
+	protected := make(map[int]bool)
+	failures := make(map[string]int)
+	for i, result := range finished {
+		if !isFailed(result) {
+			continue
+		}
+		key := experimentKey(result)
+		if failures[key] < policy.KeepLastFailures {
+			failures[key]++
+			protected[i] = true
+		}
+	}
+
+	pruned := make(map[int]bool)
+	if policy.MaxAge > 0 {
+		for i, result := range finished {
+			if !protected[i] && now.Sub(LastUpdateTime(result)) > policy.MaxAge {
+				pruned[i] = true
+			}
+		}
+	}
+	policy.pruneByCount(finished, experimentKey, policy.MaxCountPerExperiment, protected, pruned)
+	policy.pruneByCount(finished, engineKey, policy.MaxCountPerEngine, protected, pruned)
+
+	var prunable []v1alpha1.ChaosResult
+	for i := range finished {
+		if pruned[i] {
+			prunable = append(prunable, finished[i])
+		}
+	}
+	return prunable
+}
+
+// pruneByCount marks the chaosresults beyond the max count of their group for pruning
+// the protected chaosresults count towards the max count but are never pruned
+func (policy Policy) pruneByCount(results []v1alpha1.ChaosResult, groupKey func(v1alpha1.ChaosResult) string, maxCount int, protected, pruned map[int]bool) {
+	if maxCount <= 0 {
+		return
+	}
+	retained := make(map[string]int)
+	for i, result := range results {
+		if pruned[i] {
+			continue
+		}
+		key := groupKey(result)
+		if !protected[i] && retained[key] >= maxCount {
+			pruned[i] = true
+			continue
+		}
+		retained[key]++
+	}
+}
+
+// IsFinished returns true if the chaosresult has reached a terminal phase
+func IsFinished(result v1alpha1.ChaosResult) bool {
+	switch result.Status.ExperimentStatus.Phase {
+	case v1alpha1.ResultPhaseCompleted, v1alpha1.ResultPhaseCompletedWithError,
+		v1alpha1.ResultPhaseCompletedWithProbeFailure, v1alpha1.ResultPhaseStopped, v1alpha1.ResultPhaseError:
+		return true
+	}
+	return false
+}
+
+// LastUpdateTime returns the time of the last write on the chaosresult
+// chaosresults are reused across the reruns of an engine, so the creation time alone is not sufficient
+func LastUpdateTime(result v1alpha1.ChaosResult) time.Time {
+	lastUpdateTime := result.CreationTimestamp.Time
+	for _, field := range result.ManagedFields {
+		if field.Time != nil && field.Time.After(lastUpdateTime) {
+			lastUpdateTime = field.Time.Time
+		}
+	}
+	return lastUpdateTime
+}
+
+func isFailed(result v1alpha1.ChaosResult) bool {
+	return result.Status.ExperimentStatus.Verdict == v1alpha1.ResultVerdictFailed ||
+		result.Status.ExperimentStatus.Verdict == v1alpha1.ResultVerdictError
+}
+
+func experimentKey(result v1alpha1.ChaosResult) string {
+	return result.Namespace + "/" + result.Spec.ExperimentName
+}
+
+func engineKey(result v1alpha1.ChaosResult) string {
+	return result.Namespace + "/" + result.Spec.EngineName
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2019 LitmusChaos Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+   http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retention
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPrunable(t *testing.T) {
+	now := time.Now()
+	newResult := func(name, engine string, age time.Duration, phase v1alpha1.ResultPhase, verdict v1alpha1.ResultVerdict) v1alpha1.ChaosResult {
+		return v1alpha1.ChaosResult{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "default",
+				CreationTimestamp: metav1.NewTime(now.Add(-age)),
+			},
+			Spec: v1alpha1.ChaosResultSpec{
+				EngineName:     engine,
+				ExperimentName: "pod-delete",
+			},
+			Status: v1alpha1.ChaosResultStatus{
+				ExperimentStatus: v1alpha1.TestStatus{
+					Phase:   phase,
+					Verdict: verdict,
+				},
+			},
+		}
+	}
+	results := []v1alpha1.ChaosResult{
+		newResult("r1", "e1", 1*time.Hour, v1alpha1.ResultPhaseCompleted, v1alpha1.ResultVerdictPassed),
+		newResult("r2", "e2", 2*time.Hour, v1alpha1.ResultPhaseCompleted, v1alpha1.ResultVerdictFailed),
+		newResult("r3", "e3", 3*time.Hour, v1alpha1.ResultPhaseCompleted, v1alpha1.ResultVerdictPassed),
+		newResult("r4", "e1", 4*time.Hour, v1alpha1.ResultPhaseRunning, v1alpha1.ResultVerdictAwaited),
+	}
+
+	tests := map[string]struct {
+		policy   Policy
+		expected []string
+	}{
+		"Test Positive-1": {
+			policy:   Policy{MaxAge: 90 * time.Minute},
+			expected: []string{"r2", "r3"},
+		},
+		"Test Positive-2": {
+			policy:   Policy{MaxAge: 90 * time.Minute, KeepLastFailures: 1},
+			expected: []string{"r3"},
+		},
+		"Test Positive-3": {
+			policy:   Policy{MaxCountPerExperiment: 1},
+			expected: []string{"r2", "r3"},
+		},
+		"Test Positive-4": {
+			policy:   Policy{MaxCountPerExperiment: 1, KeepLastFailures: 1},
+			expected: []string{"r3"},
+		},
+		"Test Negative-1": {
+			policy:   Policy{KeepLastFailures: 1},
+			expected: nil,
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			var pruned []string
+			for _, result := range mock.policy.Prunable(results, now) {
+				pruned = append(pruned, result.Name)
+			}
+			sort.Strings(pruned)
+			if !reflect.DeepEqual(pruned, mock.expected) {
+				t.Fatalf("Test %q failed: expected pruned chaosresults %v, received %v", name, mock.expected, pruned)
+			}
+		})
+	}
+}
@@ -0,0 +1,470 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sharding splits the watched namespaces into shards, which are owned by the
+// operator replicas holding their leases, so that several replicas reconcile in parallel
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/litmuschaos/chaos-operator/pkg/metrics"
+	chaosTypes "github.com/litmuschaos/chaos-operator/pkg/types"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// ModeHash assigns the namespaces to the shards by the hash of their names
+	ModeHash = "hash"
+	// ModeLabel assigns the namespaces to the shard in their ShardLabel, falling back to the hash of their names
+	ModeLabel = "label"
+
+	// ShardLabel is the namespace label holding the shard of the namespace, used in the label mode
+	ShardLabel = "litmuschaos.io/shard"
+
+	// leaseLabel marks the leases of the sharding, its value is either leaseKindShard or leaseKindMember
+	leaseLabel      = "litmuschaos.io/sharding"
+	leaseKindShard  = "shard"
+	leaseKindMember = "member"
+
+	shardLeasePrefix  = "chaos-operator-shard-"
+	memberLeasePrefix = "chaos-operator-member-"
+
+	defaultLeaseDuration = 15 * time.Second
+)
+
+// subscription delivers the objects of the newly acquired shards
+type subscription struct {
+	list   client.ObjectList
+	events chan event.GenericEvent
+}
+
+// Sharder acquires and renews the leases of the shards, as per the fair share of the live replicas.
+// Each replica renews its member lease, and the fair share is the number of shards divided by the
+// number of replicas with a live member lease. The shards beyond the fair share are not renewed,
+// so that they are acquired by the other replicas once their leases expire, and the shards of a
+// lost replica are acquired by the remaining replicas likewise.
+//
+// A shard is owned only till RenewDeadline since its last renewal, which is shorter than the
+// LeaseDuration after which the other replicas may acquire it, so a shard has one owner at a time
+type Sharder struct {
+	// Client reads and writes the leases, it should not be backed by the cache
+	Client client.Client
+	// Reader reads the namespaces in the label mode and the objects of the subscriptions
+	Reader client.Reader
+	// Namespace holds the leases, usually the namespace of the operator
+	Namespace string
+	// Identity is the holder identity of the leases, unique per replica
+	Identity string
+	// Shards is the total number of shards
+	Shards int
+	// Mode is the assignment of the namespaces to the shards, either ModeHash or ModeLabel
+	Mode string
+	// LeaseDuration is the duration after which the leases, which are not renewed, are acquired by the other replicas
+	LeaseDuration time.Duration
+	// RenewDeadline is the duration since the last renewal, till which a shard is owned
+	RenewDeadline time.Duration
+	// RetryPeriod is the interval between two renewals of the leases
+	RetryPeriod time.Duration
+
+	now           func() time.Time
+	mu            sync.RWMutex
+	owned         map[int]time.Time
+	subscriptions []subscription
+}
+
+// NewSharder returns a sharder with the given number of shards, deriving the renew deadline and
+// the retry period from the lease duration
+func NewSharder(c client.Client, reader client.Reader, namespace, identity string, shards int, mode string, leaseDuration time.Duration) (*Sharder, error) {
+	if shards < 1 {
+		return nil, fmt.Errorf("invalid number of shards %d, it should be greater than 0", shards)
+	}
+	switch mode {
+	case "":
+		mode = ModeHash
+	case ModeHash, ModeLabel:
+	default:
+		return nil, fmt.Errorf("unsupported sharding mode %q, supported modes: %v, %v", mode, ModeHash, ModeLabel)
+	}
+	if namespace == "" || identity == "" {
+		return nil, fmt.Errorf("the namespace and the identity of the shard leases are required")
+	}
+	if leaseDuration <= 0 {
+		leaseDuration = defaultLeaseDuration
+	}
+	return &Sharder{
+		Client:        c,
+		Reader:        reader,
+		Namespace:     namespace,
+		Identity:      identity,
+		Shards:        shards,
+		Mode:          mode,
+		LeaseDuration: leaseDuration,
+		RenewDeadline: leaseDuration * 2 / 3,
+		RetryPeriod:   leaseDuration / 5,
+	}, nil
+}
+
+// Subscribe returns a source, which receives the objects of the given list type in the namespaces
+// of each newly acquired shard, so that they are reconciled by the new owner
+func (s *Sharder) Subscribe(list client.ObjectList) source.Source {
+	events := make(chan event.GenericEvent)
+	s.subscriptions = append(s.subscriptions, subscription{list: list, events: events})
+	return &source.Channel{Source: events}
+}
+
+// ShardOf returns the shard of the given namespace
+func (s *Sharder) ShardOf(ctx context.Context, namespace string) (int, error) {
+	if s.Mode == ModeLabel {
+		ns := &corev1.Namespace{}
+		if err := s.Reader.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+			return 0, fmt.Errorf("unable to get the namespace %v, due to error: %v", namespace, err)
+		}
+		if value, ok := ns.Labels[ShardLabel]; ok {
+			shard, err := strconv.Atoi(value)
+			if err == nil && shard >= 0 && shard < s.Shards {
+				return shard, nil
+			}
+			chaosTypes.Log.Info("ignoring the invalid shard label of the namespace", "namespace", namespace, "shard", value)
+		}
+	}
+	h := fnv.New32a()
+	h.Write([]byte(namespace))
+	return int(h.Sum32() % uint32(s.Shards)), nil
+}
+
+// Owns returns true if the shard of the given namespace is owned by this replica, it always returns true on a nil sharder
+func (s *Sharder) Owns(ctx context.Context, namespace string) (bool, error) {
+	if s == nil {
+		return true, nil
+	}
+	shard, err := s.ShardOf(ctx, namespace)
+	if err != nil {
+		return false, err
+	}
+	return s.isOwned(shard), nil
+}
+
+// OwnedShards returns the shards owned by this replica, in ascending order
+func (s *Sharder) OwnedShards() []int {
+	var shards []int
+	for shard := 0; shard < s.Shards; shard++ {
+		if s.isOwned(shard) {
+			shards = append(shards, shard)
+		}
+	}
+	return shards
+}
+
+func (s *Sharder) isOwned(shard int) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	renewed, ok := s.owned[shard]
+	return ok && s.clock().Sub(renewed) < s.RenewDeadline
+}
+
+// Start renews the leases till the context is cancelled
+func (s *Sharder) Start(ctx context.Context) error {
+	ticker := time.NewTicker(s.RetryPeriod)
+	defer ticker.Stop()
+
+	for {
+		if err := s.sync(ctx); err != nil {
+			chaosTypes.Log.Error(err, "unable to sync the shard leases")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// NeedLeaderElection makes the sharder run on all the replicas
+func (s *Sharder) NeedLeaderElection() bool {
+	return false
+}
+
+// sync renews the member lease, renews the owned shards within the fair share, and acquires
+// the free shards till the fair share is reached
+func (s *Sharder) sync(ctx context.Context) error {
+	now := s.clock()
+	if err := s.renewMember(ctx, now); err != nil {
+		return err
+	}
+
+	leaseList := &coordinationv1.LeaseList{}
+	if err := s.Client.List(ctx, leaseList, client.InNamespace(s.Namespace), client.HasLabels{leaseLabel}); err != nil {
+		return fmt.Errorf("unable to list the shard leases, due to error: %v", err)
+	}
+	members := 0
+	shardLeases := map[string]*coordinationv1.Lease{}
+	for i := range leaseList.Items {
+		lease := &leaseList.Items[i]
+		switch lease.Labels[leaseLabel] {
+		case leaseKindMember:
+			if lease.Spec.HolderIdentity != nil && *lease.Spec.HolderIdentity == s.Identity {
+				members++
+			} else if isExpired(lease, now) {
+				// the member leases of the lost replicas are removed, as the pod names are not reused
+				if err := s.Client.Delete(ctx, lease, client.Preconditions{ResourceVersion: &lease.ResourceVersion}); err != nil && !k8serrors.IsNotFound(err) && !k8serrors.IsConflict(err) {
+					chaosTypes.Log.Error(err, "unable to delete the expired member lease", "lease", lease.Name)
+				}
+			} else {
+				members++
+			}
+		case leaseKindShard:
+			shardLeases[lease.Name] = lease
+		}
+	}
+	if members == 0 {
+		members = 1
+	}
+	fairShare := (s.Shards + members - 1) / members
+
+	// the shards beyond the fair share are not renewed, the lowest ones are kept
+	kept := 0
+	var acquired []int
+	for _, shard := range s.order() {
+		lease := shardLeases[shardLeaseName(shard)]
+		if lease != nil && isHeldBy(lease, s.Identity) && !isExpired(lease, now) && kept < fairShare {
+			if err := s.renewShard(ctx, lease, now); err != nil {
+				s.release(shard)
+				chaosTypes.Log.Error(err, "unable to renew the shard lease", "shard", shard)
+				continue
+			}
+			s.own(shard, now)
+			kept++
+			continue
+		}
+		s.release(shard)
+	}
+	for _, shard := range s.order() {
+		if kept >= fairShare {
+			break
+		}
+		lease := shardLeases[shardLeaseName(shard)]
+		if s.isOwned(shard) || (lease != nil && !isExpired(lease, now)) {
+			continue
+		}
+		isAcquired, err := s.acquireShard(ctx, shard, lease, now)
+		if err != nil {
+			chaosTypes.Log.Error(err, "unable to acquire the shard lease", "shard", shard)
+			continue
+		}
+		if isAcquired {
+			s.own(shard, now)
+			acquired = append(acquired, shard)
+			kept++
+		}
+	}
+	metrics.OwnedShards.Set(float64(kept))
+
+	for _, shard := range acquired {
+		chaosTypes.Log.Info("acquired the shard", "shard", shard, "identity", s.Identity)
+	}
+	if len(acquired) != 0 {
+		// the objects are sent in background, as the controllers may not have started to receive them yet
+		go func() {
+			for _, shard := range acquired {
+				if err := s.notify(ctx, shard); err != nil {
+					chaosTypes.Log.Error(err, "unable to reconcile the objects of the acquired shard", "shard", shard)
+				}
+			}
+		}()
+	}
+	return nil
+}
+
+// order returns the shards starting from an offset derived from the identity, so that the
+// replicas do not contend for the same free shards
+func (s *Sharder) order() []int {
+	h := fnv.New32a()
+	h.Write([]byte(s.Identity))
+	offset := int(h.Sum32() % uint32(s.Shards))
+	shards := make([]int, 0, s.Shards)
+	for i := 0; i < s.Shards; i++ {
+		shards = append(shards, (offset+i)%s.Shards)
+	}
+	// the owned shards come first, to keep renewing the same ones across the syncs
+	sort.SliceStable(shards, func(i, j int) bool {
+		return s.isOwned(shards[i]) && !s.isOwned(shards[j])
+	})
+	return shards
+}
+
+func (s *Sharder) own(shard int, renewed time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.owned == nil {
+		s.owned = map[int]time.Time{}
+	}
+	s.owned[shard] = renewed
+}
+
+func (s *Sharder) release(shard int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.owned[shard]; ok {
+		chaosTypes.Log.Info("released the shard", "shard", shard, "identity", s.Identity)
+		delete(s.owned, shard)
+	}
+}
+
+// renewMember creates or renews the member lease of this replica
+func (s *Sharder) renewMember(ctx context.Context, now time.Time) error {
+	lease := &coordinationv1.Lease{}
+	err := s.Client.Get(ctx, types.NamespacedName{Name: memberLeasePrefix + s.Identity, Namespace: s.Namespace}, lease)
+	switch {
+	case k8serrors.IsNotFound(err):
+		lease = s.newLease(memberLeasePrefix+s.Identity, leaseKindMember, now)
+		if err := s.Client.Create(ctx, lease); err != nil {
+			return fmt.Errorf("unable to create the member lease, due to error: %v", err)
+		}
+		return nil
+	case err != nil:
+		return fmt.Errorf("unable to get the member lease, due to error: %v", err)
+	}
+	renewTime := metav1.NewMicroTime(now)
+	lease.Spec.RenewTime = &renewTime
+	if err := s.Client.Update(ctx, lease); err != nil {
+		return fmt.Errorf("unable to renew the member lease, due to error: %v", err)
+	}
+	return nil
+}
+
+// renewShard renews the held shard lease, failing on a conflict with another replica
+func (s *Sharder) renewShard(ctx context.Context, lease *coordinationv1.Lease, now time.Time) error {
+	renewTime := metav1.NewMicroTime(now)
+	lease.Spec.RenewTime = &renewTime
+	return s.Client.Update(ctx, lease)
+}
+
+// acquireShard creates the missing shard lease, or takes over the expired one. It returns false
+// if another replica has acquired it in the meantime
+func (s *Sharder) acquireShard(ctx context.Context, shard int, lease *coordinationv1.Lease, now time.Time) (bool, error) {
+	if lease == nil {
+		err := s.Client.Create(ctx, s.newLease(shardLeaseName(shard), leaseKindShard, now))
+		if k8serrors.IsAlreadyExists(err) {
+			return false, nil
+		}
+		return err == nil, err
+	}
+
+	transitions := int32(1)
+	if lease.Spec.LeaseTransitions != nil {
+		transitions = *lease.Spec.LeaseTransitions + 1
+	}
+	acquireTime := metav1.NewMicroTime(now)
+	durationSeconds := int32(s.LeaseDuration / time.Second)
+	lease.Spec.HolderIdentity = &s.Identity
+	lease.Spec.LeaseDurationSeconds = &durationSeconds
+	lease.Spec.AcquireTime = &acquireTime
+	lease.Spec.RenewTime = &acquireTime
+	lease.Spec.LeaseTransitions = &transitions
+	// the update carries the resourceVersion of the expired lease, so only one replica takes it over
+	err := s.Client.Update(ctx, lease)
+	if k8serrors.IsConflict(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (s *Sharder) newLease(name, kind string, now time.Time) *coordinationv1.Lease {
+	renewTime := metav1.NewMicroTime(now)
+	durationSeconds := int32(s.LeaseDuration / time.Second)
+	transitions := int32(0)
+	return &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: s.Namespace,
+			Labels:    map[string]string{leaseLabel: kind},
+		},
+		Spec: coordinationv1.LeaseSpec{
+			HolderIdentity:       &s.Identity,
+			LeaseDurationSeconds: &durationSeconds,
+			AcquireTime:          &renewTime,
+			RenewTime:            &renewTime,
+			LeaseTransitions:     &transitions,
+		},
+	}
+}
+
+// notify sends the objects of the subscriptions in the namespaces of the given shard
+func (s *Sharder) notify(ctx context.Context, shard int) error {
+	for _, sub := range s.subscriptions {
+		list := sub.list.DeepCopyObject().(client.ObjectList)
+		if err := s.Reader.List(ctx, list); err != nil {
+			return fmt.Errorf("unable to list the objects of the acquired shard, due to error: %v", err)
+		}
+		objects, err := meta.ExtractList(list)
+		if err != nil {
+			return err
+		}
+		for _, object := range objects {
+			obj, ok := object.(client.Object)
+			if !ok {
+				continue
+			}
+			if objShard, err := s.ShardOf(ctx, obj.GetNamespace()); err != nil || objShard != shard {
+				continue
+			}
+			select {
+			case sub.events <- event.GenericEvent{Object: obj}:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Sharder) clock() time.Time {
+	if s.now != nil {
+		return s.now()
+	}
+	return time.Now()
+}
+
+func shardLeaseName(shard int) string {
+	return shardLeasePrefix + strconv.Itoa(shard)
+}
+
+func isHeldBy(lease *coordinationv1.Lease, identity string) bool {
+	return lease.Spec.HolderIdentity != nil && *lease.Spec.HolderIdentity == identity
+}
+
+// isExpired returns true if the lease has not been renewed within its duration
+func isExpired(lease *coordinationv1.Lease, now time.Time) bool {
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return true
+	}
+	return !now.Before(lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second))
+}
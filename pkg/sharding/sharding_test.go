@@ -0,0 +1,221 @@
+/*
+Copyright 2019 LitmusChaos Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+   http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newTestSharder(t *testing.T, c client.Client, identity string, now *time.Time) *Sharder {
+	s, err := NewSharder(c, c, "litmus", identity, 4, ModeHash, 15*time.Second)
+	if err != nil {
+		t.Fatalf("unable to create sharder, due to error: %v", err)
+	}
+	s.now = func() time.Time { return *now }
+	return s
+}
+
+func TestNewSharder(t *testing.T) {
+	tests := map[string]struct {
+		shards    int
+		mode      string
+		namespace string
+		isErr     bool
+	}{
+		"Test Positive-1": {
+			shards:    4,
+			namespace: "litmus",
+		},
+		"Test Positive-2": {
+			shards:    4,
+			mode:      ModeLabel,
+			namespace: "litmus",
+		},
+		"Test Negative-1": {
+			shards:    0,
+			namespace: "litmus",
+			isErr:     true,
+		},
+		"Test Negative-2": {
+			shards:    4,
+			mode:      "random",
+			namespace: "litmus",
+			isErr:     true,
+		},
+		"Test Negative-3": {
+			shards: 4,
+			isErr:  true,
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewSharder(nil, nil, mock.namespace, "chaos-operator-0", mock.shards, mock.mode, 0)
+			if mock.isErr && err == nil {
+				t.Fatalf("Test %q failed: expected error not to be nil", name)
+			}
+			if !mock.isErr && err != nil {
+				t.Fatalf("Test %q failed: expected error to be nil, received %v", name, err)
+			}
+		})
+	}
+}
+
+func TestShardOf(t *testing.T) {
+	reader := fake.NewClientBuilder().WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a", Labels: map[string]string{ShardLabel: "3"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-b", Labels: map[string]string{ShardLabel: "7"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-c"}},
+	).Build()
+	hashed := &Sharder{Shards: 4, Mode: ModeHash}
+
+	tests := map[string]struct {
+		mode      string
+		namespace string
+		shard     int
+		isErr     bool
+	}{
+		"Test Positive-1": {
+			mode:      ModeHash,
+			namespace: "team-a",
+			shard:     mustShardOf(t, hashed, "team-a"),
+		},
+		"Test Positive-2": {
+			mode:      ModeLabel,
+			namespace: "team-a",
+			shard:     3,
+		},
+		"Test Positive-3": {
+			mode:      ModeLabel,
+			namespace: "team-b",
+			shard:     mustShardOf(t, hashed, "team-b"),
+		},
+		"Test Positive-4": {
+			mode:      ModeLabel,
+			namespace: "team-c",
+			shard:     mustShardOf(t, hashed, "team-c"),
+		},
+		"Test Negative-1": {
+			mode:      ModeLabel,
+			namespace: "team-d",
+			isErr:     true,
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := &Sharder{Reader: reader, Shards: 4, Mode: mock.mode}
+			shard, err := s.ShardOf(context.Background(), mock.namespace)
+			if mock.isErr {
+				if err == nil {
+					t.Fatalf("Test %q failed: expected error not to be nil", name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Test %q failed: expected error to be nil, received %v", name, err)
+			}
+			if shard != mock.shard {
+				t.Fatalf("Test %q failed: expected shard %v, received %v", name, mock.shard, shard)
+			}
+		})
+	}
+}
+
+func mustShardOf(t *testing.T, s *Sharder, namespace string) int {
+	shard, err := s.ShardOf(context.Background(), namespace)
+	if err != nil {
+		t.Fatalf("unable to get the shard of %v, due to error: %v", namespace, err)
+	}
+	if shard < 0 || shard >= s.Shards {
+		t.Fatalf("shard %v of %v is out of range", shard, namespace)
+	}
+	return shard
+}
+
+func TestOwnsNilSharder(t *testing.T) {
+	var s *Sharder
+	if owned, err := s.Owns(context.Background(), "litmus"); err != nil || !owned {
+		t.Fatalf("expected a nil sharder to own all the namespaces, received %v, %v", owned, err)
+	}
+}
+
+// TestSync scales the replicas from one to two and back, checking the shards
+// are owned by exactly one replica at a time and rebalanced in between
+func TestSync(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewClientBuilder().Build()
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := newTestSharder(t, c, "chaos-operator-a", &now)
+	b := newTestSharder(t, c, "chaos-operator-b", &now)
+
+	assertDisjoint := func(step string) {
+		seen := map[int]bool{}
+		for _, s := range []*Sharder{a, b} {
+			for _, shard := range s.OwnedShards() {
+				if seen[shard] {
+					t.Fatalf("%v: shard %v is owned by more than one replica", step, shard)
+				}
+				seen[shard] = true
+			}
+		}
+	}
+	sync := func(s *Sharder) {
+		if err := s.sync(ctx); err != nil {
+			t.Fatalf("unable to sync %v, due to error: %v", s.Identity, err)
+		}
+		assertDisjoint(s.Identity)
+	}
+
+	sync(a)
+	if owned := a.OwnedShards(); !reflect.DeepEqual(owned, []int{0, 1, 2, 3}) {
+		t.Fatalf("expected the only replica to own all the shards, received %v", owned)
+	}
+
+	// the new replica waits for the extra shards of the first one to expire
+	sync(b)
+	if owned := b.OwnedShards(); len(owned) != 0 {
+		t.Fatalf("expected the new replica to own no shards, received %v", owned)
+	}
+	sync(a)
+	if owned := a.OwnedShards(); len(owned) != 2 {
+		t.Fatalf("expected the first replica to keep its fair share, received %v", owned)
+	}
+	for i := 0; i < 4; i++ {
+		now = now.Add(5 * time.Second)
+		sync(a)
+		sync(b)
+	}
+	if len(a.OwnedShards()) != 2 || len(b.OwnedShards()) != 2 {
+		t.Fatalf("expected the shards to be balanced, received %v and %v", a.OwnedShards(), b.OwnedShards())
+	}
+
+	// the shards of the lost replica are acquired once its leases expire
+	now = now.Add(10 * time.Second)
+	sync(b)
+	if owned := b.OwnedShards(); len(owned) != 2 {
+		t.Fatalf("expected the shards of the lost replica not to be acquired before expiry, received %v", owned)
+	}
+	now = now.Add(10 * time.Second)
+	sync(b)
+	if owned := b.OwnedShards(); !reflect.DeepEqual(owned, []int{0, 1, 2, 3}) {
+		t.Fatalf("expected the remaining replica to own all the shards, received %v", owned)
+	}
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package timeout bounds the api calls of the chaos-operator by the deadlines of the operator config
+package timeout
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/litmuschaos/chaos-operator/pkg/config"
+	"github.com/litmuschaos/chaos-operator/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// operations of the timeouts metric
+const (
+	OperationGet         = "get"
+	OperationList        = "list"
+	OperationCreate      = "create"
+	OperationUpdate      = "update"
+	OperationPatch       = "patch"
+	OperationDelete      = "delete"
+	OperationDeleteAllOf = "deleteallof"
+	OperationReconcile   = "reconcile"
+)
+
+// Client bounds each api call of the wrapped client by the read or the write timeout of the operator
+// config, the calls which time out are counted in the timeouts metric
+type Client struct {
+	client.Client
+	// Config holds the effective operator config, the defaults are used if it is not set
+	Config *config.Store
+}
+
+// NewClient returns the client bounding the api calls of the given client
+func NewClient(c client.Client, store *config.Store) *Client {
+	return &Client{Client: c, Config: store}
+}
+
+// WithReconcileTimeout returns the context bounded by the reconcile timeout of the operator config
+func WithReconcileTimeout(ctx context.Context, store *config.Store) (context.Context, context.CancelFunc) {
+	return withTimeout(ctx, store.Get().Controller.Timeouts.Reconcile.Duration)
+}
+
+// Observe counts the error in the timeouts metric, if it has been caused by the deadline of the context
+func Observe(ctx context.Context, operation, kind string, err error) error {
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		metrics.Timeouts.WithLabelValues(operation, kind).Inc()
+	}
+	return err
+}
+
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+func (c *Client) read(ctx context.Context) (context.Context, context.CancelFunc) {
+	return withTimeout(ctx, c.Config.Get().Controller.Timeouts.Read.Duration)
+}
+
+func (c *Client) write(ctx context.Context) (context.Context, context.CancelFunc) {
+	return withTimeout(ctx, c.Config.Get().Controller.Timeouts.Write.Duration)
+}
+
+// kind returns the kind of the object for the timeouts metric
+func (c *Client) kind(obj client.Object) string {
+	gvk, err := c.Client.GroupVersionKindFor(obj)
+	if err != nil {
+		return "unknown"
+	}
+	return gvk.Kind
+}
+
+// Get retrieves the object within the read timeout
+func (c *Client) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	ctx, cancel := c.read(ctx)
+	defer cancel()
+	return Observe(ctx, OperationGet, c.kind(obj), c.Client.Get(ctx, key, obj, opts...))
+}
+
+// List retrieves the list within the read timeout
+func (c *Client) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	ctx, cancel := c.read(ctx)
+	defer cancel()
+	kind := "unknown"
+	if gvk, err := c.Client.GroupVersionKindFor(list); err == nil {
+		kind = gvk.Kind
+	}
+	return Observe(ctx, OperationList, kind, c.Client.List(ctx, list, opts...))
+}
+
+// Create creates the object within the write timeout
+func (c *Client) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	ctx, cancel := c.write(ctx)
+	defer cancel()
+	return Observe(ctx, OperationCreate, c.kind(obj), c.Client.Create(ctx, obj, opts...))
+}
+
+// Delete deletes the object within the write timeout
+func (c *Client) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	ctx, cancel := c.write(ctx)
+	defer cancel()
+	return Observe(ctx, OperationDelete, c.kind(obj), c.Client.Delete(ctx, obj, opts...))
+}
+
+// Update updates the object within the write timeout
+func (c *Client) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	ctx, cancel := c.write(ctx)
+	defer cancel()
+	return Observe(ctx, OperationUpdate, c.kind(obj), c.Client.Update(ctx, obj, opts...))
+}
+
+// Patch patches the object within the write timeout
+func (c *Client) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	ctx, cancel := c.write(ctx)
+	defer cancel()
+	return Observe(ctx, OperationPatch, c.kind(obj), c.Client.Patch(ctx, obj, patch, opts...))
+}
+
+// DeleteAllOf deletes the matching objects within the write timeout
+func (c *Client) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	ctx, cancel := c.write(ctx)
+	defer cancel()
+	return Observe(ctx, OperationDeleteAllOf, c.kind(obj), c.Client.DeleteAllOf(ctx, obj, opts...))
+}
+
+// Status returns the status writer, bounding its calls by the write timeout
+func (c *Client) Status() client.SubResourceWriter {
+	return &subResourceClient{writer: c.Client.Status(), c: c}
+}
+
+// SubResource returns the subresource client, bounding its calls by the read and the write timeouts
+func (c *Client) SubResource(subResource string) client.SubResourceClient {
+	sub := c.Client.SubResource(subResource)
+	return &subResourceClient{reader: sub, writer: sub, c: c}
+}
+
+// subResourceClient bounds the calls of the subresource reader and writer
+type subResourceClient struct {
+	reader client.SubResourceReader
+	writer client.SubResourceWriter
+	c      *Client
+}
+
+func (s *subResourceClient) Get(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceGetOption) error {
+	ctx, cancel := s.c.read(ctx)
+	defer cancel()
+	return Observe(ctx, OperationGet, s.c.kind(obj), s.reader.Get(ctx, obj, subResource, opts...))
+}
+
+func (s *subResourceClient) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	ctx, cancel := s.c.write(ctx)
+	defer cancel()
+	return Observe(ctx, OperationCreate, s.c.kind(obj), s.writer.Create(ctx, obj, subResource, opts...))
+}
+
+func (s *subResourceClient) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	ctx, cancel := s.c.write(ctx)
+	defer cancel()
+	return Observe(ctx, OperationUpdate, s.c.kind(obj), s.writer.Update(ctx, obj, opts...))
+}
+
+func (s *subResourceClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	ctx, cancel := s.c.write(ctx)
+	defer cancel()
+	return Observe(ctx, OperationPatch, s.c.kind(obj), s.writer.Patch(ctx, obj, patch, opts...))
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2019 LitmusChaos Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+   http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package timeout
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/litmuschaos/chaos-operator/pkg/config"
+	"github.com/litmuschaos/chaos-operator/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// blockingClient returns a fake client whose calls block till their context is done
+func blockingClient(scheme *runtime.Scheme) client.WithWatch {
+	return fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+		SubResourcePatch: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	}).Build()
+}
+
+func TestClient(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("unable to build the scheme, due to error: %v", err)
+	}
+	operatorConfig := config.Default()
+	operatorConfig.Controller.Timeouts.Read = metav1.Duration{Duration: 10 * time.Millisecond}
+	operatorConfig.Controller.Timeouts.Write = metav1.Duration{Duration: 20 * time.Millisecond}
+	c := NewClient(blockingClient(scheme), config.NewStore(operatorConfig))
+
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "runner", Namespace: "litmus"}}
+	tests := map[string]struct {
+		call      func(ctx context.Context) error
+		operation string
+		timeout   time.Duration
+	}{
+		"Test Negative-1": {
+			call: func(ctx context.Context) error {
+				return c.Get(ctx, client.ObjectKeyFromObject(pod), &corev1.Pod{})
+			},
+			operation: OperationGet,
+			timeout:   10 * time.Millisecond,
+		},
+		"Test Negative-2": {
+			call: func(ctx context.Context) error {
+				return c.Create(ctx, pod.DeepCopy())
+			},
+			operation: OperationCreate,
+			timeout:   20 * time.Millisecond,
+		},
+		"Test Negative-3": {
+			call: func(ctx context.Context) error {
+				return c.Status().Patch(ctx, pod.DeepCopy(), client.MergeFrom(pod))
+			},
+			operation: OperationPatch,
+			timeout:   20 * time.Millisecond,
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			before := testutil.ToFloat64(metrics.Timeouts.WithLabelValues(mock.operation, "Pod"))
+			start := time.Now()
+			err := mock.call(context.Background())
+			if !errors.Is(err, context.DeadlineExceeded) {
+				t.Fatalf("Test %q failed: expected the call to time out, received %v", name, err)
+			}
+			if elapsed := time.Since(start); elapsed < mock.timeout || elapsed > time.Second {
+				t.Fatalf("Test %q failed: expected the call to time out after %v, returned after %v", name, mock.timeout, elapsed)
+			}
+			if after := testutil.ToFloat64(metrics.Timeouts.WithLabelValues(mock.operation, "Pod")); after != before+1 {
+				t.Fatalf("Test %q failed: expected the timeout to be counted, received %v", name, after-before)
+			}
+		})
+	}
+}
+
+func TestClientWithoutTimeout(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatalf("unable to build the scheme, due to error: %v", err)
+	}
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "runner", Namespace: "litmus"}}
+	c := NewClient(fake.NewClientBuilder().WithScheme(scheme).WithObjects(pod).Build(), nil)
+
+	// the calls which succeed, or fail before their deadline, are not counted
+	before := testutil.ToFloat64(metrics.Timeouts.WithLabelValues(OperationGet, "Pod"))
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(pod), &corev1.Pod{}); err != nil {
+		t.Fatalf("expected the pod to be retrieved, received %v", err)
+	}
+	if err := c.Get(context.Background(), client.ObjectKey{Namespace: "litmus", Name: "unknown"}, &corev1.Pod{}); err == nil {
+		t.Fatalf("expected the unknown pod not to be found")
+	}
+	if after := testutil.ToFloat64(metrics.Timeouts.WithLabelValues(OperationGet, "Pod")); after != before {
+		t.Fatalf("expected no timeouts to be counted, received %v", after-before)
+	}
+}
+
+func TestWithReconcileTimeout(t *testing.T) {
+	operatorConfig := config.Default()
+	operatorConfig.Controller.Timeouts.Reconcile = metav1.Duration{Duration: time.Minute}
+	ctx, cancel := WithReconcileTimeout(context.Background(), config.NewStore(operatorConfig))
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	if !ok || time.Until(deadline) > time.Minute {
+		t.Fatalf("expected the reconcile deadline within a minute, received %v", deadline)
+	}
+}
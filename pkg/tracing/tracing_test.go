@@ -0,0 +1,87 @@
+/*
+Copyright 2019 LitmusChaos Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+   http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSetup(t *testing.T) {
+	tests := map[string]struct {
+		exporter string
+		endpoint string
+		isErr    bool
+	}{
+		"Test Positive-1": {
+			exporter: ExporterNone,
+		},
+		"Test Positive-2": {
+			exporter: ExporterFile,
+			endpoint: filepath.Join(t.TempDir(), "traces.json"),
+		},
+		"Test Negative-1": {
+			exporter: ExporterFile,
+			isErr:    true,
+		},
+		"Test Negative-2": {
+			exporter: "jaeger",
+			isErr:    true,
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			shutdown, err := Setup(context.TODO(), mock.exporter, mock.endpoint)
+			if mock.isErr != (err != nil) {
+				t.Fatalf("Test %q failed: expected error to be %v, received %v", name, mock.isErr, err)
+			}
+			if err == nil {
+				if err := shutdown(context.TODO()); err != nil {
+					t.Fatalf("Test %q failed: unable to shutdown tracer provider: %v", name, err)
+				}
+			}
+		})
+	}
+}
+
+func TestTraceParent(t *testing.T) {
+	if traceParent := TraceParent(context.TODO()); traceParent != "" {
+		t.Fatalf("Test failed: expected empty traceparent for an untraced context, received %q", traceParent)
+	}
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(sdktrace.NewTracerProvider())
+
+	ctx, span := StartSpan(context.TODO(), "Reconcile")
+	traceParent := TraceParent(ctx)
+	EndSpan(span, errors.New("unable to create runner"))
+
+	if !strings.HasPrefix(traceParent, "00-"+span.SpanContext().TraceID().String()+"-"+span.SpanContext().SpanID().String()) {
+		t.Fatalf("Test failed: unexpected traceparent %q", traceParent)
+	}
+	spans := exporter.GetSpans()
+	if len(spans) != 1 || spans[0].Status.Code != codes.Error {
+		t.Fatalf("Test failed: expected an errored span to be exported, received %v", spans)
+	}
+}
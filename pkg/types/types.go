@@ -57,4 +57,6 @@ type EngineInfo struct {
 	Targets        string
 	VolumeOpts     utils.VolumeOpts
 	AppExperiments []string
+	// TraceParent is the w3c trace context propagated to the runner
+	TraceParent string
 }
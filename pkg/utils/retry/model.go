@@ -0,0 +1,103 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Action defines the prototype of action function, function as a value
+type Action func(attempt uint) error
+
+// Model defines the schema, contains all the attributes need for retry
+// It is the builder of the retries with a constant wait, see Retrier for the backoff and the context
+type Model struct {
+	retry    uint
+	waitTime time.Duration
+	timeout  int64
+}
+
+// Times is used to define the retry count
+// it will run if the instance of model is not present before
+func Times(retry uint) *Model {
+	model := Model{}
+	return model.Times(retry)
+}
+
+// Times is used to define the retry count
+// it will run if the instance of model is already present
+func (model *Model) Times(retry uint) *Model {
+	model.retry = retry
+	return model
+}
+
+// Wait is used to define the wait duration after each iteration of retry
+// it will run if the instance of model is not present before
+func Wait(waitTime time.Duration) *Model {
+	model := Model{}
+	return model.Wait(waitTime)
+}
+
+// Wait is used to define the wait duration after each iteration of retry
+// it will run if the instance of model is already present
+func (model *Model) Wait(waitTime time.Duration) *Model {
+	model.waitTime = waitTime
+	return model
+}
+
+// Timeout is used to define the timeout duration for each iteration of retry
+// it will run if the instance of model is not present before
+func Timeout(timeout int64) *Model {
+	model := Model{}
+	return model.Timeout(timeout)
+}
+
+// Timeout is used to define the timeout duration for each iteration of retry
+// it will run if the instance of model is already present
+func (model *Model) Timeout(timeout int64) *Model {
+	model.timeout = timeout
+	return model
+}
+
+// retrier returns the retrier of the model, which stops on the ErrTerminated errors
+func (model Model) retrier() Retrier {
+	return Retrier{
+		MaxAttempts: model.retry + 1,
+		Backoff:     Constant(model.waitTime),
+		Classifier: func(err error) bool {
+			return !errors.Is(err, ErrTerminated)
+		},
+	}
+}
+
+// Try is used to run a action with retries and some delay after each iteration
+func (model Model) Try(action Action) error {
+	return model.TryWithContext(context.Background(), action)
+}
+
+// TryWithContext is used to run a action with retries and some delay after each iteration,
+// till the context is done
+func (model Model) TryWithContext(ctx context.Context, action Action) error {
+	if action == nil {
+		return fmt.Errorf("no action specified")
+	}
+	return model.retrier().Do(ctx, func(_ context.Context, attempt uint) error {
+		return action(attempt)
+	})
+}
+
+// TryWithTimeout is used to run a action with retries
+// for each iteration of retry the action is repeated till it succeeds or the timeout expires
+func (model Model) TryWithTimeout(action Action) error {
+	if action == nil {
+		return fmt.Errorf("no action specified")
+	}
+	return model.retrier().Do(context.Background(), func(ctx context.Context, attempt uint) error {
+		ctx, cancel := context.WithTimeout(ctx, time.Duration(model.timeout)*time.Second)
+		defer cancel()
+		return Retrier{Backoff: Constant(model.waitTime)}.Do(ctx, func(context.Context, uint) error {
+			return action(attempt)
+		})
+	})
+}
@@ -1,104 +1,179 @@
+// Package retry runs the actions with retries, waiting as per the backoff between the attempts
 package retry
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math"
+	"math/rand"
+	"sync"
 	"time"
 
-	"github.com/pkg/errors"
+	"github.com/go-logr/logr"
 )
 
-// Action defines the prototype of action function, function as a value
-type Action func(attempt uint) error
+// ErrTerminated is returned by the actions awaiting a container which has terminated, it is not retried
+var ErrTerminated = errors.New("container is in terminated state")
+
+// ContextAction defines the prototype of the action retried with a context,
+// the context is cancelled on the timeout of the attempt
+type ContextAction func(ctx context.Context, attempt uint) error
+
+// Classifier returns true if the error of an attempt is retryable
+type Classifier func(err error) bool
+
+// Hook is called after each failed attempt which is retried, with the delay before the next attempt
+type Hook func(attempt uint, err error, delay time.Duration)
+
+// Backoff defines the delays between the attempts
+type Backoff struct {
+	// Initial is the delay after the first attempt
+	Initial time.Duration
+	// Factor multiplies the delay after every attempt, a factor up to 1 keeps the delay constant
+	Factor float64
+	// Max caps the delay, it is not capped if zero
+	Max time.Duration
+	// Jitter randomizes the delay by up to the given fraction of it, in either direction
+	Jitter float64
+}
 
-// Model defines the schema, contains all the attributes need for retry
-type Model struct {
-	retry    uint
-	waitTime time.Duration
-	timeout  int64
+// Constant returns a backoff with the same delay between all the attempts
+func Constant(delay time.Duration) Backoff {
+	return Backoff{Initial: delay}
 }
 
-// Times is used to define the retry count
-// it will run if the instance of model is not present before
-func Times(retry uint) *Model {
-	model := Model{}
-	return model.Times(retry)
+// Exponential returns a backoff doubling the delay after every attempt till max, with 10% jitter
+func Exponential(initial, max time.Duration) Backoff {
+	return Backoff{Initial: initial, Factor: 2, Max: max, Jitter: 0.1}
 }
 
-// Times is used to define the retry count
-// it will run if the instance of model is already present
-func (model *Model) Times(retry uint) *Model {
-	model.retry = retry
-	return model
+var (
+	randMu sync.Mutex
+	rnd    = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// Delay returns the delay after the given attempt, counted from zero
+func (backoff Backoff) Delay(attempt uint) time.Duration {
+	delay := float64(backoff.Initial)
+	if backoff.Factor > 1 {
+		delay *= math.Pow(backoff.Factor, float64(attempt))
+	}
+	if backoff.Max > 0 && delay > float64(backoff.Max) {
+		delay = float64(backoff.Max)
+	}
+	if backoff.Jitter > 0 {
+		randMu.Lock()
+		delay += delay * backoff.Jitter * (2*rnd.Float64() - 1)
+		randMu.Unlock()
+		if backoff.Max > 0 && delay > float64(backoff.Max) {
+			delay = float64(backoff.Max)
+		}
+	}
+	if delay < 0 {
+		return 0
+	}
+	return time.Duration(delay)
 }
 
-// Wait is used to define the wait duration after each iteration of retry
-// it will run if the instance of model is not present before
-func Wait(waitTime time.Duration) *Model {
-	model := Model{}
-	return model.Wait(waitTime)
+// permanentError marks an error which is not retried
+type permanentError struct {
+	err error
 }
 
-// Wait is used to define the wait duration after each iteration of retry
-// it will run if the instance of model is already present
-func (model *Model) Wait(waitTime time.Duration) *Model {
-	model.waitTime = waitTime
-	return model
+func (e *permanentError) Error() string { return e.err.Error() }
+
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent marks the error as not retryable, irrespective of the classifier
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
 }
 
-// Timeout is used to define the timeout duration for each iteration of retry
-// it will run if the instance of model is not present before
-func Timeout(timeout int64) *Model {
-	model := Model{}
-	return model.Timeout(timeout)
+// IsPermanent returns true if the error is marked as not retryable
+func IsPermanent(err error) bool {
+	var permanent *permanentError
+	return errors.As(err, &permanent)
 }
 
-// Timeout is used to define the timeout duration for each iteration of retry
-// it will run if the instance of model is already present
-func (model *Model) Timeout(timeout int64) *Model {
-	model.timeout = timeout
-	return model
+// Retrier runs an action till it succeeds, the attempts are exhausted, its error is
+// not retryable, or the context is done
+type Retrier struct {
+	// MaxAttempts is the maximum number of attempts, the attempts are bounded only by the context if zero
+	MaxAttempts uint
+	// Backoff defines the delays between the attempts
+	Backoff Backoff
+	// AttemptTimeout bounds each attempt through the context passed to the action, if it is set
+	AttemptTimeout time.Duration
+	// Classifier returns true for the retryable errors, all the errors are retryable if it is not set.
+	// The errors marked as Permanent are never retried
+	Classifier Classifier
+	// OnRetry is called before waiting for each retry, e.g. to log or count the retries
+	OnRetry Hook
 }
 
-// Try is used to run a action with retries and some delay after each iteration
-func (model Model) Try(action Action) error {
+// Do runs the action with retries. It returns nil on success, or else the error of the last attempt.
+// The context error is returned only if the context is done before the first attempt has failed
+func (retrier Retrier) Do(ctx context.Context, action ContextAction) error {
 	if action == nil {
 		return fmt.Errorf("no action specified")
 	}
 
 	var err error
-	for attempt := uint(0); (attempt == 0 || err != nil) && attempt <= model.retry; attempt++ {
-		err = action(attempt)
-		if model.waitTime > 0 {
-			time.Sleep(model.waitTime)
+	for attempt := uint(0); retrier.MaxAttempts == 0 || attempt < retrier.MaxAttempts; attempt++ {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			if err != nil {
+				return err
+			}
+			return ctxErr
+		}
+
+		err = retrier.attempt(ctx, action, attempt)
+		if err == nil || !retrier.isRetryable(err) {
+			return err
 		}
-		if err == errors.Errorf("container is in terminated state") {
+		if retrier.MaxAttempts != 0 && attempt+1 >= retrier.MaxAttempts {
 			break
 		}
-	}
 
+		delay := retrier.Backoff.Delay(attempt)
+		if retrier.OnRetry != nil {
+			retrier.OnRetry(attempt, err, delay)
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
 	return err
 }
 
-// TryWithTimeout is used to run a action with retries
-// for each iteration of retry there will be some timeout
-func (model Model) TryWithTimeout(action Action) error {
-	if action == nil {
-		return fmt.Errorf("no action specified")
+// attempt runs the action once, within the attempt timeout if it is set
+func (retrier Retrier) attempt(ctx context.Context, action ContextAction, attempt uint) error {
+	if retrier.AttemptTimeout <= 0 {
+		return action(ctx, attempt)
 	}
-	var err error
-	err = nil
-	for attempt := uint(0); (attempt == 0 || err != nil) && attempt <= model.retry; attempt++ {
-		startTime := time.Now().Unix()
-		currentTime := time.Now().Unix()
-		for trial := uint(0); (trial == 0 || err != nil) && currentTime < startTime+model.timeout; trial++ {
-			err = action(attempt)
-			if model.waitTime > 0 {
-				time.Sleep(model.waitTime)
-			}
-			currentTime = time.Now().Unix()
-		}
+	ctx, cancel := context.WithTimeout(ctx, retrier.AttemptTimeout)
+	defer cancel()
+	return action(ctx, attempt)
+}
 
+func (retrier Retrier) isRetryable(err error) bool {
+	if IsPermanent(err) {
+		return false
 	}
+	return retrier.Classifier == nil || retrier.Classifier(err)
+}
 
-	return err
+// LogRetries returns a hook which logs the failed attempts of the operation
+func LogRetries(logger logr.Logger, operation string) Hook {
+	return func(attempt uint, err error, delay time.Duration) {
+		logger.V(1).Info("retrying the failed attempt", "operation", operation, "attempt", attempt, "delay", delay.String(), "error", err.Error())
+	}
 }
@@ -0,0 +1,222 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+var errTransient = errors.New("transient error")
+
+func TestBackoffDelay(t *testing.T) {
+	tests := map[string]struct {
+		backoff  Backoff
+		attempt  uint
+		min, max time.Duration
+	}{
+		"Test Positive-1": {
+			backoff: Constant(time.Second),
+			attempt: 5,
+			min:     time.Second,
+			max:     time.Second,
+		},
+		"Test Positive-2": {
+			backoff: Backoff{Initial: time.Second, Factor: 2},
+			attempt: 3,
+			min:     8 * time.Second,
+			max:     8 * time.Second,
+		},
+		"Test Positive-3": {
+			backoff: Backoff{Initial: time.Second, Factor: 2, Max: 5 * time.Second},
+			attempt: 10,
+			min:     5 * time.Second,
+			max:     5 * time.Second,
+		},
+		"Test Positive-4": {
+			backoff: Backoff{Initial: time.Second, Factor: 2, Jitter: 0.5},
+			attempt: 1,
+			min:     time.Second,
+			max:     3 * time.Second,
+		},
+		"Test Positive-5": {
+			backoff: Exponential(time.Second, 4*time.Second),
+			attempt: 8,
+			min:     3600 * time.Millisecond,
+			max:     4 * time.Second,
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				if delay := mock.backoff.Delay(mock.attempt); delay < mock.min || delay > mock.max {
+					t.Fatalf("Test %q failed: expected delay within [%v, %v], received %v", name, mock.min, mock.max, delay)
+				}
+			}
+		})
+	}
+}
+
+func TestRetrierDo(t *testing.T) {
+	tests := map[string]struct {
+		retrier          Retrier
+		failures         int
+		failure          error
+		expectedAttempts int
+		expectedErr      error
+	}{
+		"Test Positive-1": {
+			retrier:          Retrier{MaxAttempts: 3},
+			expectedAttempts: 1,
+		},
+		"Test Positive-2": {
+			retrier:          Retrier{MaxAttempts: 3, Backoff: Constant(time.Millisecond)},
+			failures:         2,
+			failure:          errTransient,
+			expectedAttempts: 3,
+		},
+		"Test Positive-3": {
+			retrier:          Retrier{Backoff: Exponential(time.Millisecond, 2*time.Millisecond)},
+			failures:         5,
+			failure:          errTransient,
+			expectedAttempts: 6,
+		},
+		"Test Negative-1": {
+			retrier:          Retrier{MaxAttempts: 3},
+			failures:         5,
+			failure:          errTransient,
+			expectedAttempts: 3,
+			expectedErr:      errTransient,
+		},
+		"Test Negative-2": {
+			retrier:          Retrier{MaxAttempts: 3},
+			failures:         5,
+			failure:          Permanent(errTransient),
+			expectedAttempts: 1,
+			expectedErr:      errTransient,
+		},
+		"Test Negative-3": {
+			retrier: Retrier{MaxAttempts: 3, Classifier: func(err error) bool {
+				return !errors.Is(err, errTransient)
+			}},
+			failures:         5,
+			failure:          fmt.Errorf("wrapped: %w", errTransient),
+			expectedAttempts: 1,
+			expectedErr:      errTransient,
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			attempts, retries := 0, 0
+			mock.retrier.OnRetry = func(attempt uint, err error, delay time.Duration) {
+				retries++
+			}
+			err := mock.retrier.Do(context.Background(), func(ctx context.Context, attempt uint) error {
+				if int(attempt) != attempts {
+					t.Fatalf("Test %q failed: expected attempt %v, received %v", name, attempts, attempt)
+				}
+				attempts++
+				if attempts <= mock.failures {
+					return mock.failure
+				}
+				return nil
+			})
+			if !errors.Is(err, mock.expectedErr) || (mock.expectedErr == nil && err != nil) {
+				t.Fatalf("Test %q failed: expected error %v, received %v", name, mock.expectedErr, err)
+			}
+			if attempts != mock.expectedAttempts {
+				t.Fatalf("Test %q failed: expected %v attempts, received %v", name, mock.expectedAttempts, attempts)
+			}
+			if retries != attempts-1 && err == nil {
+				t.Fatalf("Test %q failed: expected %v retry hooks, received %v", name, attempts-1, retries)
+			}
+		})
+	}
+}
+
+func TestRetrierCancellation(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := Retrier{Backoff: Constant(time.Hour)}.Do(ctx, func(ctx context.Context, attempt uint) error {
+		return errTransient
+	})
+	if !errors.Is(err, errTransient) {
+		t.Fatalf("expected the error of the last attempt, received %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected the wait to stop on the cancellation, waited %v", elapsed)
+	}
+
+	if err := (Retrier{}).Do(ctx, func(ctx context.Context, attempt uint) error { return nil }); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the context error before the first attempt, received %v", err)
+	}
+}
+
+func TestRetrierAttemptTimeout(t *testing.T) {
+	err := Retrier{MaxAttempts: 2, AttemptTimeout: 10 * time.Millisecond}.Do(context.Background(), func(ctx context.Context, attempt uint) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the attempts to time out, received %v", err)
+	}
+}
+
+func TestModelTry(t *testing.T) {
+	tests := map[string]struct {
+		failure          error
+		expectedAttempts int
+	}{
+		"Test Positive-1": {
+			expectedAttempts: 1,
+		},
+		"Test Negative-1": {
+			failure:          errTransient,
+			expectedAttempts: 3,
+		},
+		"Test Negative-2": {
+			failure:          ErrTerminated,
+			expectedAttempts: 1,
+		},
+	}
+	for name, mock := range tests {
+		t.Run(name, func(t *testing.T) {
+			attempts := 0
+			start := time.Now()
+			err := Times(2).Wait(10 * time.Millisecond).Try(func(attempt uint) error {
+				attempts++
+				return mock.failure
+			})
+			if err != mock.failure {
+				t.Fatalf("Test %q failed: expected error %v, received %v", name, mock.failure, err)
+			}
+			if attempts != mock.expectedAttempts {
+				t.Fatalf("Test %q failed: expected %v attempts, received %v", name, mock.expectedAttempts, attempts)
+			}
+			// the wait follows only the failed attempts which are retried
+			if elapsed := time.Since(start); elapsed > time.Duration(mock.expectedAttempts)*10*time.Millisecond+100*time.Millisecond {
+				t.Fatalf("Test %q failed: waited %v for %v attempts", name, elapsed, attempts)
+			}
+		})
+	}
+}
+
+func TestModelTryWithTimeout(t *testing.T) {
+	attempts := map[uint]int{}
+	err := Times(1).Wait(time.Millisecond).Timeout(1).TryWithTimeout(func(attempt uint) error {
+		attempts[attempt]++
+		if attempts[attempt] < 3 {
+			return errTransient
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected the action to succeed within the timeout, received %v", err)
+	}
+	if len(attempts) != 1 || attempts[0] != 3 {
+		t.Fatalf("expected the first attempt to be repeated thrice, received %v", attempts)
+	}
+}
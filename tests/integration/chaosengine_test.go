@@ -0,0 +1,285 @@
+//go:build integration
+// +build integration
+
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+
+	litmuschaosv1alpha1 "github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/chaos-operator/pkg/fakerunner"
+	"github.com/litmuschaos/chaos-operator/pkg/report"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	engineName     = "nginx-chaos"
+	serviceAccount = "pod-delete-sa"
+	finalizer      = "chaosengine.litmuschaos.io/finalizer"
+)
+
+var _ = Describe("ChaosEngine lifecycle", func() {
+	var (
+		ctx       context.Context
+		namespace string
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		// every test runs in its own namespace, as the namespaces are never removed without the namespace controller
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{GenerateName: "chaos-"}}
+		Expect(k8sClient.Create(ctx, ns)).To(Succeed())
+		namespace = ns.Name
+		// the serviceaccount admission rejects the runner pods of a missing serviceaccount
+		Expect(k8sClient.Create(ctx, &corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{Name: serviceAccount, Namespace: namespace},
+		})).To(Succeed())
+	})
+
+	It("completes the run once the runner has completed", func() {
+		engine := createEngine(ctx, namespace, litmuschaosv1alpha1.CleanUpPolicyDelete)
+		awaitRunning(ctx, engine)
+
+		completeRunner(ctx, engine)
+		awaitStatus(ctx, engine, litmuschaosv1alpha1.EngineStateStop, litmuschaosv1alpha1.EngineStatusCompleted)
+		Expect(getEngine(ctx, engine).Status.CompletionTime).ToNot(BeNil())
+
+		By("deleting the runner pod as per the jobCleanUpPolicy")
+		Eventually(func() bool {
+			return k8serrors.IsNotFound(k8sClient.Get(ctx, runnerKey(engine), &corev1.Pod{}))
+		}, timeout, interval).Should(BeTrue())
+
+		By("publishing the run report")
+		reportConfigMap := &corev1.ConfigMap{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, types.NamespacedName{Name: report.ConfigMapName(engine.Name), Namespace: namespace}, reportConfigMap)
+		}, timeout, interval).Should(Succeed())
+		Expect(reportConfigMap.Data).To(HaveKey(report.JSONKey))
+		Expect(reportConfigMap.Data).To(HaveKey(report.JUnitKey))
+	})
+
+	It("mirrors the chaosresults of the runner into the chaosengine", func() {
+		engine := createEngine(ctx, namespace, litmuschaosv1alpha1.CleanUpPolicyRetain)
+		runner := awaitRunning(ctx, engine)
+
+		By("running the experiments with the fake-runner, in place of the runner pod")
+		env, err := fakerunner.EnvFrom(func(key string) string {
+			for _, e := range runner.Spec.Containers[0].Env {
+				if e.Name == key {
+					return e.Value
+				}
+			}
+			return ""
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(fakerunner.Run(ctx, k8sClient, env, fakerunner.Scenario{
+			Verdict: litmuschaosv1alpha1.ResultVerdictFailed,
+			Probes: []litmuschaosv1alpha1.ProbeStatuses{
+				{Name: "check-frontend", Type: "httpProbe", Mode: "Continuous", Status: litmuschaosv1alpha1.ProbeStatus{Verdict: litmuschaosv1alpha1.ProbeVerdictPassed}},
+				{Name: "check-backend", Type: "cmdProbe", Mode: "EOT", Status: litmuschaosv1alpha1.ProbeStatus{Verdict: litmuschaosv1alpha1.ProbeVerdictFailed}},
+			},
+			Targets:      []string{"deployment/nginx"},
+			TargetStatus: "reverted",
+		})).To(Succeed())
+		completeRunner(ctx, engine)
+		awaitStatus(ctx, engine, litmuschaosv1alpha1.EngineStateStop, litmuschaosv1alpha1.EngineStatusCompleted)
+
+		Eventually(func() []litmuschaosv1alpha1.ExperimentStatuses {
+			return getEngine(ctx, engine).Status.Experiments
+		}, timeout, interval).Should(ConsistOf(And(
+			HaveField("Name", "pod-delete"),
+			HaveField("Verdict", string(litmuschaosv1alpha1.ResultVerdictFailed)),
+			HaveField("ResultPhase", litmuschaosv1alpha1.ResultPhaseCompletedWithProbeFailure),
+			HaveField("ProbeSuccessPercentage", "50"),
+		)))
+		Eventually(func() string {
+			return getEngine(ctx, engine).Status.ResilienceScore
+		}, timeout, interval).Should(Equal("50.00"))
+
+		By("moving the target annotations of the chaosresult into its history")
+		Eventually(func() []litmuschaosv1alpha1.TargetDetails {
+			result := &litmuschaosv1alpha1.ChaosResult{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: engine.Name + "-pod-delete", Namespace: namespace}, result)).To(Succeed())
+			if result.Status.History == nil {
+				return nil
+			}
+			return result.Status.History.Targets
+		}, timeout, interval).Should(ContainElement(litmuschaosv1alpha1.TargetDetails{Name: "nginx", Kind: "deployment", ChaosStatus: "reverted"}))
+	})
+
+	It("stops the run and removes the chaos resources on an abort", func() {
+		engine := createEngine(ctx, namespace, litmuschaosv1alpha1.CleanUpPolicyRetain)
+		awaitRunning(ctx, engine)
+
+		patchEngineState(ctx, engine, litmuschaosv1alpha1.EngineStateStop)
+		awaitStatus(ctx, engine, litmuschaosv1alpha1.EngineStateStop, litmuschaosv1alpha1.EngineStatusStopped)
+
+		Eventually(func() []string {
+			return getEngine(ctx, engine).Finalizers
+		}, timeout, interval).ShouldNot(ContainElement(finalizer))
+		Eventually(func() bool {
+			return k8serrors.IsNotFound(k8sClient.Get(ctx, runnerKey(engine), &corev1.Pod{}))
+		}, timeout, interval).Should(BeTrue())
+	})
+
+	It("restarts the run after an abort", func() {
+		engine := createEngine(ctx, namespace, litmuschaosv1alpha1.CleanUpPolicyRetain)
+		runner := awaitRunning(ctx, engine)
+		patchEngineState(ctx, engine, litmuschaosv1alpha1.EngineStateStop)
+		awaitStatus(ctx, engine, litmuschaosv1alpha1.EngineStateStop, litmuschaosv1alpha1.EngineStatusStopped)
+
+		patchEngineState(ctx, engine, litmuschaosv1alpha1.EngineStateActive)
+		restarted := awaitRunning(ctx, engine)
+		Expect(restarted.UID).ToNot(Equal(runner.UID), "expected a new runner pod")
+		Expect(getEngine(ctx, engine).Finalizers).To(ContainElement(finalizer))
+
+		completeRunner(ctx, engine)
+		awaitStatus(ctx, engine, litmuschaosv1alpha1.EngineStateStop, litmuschaosv1alpha1.EngineStatusCompleted)
+	})
+
+	It("restarts the run after its completion", func() {
+		engine := createEngine(ctx, namespace, litmuschaosv1alpha1.CleanUpPolicyRetain)
+		runner := awaitRunning(ctx, engine)
+		completeRunner(ctx, engine)
+		awaitStatus(ctx, engine, litmuschaosv1alpha1.EngineStateStop, litmuschaosv1alpha1.EngineStatusCompleted)
+		completed := getEngine(ctx, engine)
+
+		patchEngineState(ctx, engine, litmuschaosv1alpha1.EngineStateActive)
+		restarted := awaitRunning(ctx, engine)
+		Expect(restarted.UID).ToNot(Equal(runner.UID), "expected the retained runner pod to be replaced")
+
+		rerun := getEngine(ctx, engine)
+		Expect(rerun.Status.CompletionTime).To(BeNil())
+		Expect(rerun.Status.StartTime.Time).ToNot(BeTemporally("<", completed.Status.StartTime.Time))
+	})
+
+	It("removes the finalizer of a deleted chaosengine", func() {
+		engine := createEngine(ctx, namespace, litmuschaosv1alpha1.CleanUpPolicyRetain)
+		awaitRunning(ctx, engine)
+
+		Expect(k8sClient.Delete(ctx, engine)).To(Succeed())
+		Eventually(func() bool {
+			return k8serrors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(engine), &litmuschaosv1alpha1.ChaosEngine{}))
+		}, timeout, interval).Should(BeTrue())
+	})
+
+	It("rejects the chaosengines which fail the validations of the crd", func() {
+		incompleteAppInfo := newEngine(namespace, litmuschaosv1alpha1.CleanUpPolicyRetain)
+		incompleteAppInfo.Spec.Appinfo.AppKind = ""
+		Expect(k8sClient.Create(ctx, incompleteAppInfo)).To(MatchError(ContainSubstring("appkind and applabel must be specified together")))
+
+		emptySelectors := newEngine(namespace, litmuschaosv1alpha1.CleanUpPolicyRetain)
+		emptySelectors.Spec.Selectors = &litmuschaosv1alpha1.Selector{}
+		Expect(k8sClient.Create(ctx, emptySelectors)).To(MatchError(ContainSubstring("exactly one of pods or workloads must be specified")))
+	})
+})
+
+// newEngine returns an active chaosengine of a single experiment
+func newEngine(namespace string, cleanUpPolicy litmuschaosv1alpha1.CleanUpPolicy) *litmuschaosv1alpha1.ChaosEngine {
+	return &litmuschaosv1alpha1.ChaosEngine{
+		ObjectMeta: metav1.ObjectMeta{Name: engineName, Namespace: namespace},
+		Spec: litmuschaosv1alpha1.ChaosEngineSpec{
+			EngineState: litmuschaosv1alpha1.EngineStateActive,
+			Appinfo: litmuschaosv1alpha1.ApplicationParams{
+				Appns:    namespace,
+				Applabel: "app=nginx",
+				AppKind:  "deployment",
+			},
+			ChaosServiceAccount: serviceAccount,
+			JobCleanUpPolicy:    cleanUpPolicy,
+			Experiments:         []litmuschaosv1alpha1.ExperimentList{{Name: "pod-delete"}},
+		},
+	}
+}
+
+// createEngine creates the chaosengine and waits for its initialization
+func createEngine(ctx context.Context, namespace string, cleanUpPolicy litmuschaosv1alpha1.CleanUpPolicy) *litmuschaosv1alpha1.ChaosEngine {
+	engine := newEngine(namespace, cleanUpPolicy)
+	Expect(k8sClient.Create(ctx, engine)).To(Succeed())
+	awaitStatus(ctx, engine, litmuschaosv1alpha1.EngineStateActive, litmuschaosv1alpha1.EngineStatusInitialized)
+	Expect(getEngine(ctx, engine).Finalizers).To(ContainElement(finalizer))
+	return engine
+}
+
+// getEngine returns the current state of the chaosengine
+func getEngine(ctx context.Context, engine *litmuschaosv1alpha1.ChaosEngine) *litmuschaosv1alpha1.ChaosEngine {
+	current := &litmuschaosv1alpha1.ChaosEngine{}
+	ExpectWithOffset(1, k8sClient.Get(ctx, client.ObjectKeyFromObject(engine), current)).To(Succeed())
+	return current
+}
+
+// awaitStatus waits until the chaosengine has the engineState and the engine status
+func awaitStatus(ctx context.Context, engine *litmuschaosv1alpha1.ChaosEngine, state litmuschaosv1alpha1.EngineState, status litmuschaosv1alpha1.EngineStatus) {
+	EventuallyWithOffset(1, func() []string {
+		current := getEngine(ctx, engine)
+		return []string{string(current.Spec.EngineState), string(current.Status.EngineStatus)}
+	}, timeout, interval).Should(Equal([]string{string(state), string(status)}))
+}
+
+// patchEngineState patches the engineState of the chaosengine, as done by the users to abort or restart it
+func patchEngineState(ctx context.Context, engine *litmuschaosv1alpha1.ChaosEngine, state litmuschaosv1alpha1.EngineState) {
+	patch := client.RawPatch(types.MergePatchType, []byte(`{"spec":{"engineState":"`+string(state)+`"}}`))
+	ExpectWithOffset(1, k8sClient.Patch(ctx, getEngine(ctx, engine), patch)).To(Succeed())
+}
+
+// runnerKey returns the key of the runner pod of the chaosengine
+func runnerKey(engine *litmuschaosv1alpha1.ChaosEngine) types.NamespacedName {
+	return types.NamespacedName{Name: engine.Name + "-runner", Namespace: engine.Namespace}
+}
+
+// awaitRunning waits for the runner pod of the chaosengine, and reports it as running as the kubelet would
+func awaitRunning(ctx context.Context, engine *litmuschaosv1alpha1.ChaosEngine) *corev1.Pod {
+	runner := &corev1.Pod{}
+	EventuallyWithOffset(1, func() error {
+		return k8sClient.Get(ctx, runnerKey(engine), runner)
+	}, timeout, interval).Should(Succeed())
+	ExpectWithOffset(1, runner.Labels).To(HaveKeyWithValue("chaosUID", string(getEngine(ctx, engine).UID)))
+
+	setRunnerStatus(ctx, runner, corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: metav1.Now()}}, true)
+	return runner
+}
+
+// completeRunner reports the runner container of the chaosengine as completed, as the kubelet would
+// once the chaos-runner has run all the experiments
+func completeRunner(ctx context.Context, engine *litmuschaosv1alpha1.ChaosEngine) {
+	runner := &corev1.Pod{}
+	ExpectWithOffset(1, k8sClient.Get(ctx, runnerKey(engine), runner)).To(Succeed())
+	setRunnerStatus(ctx, runner, corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+		Reason:     "Completed",
+		FinishedAt: metav1.Now(),
+	}}, false)
+}
+
+// setRunnerStatus updates the status of the runner pod to the state of its chaos-runner container
+func setRunnerStatus(ctx context.Context, runner *corev1.Pod, state corev1.ContainerState, ready bool) {
+	runner.Status.Phase = corev1.PodRunning
+	runner.Status.ContainerStatuses = []corev1.ContainerStatus{{
+		Name:  "chaos-runner",
+		Image: runner.Spec.Containers[0].Image,
+		State: state,
+		Ready: ready,
+	}}
+	ExpectWithOffset(2, k8sClient.Status().Update(ctx, runner)).To(Succeed())
+}
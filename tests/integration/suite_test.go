@@ -0,0 +1,124 @@
+//go:build integration
+// +build integration
+
+/*
+Copyright 2019 LitmusChaos Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package integration runs the chaosengine controller against a local apiserver and etcd started by envtest.
+// There is no kubelet, so the tests play the part of the chaos-runner by updating the status of the runner pods.
+// Run it with make test-integration, which downloads the binaries of the apiserver and etcd.
+package integration
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	litmuschaosv1alpha1 "github.com/litmuschaos/chaos-operator/api/litmuschaos/v1alpha1"
+	"github.com/litmuschaos/chaos-operator/controllers"
+	"github.com/litmuschaos/chaos-operator/pkg/config"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+const (
+	// timeout bounds the wait for each reconcile outcome
+	timeout = 30 * time.Second
+	// interval is the polling interval of the outcomes
+	interval = 250 * time.Millisecond
+)
+
+var (
+	testEnv   *envtest.Environment
+	k8sClient client.Client
+	cancel    context.CancelFunc
+)
+
+func TestIntegration(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "ChaosEngine controller integration")
+}
+
+var _ = BeforeSuite(func() {
+	logf.SetLogger(zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)))
+
+	By("starting the apiserver and etcd with the crds")
+	testEnv = &envtest.Environment{
+		CRDDirectoryPaths:     []string{filepath.Join("..", "..", "deploy", "crds")},
+		ErrorIfCRDPathMissing: true,
+	}
+	cfg, err := testEnv.Start()
+	Expect(err).ToNot(HaveOccurred())
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(litmuschaosv1alpha1.AddToScheme(scheme))
+
+	// the tests read directly from the apiserver, the reconciler reads from the cache of the manager
+	k8sClient, err = client.New(cfg, client.Options{Scheme: scheme})
+	Expect(err).ToNot(HaveOccurred())
+
+	By("starting the manager with the chaosengine and chaosresult reconcilers")
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:                 scheme,
+		MetricsBindAddress:     "0",
+		HealthProbeBindAddress: "0",
+	})
+	Expect(err).ToNot(HaveOccurred())
+
+	operatorConfig := config.Default()
+	// the runner pods are deleted at once without a kubelet, the termination is not awaited for long
+	operatorConfig.Controller.ChaosPodTerminationTimeout.Duration = 10 * time.Second
+	configStore := config.NewStore(operatorConfig)
+	Expect((&controllers.ChaosEngineReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Recorder:  mgr.GetEventRecorderFor("chaos-operator"),
+		Config:    configStore,
+		APIReader: mgr.GetAPIReader(),
+	}).SetupWithManager(mgr)).To(Succeed())
+	Expect((&controllers.ChaosResultReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		Config: configStore,
+	}).SetupWithManager(mgr)).To(Succeed())
+
+	var ctx context.Context
+	ctx, cancel = context.WithCancel(context.Background())
+	go func() {
+		defer GinkgoRecover()
+		Expect(mgr.Start(ctx)).To(Succeed())
+	}()
+}, 120)
+
+var _ = AfterSuite(func() {
+	By("stopping the manager, the apiserver and etcd")
+	if cancel != nil {
+		cancel()
+	}
+	if testEnv != nil {
+		Expect(testEnv.Stop()).To(Succeed())
+	}
+})